@@ -148,6 +148,11 @@ func (s *SSOTokenStrategy) ApplyAuth(req *http.Request, token string) {
 	req.Header.Set("Authorization", "QB-TEMP-TOKEN "+token)
 }
 
+// Identity returns a hashed key for the realm and SAML token, for sharing throttles.
+func (s *SSOTokenStrategy) Identity() string {
+	return "sso:" + hashIdentity(s.realm, s.samlToken)
+}
+
 // HandleAuthError handles 401 errors by refreshing the SSO token.
 func (s *SSOTokenStrategy) HandleAuthError(ctx context.Context, statusCode int, dbid string, attempt int, maxAttempts int) (string, error) {
 	if statusCode != http.StatusUnauthorized || attempt >= maxAttempts-1 {
@@ -70,6 +70,8 @@ package auth
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"net/http"
 )
 
@@ -105,6 +107,28 @@ type SignOuter interface {
 	SignOut()
 }
 
+// Identifier is an optional interface for strategies that can name the
+// identity QuickBase rate-limits against. Clients whose strategies report
+// the same identity share one throttle (see client.ThrottleRegistry).
+//
+// Identities are hashed, so they never contain credentials.
+// [TempTokenStrategy] does not implement this interface.
+type Identifier interface {
+	// Identity returns a stable, non-secret key for the credentials,
+	// such as "usertoken:3f9a...".
+	Identity() string
+}
+
+// hashIdentity returns a short hex SHA-256 digest of parts, for Identity.
+func hashIdentity(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
 // XMLAuthProvider is an optional interface for strategies that support XML API authentication.
 // The XML API uses different authentication than the JSON API - tokens must be included
 // in the request body as XML elements, not in HTTP headers.
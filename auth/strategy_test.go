@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIdentity(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy Identifier
+		prefix   string
+		secret   string
+	}{
+		{"user token", NewUserTokenStrategy("b9f3pk_secret"), "usertoken:", "b9f3pk_secret"},
+		{"existing ticket", NewExistingTicketStrategy("ticket_secret"), "ticket:", "ticket_secret"},
+		{"ticket", NewTicketStrategy("user@example.com", "password", "myrealm"), "user:", "user@example.com"},
+		{"sso", NewSSOTokenStrategy("saml_secret", "myrealm"), "sso:", "saml_secret"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := tt.strategy.Identity()
+			if !strings.HasPrefix(id, tt.prefix) {
+				t.Errorf("Identity() = %q, want prefix %q", id, tt.prefix)
+			}
+			if strings.Contains(id, tt.secret) {
+				t.Errorf("Identity() = %q leaks credentials", id)
+			}
+			if id != tt.strategy.Identity() {
+				t.Error("Identity() is not stable")
+			}
+		})
+	}
+
+	if NewUserTokenStrategy("a").Identity() == NewUserTokenStrategy("b").Identity() {
+		t.Error("different tokens share an identity")
+	}
+	if NewTicketStrategy("u", "p", "realm1").Identity() == NewTicketStrategy("u", "p", "realm2").Identity() {
+		t.Error("same user in different realms share an identity")
+	}
+
+	var s Strategy = NewTempTokenStrategy("myrealm")
+	if _, ok := s.(Identifier); ok {
+		t.Error("TempTokenStrategy should not implement Identifier")
+	}
+}
@@ -219,6 +219,11 @@ func (s *TicketStrategy) ApplyAuth(req *http.Request, token string) {
 	req.Header.Set("Authorization", "QB-TICKET "+token)
 }
 
+// Identity returns a hashed key for the realm and username, for sharing throttles.
+func (s *TicketStrategy) Identity() string {
+	return "user:" + hashIdentity(s.realm, s.username)
+}
+
 // HandleAuthError handles 401 errors. Since the password is discarded after
 // initial authentication, this returns an empty string to signal that
 // re-authentication is not possible and the user must create a new client.
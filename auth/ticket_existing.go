@@ -44,6 +44,11 @@ func (s *ExistingTicketStrategy) ApplyAuth(req *http.Request, token string) {
 	req.Header.Set("Authorization", "QB-TICKET "+token)
 }
 
+// Identity returns a hashed key for the ticket, for sharing throttles.
+func (s *ExistingTicketStrategy) Identity() string {
+	return "ticket:" + hashIdentity(s.ticket)
+}
+
 // HandleAuthError handles 401 errors. Since we don't have credentials,
 // we cannot re-authenticate - the ticket has expired.
 func (s *ExistingTicketStrategy) HandleAuthError(ctx context.Context, statusCode int, dbid string, attempt int, maxAttempts int) (string, error) {
@@ -34,6 +34,11 @@ func (s *UserTokenStrategy) ApplyAuth(req *http.Request, token string) {
 	req.Header.Set("Authorization", "QB-USER-TOKEN "+token)
 }
 
+// Identity returns a hashed key for the user token, for sharing throttles.
+func (s *UserTokenStrategy) Identity() string {
+	return "usertoken:" + hashIdentity(s.token)
+}
+
 // HandleAuthError handles 401 errors by returning the same token for retry.
 // User tokens can't be refreshed, so we just retry with the same token.
 func (s *UserTokenStrategy) HandleAuthError(ctx context.Context, statusCode int, dbid string, attempt int, maxAttempts int) (string, error) {
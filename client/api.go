@@ -122,45 +122,38 @@ func (c *Client) RunQuery(ctx context.Context, body generated.RunQueryJSONReques
 		return nil, parseAPIError(resp.StatusCode(), resp.Body, resp.HTTPResponse)
 	}
 
-	return &RunQueryResult{resp: resp}, nil
+	return &RunQueryResult{resp: resp, numbers: c.numbers}, nil
 }
 
 // transformRunQueryBody transforms a RunQuery body, resolving table alias to ID.
-// Also transforms where clause field aliases.
+// Also validates the where clause and transforms its field aliases, so malformed
+// queries fail with a SchemaError before any request is sent.
 func (c *Client) transformRunQueryBody(body generated.RunQueryJSONRequestBody) (generated.RunQueryJSONRequestBody, string, error) {
 	tableID := body.From
-
-	if c.schema == nil {
-		return body, tableID, nil
-	}
-
 	result := body
 
-	// Resolve table alias in 'from'
-	resolvedTableID, err := core.ResolveTableAlias(c.schema, body.From)
-	if err != nil {
-		return body, "", err
+	if c.schema != nil {
+		// Resolve table alias in 'from'
+		resolvedTableID, err := core.ResolveTableAlias(c.schema, body.From)
+		if err != nil {
+			return body, "", err
+		}
+		result.From = resolvedTableID
+		tableID = resolvedTableID
 	}
-	result.From = resolvedTableID
-	tableID = resolvedTableID
-
-	// Transform where clause field aliases
-	if body.Where != nil {
-		// Extract the where string from the union type
-		if whereStr, ok := extractWhereString(body.Where); ok {
-			// Transform field aliases in where clause
-			bodyMap := map[string]any{
-				"from":  body.From,
-				"where": whereStr,
-			}
-			transformed, _, _ := core.TransformRequest(bodyMap, c.schema)
-			if transformedWhere, ok := transformed["where"].(string); ok {
-				// Convert back to union type
-				whereUnion, err := StringToWhereUnion(transformedWhere)
-				if err == nil {
-					result.Where = whereUnion
-				}
+
+	// Validate where clause and transform field aliases
+	if whereStr, ok := extractWhereString(body.Where); ok {
+		transformedWhere, err := core.TransformWhere(whereStr, c.schema, tableID)
+		if err != nil {
+			return body, "", err
+		}
+		if transformedWhere != whereStr {
+			whereUnion, err := StringToWhereUnion(transformedWhere)
+			if err != nil {
+				return body, "", err
 			}
+			result.Where = whereUnion
 		}
 	}
 
@@ -186,12 +179,9 @@ func (c *Client) RunQueryN(ctx context.Context, body generated.RunQueryJSONReque
 // runQueryFetcher creates a page fetcher for RunQuery
 func (c *Client) runQueryFetcher(body generated.RunQueryJSONRequestBody) PageFetcher[generated.QuickbaseRecord, *runQueryPageResponse] {
 	return func(ctx context.Context, skip int, nextToken string) (*runQueryPageResponse, error) {
-		// Create a copy of body with updated skip
+		// Create a copy of body with updated skip, leaving the caller's options untouched
 		bodyCopy := body
-		if bodyCopy.Options == nil {
-			bodyCopy.Options = &generated.RunQueryJSONBody_Options{}
-		}
-		bodyCopy.Options.Skip = &skip
+		bodyCopy.Options = withSkip(body.Options, skip)
 
 		resp, err := c.API().RunQueryWithResponse(ctx, bodyCopy)
 		if err != nil {
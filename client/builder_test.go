@@ -1,8 +1,14 @@
 package client
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/auth"
 	"github.com/DrewBradfordXYZ/quickbase-go/v2/core"
 	"github.com/DrewBradfordXYZ/quickbase-go/v2/generated"
 )
@@ -639,6 +645,36 @@ func TestDeleteRecordsBuilder_Chaining(t *testing.T) {
 	}
 }
 
+func TestDeleteRecordsBuilder_WhereCondition(t *testing.T) {
+	c := &Client{schema: testSchema(map[string]core.TableSchema{
+		"projects": {ID: "bqxyz123", Fields: map[string]int{"status": 7}},
+	})}
+	b := c.DeleteRecords("projects").
+		Where(core.F("status").EX("Closed"))
+
+	if b.err != nil {
+		t.Fatalf("unexpected error: %v", b.err)
+	}
+	if b.params["where"] != "{7.EX.'Closed'}" {
+		t.Errorf("where = %v, want {7.EX.'Closed'}", b.params["where"])
+	}
+
+	b = c.DeleteRecords("projects").Where(core.F("status").Op("EQ", "x"))
+	if b.err == nil {
+		t.Error("expected error for unknown operator")
+	}
+
+	// Query strings are validated and their aliases resolved too
+	b = c.DeleteRecords("projects").Where("{'status'.EX.'Closed'}")
+	if b.err != nil || b.params["where"] != "{7.EX.'Closed'}" {
+		t.Errorf("where = %v, err = %v; want {7.EX.'Closed'}", b.params["where"], b.err)
+	}
+	b = c.DeleteRecords("projects").Where("{'stauts'.EX.'Closed'}")
+	if b.err == nil {
+		t.Error("expected error for unknown alias")
+	}
+}
+
 func TestGetFieldsBuilder_Basic(t *testing.T) {
 	c := &Client{}
 	b := c.GetFields("bqxyz123")
@@ -763,3 +799,122 @@ func TestGetAppBuilder_Basic(t *testing.T) {
 // NOTE: Result type structure tests were removed in v2.0.
 // In the raw-first architecture, all builders return generated types directly.
 // Users can use opt-in helpers like UnwrapRecords() and Deref() for convenience.
+
+// newPagingServer serves the given JSON pages in order and records each
+// request body.
+func newPagingServer(t *testing.T, pages ...string) (*Client, *[]map[string]any) {
+	t.Helper()
+	var requests []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		requests = append(requests, body)
+		if len(requests) > len(pages) {
+			t.Errorf("unexpected request %d: %v", len(requests), body)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(pages[len(requests)-1]))
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := New("myrealm", auth.NewUserTokenStrategy("token"), WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return c, &requests
+}
+
+func TestAuditBuilder_All(t *testing.T) {
+	c, requests := newPagingServer(t,
+		`{"queryId":"q1","nextToken":"t1","events":[{"id":"1"},{"id":"2"}]}`,
+		`{"queryId":"q1","nextToken":"t2","events":[{"id":"3"}]}`,
+		`{"queryId":"q1","events":[{"id":"4"}]}`,
+	)
+
+	events, err := c.Audit().NumRows(1000).All(context.Background())
+	if err != nil {
+		t.Fatalf("All() error = %v", err)
+	}
+	if len(events) != 4 || events[3].Id != "4" {
+		t.Errorf("All() returned %d events, want 4", len(events))
+	}
+
+	// Later pages carry the token and the query ID from the first response
+	want := []map[string]any{
+		{"numRows": float64(1000)},
+		{"numRows": float64(1000), "nextToken": "t1", "queryId": "q1"},
+		{"numRows": float64(1000), "nextToken": "t2", "queryId": "q1"},
+	}
+	if len(*requests) != len(want) {
+		t.Fatalf("made %d requests, want %d", len(*requests), len(want))
+	}
+	for i, req := range *requests {
+		for k, v := range want[i] {
+			if req[k] != v {
+				t.Errorf("request %d: %s = %v, want %v", i+1, k, req[k], v)
+			}
+		}
+		if i == 0 && (req["nextToken"] != nil || req["queryId"] != nil) {
+			t.Errorf("request 1 should not carry a token: %v", req)
+		}
+	}
+}
+
+func TestGetUsersBuilder_IterAndN(t *testing.T) {
+	pages := []string{
+		`{"metadata":{"nextPageToken":"p2"},"users":[{"userName":"a"},{"userName":"b"}]}`,
+		`{"metadata":{"nextPageToken":""},"users":[{"userName":"c"}]}`,
+	}
+
+	c, requests := newPagingServer(t, pages...)
+	var names []string
+	for user, err := range c.GetUsers().Emails("a@example.com").Iter(context.Background()) {
+		if err != nil {
+			t.Fatalf("Iter() error = %v", err)
+		}
+		names = append(names, user.UserName)
+	}
+	if len(names) != 3 || names[2] != "c" {
+		t.Errorf("Iter() yielded %v, want [a b c]", names)
+	}
+	if got := (*requests)[1]["nextPageToken"]; got != "p2" {
+		t.Errorf("second request nextPageToken = %v, want p2", got)
+	}
+
+	// N stops fetching once it has enough items
+	c, requests = newPagingServer(t, pages...)
+	users, err := c.GetUsers().N(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("N() error = %v", err)
+	}
+	if len(users) != 2 || len(*requests) != 1 {
+		t.Errorf("N(2) returned %d users after %d requests, want 2 after 1", len(users), len(*requests))
+	}
+}
+
+func TestPlatformAnalyticEventSummariesBuilder_AllPages(t *testing.T) {
+	page := func(next string) string {
+		return `{"data":{"eventsSummaries":{"accountId":"1","groupBy":"app","metadata":{"nextToken":"` + next + `"},` +
+			`"results":[{"id":"app` + next + `","name":"App","eventTypes":[],"totals":{}}],"totals":{},"where":[],` +
+			`"start":"2026-01-01T00:00:00Z","end":"2026-01-02T00:00:00Z"}}}`
+	}
+	c, requests := newPagingServer(t, page("n1"), page(""))
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).Format(time.RFC3339)
+	end := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC).Format(time.RFC3339)
+	pages, err := c.PlatformAnalyticEventSummaries().Start(start).End(end).GroupBy("app").AllPages(context.Background())
+	if err != nil {
+		t.Fatalf("AllPages() error = %v", err)
+	}
+	if len(pages) != 2 {
+		t.Fatalf("AllPages() returned %d pages, want 2", len(pages))
+	}
+	if got := pages[0].Raw().JSON200.Data.EventsSummaries.Results[0].Id; got != "appn1" {
+		t.Errorf("first page result id = %q, want appn1", got)
+	}
+	if got := (*requests)[1]["nextToken"]; got != "n1" {
+		t.Errorf("second request nextToken = %v, want n1", got)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
+	"maps"
 	"strconv"
 
 	"github.com/DrewBradfordXYZ/quickbase-go/v2/core"
@@ -36,6 +38,7 @@ type AddManagersToGroupBuilder struct {
 	client  *Client
 	gid float32
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -51,11 +54,18 @@ func (c *Client) AddManagersToGroup(gid float32) *AddManagersToGroupBuilder {
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *AddManagersToGroupBuilder) RetryPolicy(policy RetryPolicy) *AddManagersToGroupBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the addManagersToGroup request and returns the response data directly.
 func (b *AddManagersToGroupBuilder) Run(ctx context.Context) (*AddManagersToGroupResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// Build request body from params
 	body := make(map[string]any)
 	for k, v := range b.params {
@@ -91,6 +101,7 @@ type AddMembersToGroupBuilder struct {
 	client  *Client
 	gid float32
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -106,11 +117,18 @@ func (c *Client) AddMembersToGroup(gid float32) *AddMembersToGroupBuilder {
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *AddMembersToGroupBuilder) RetryPolicy(policy RetryPolicy) *AddMembersToGroupBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the addMembersToGroup request and returns the response data directly.
 func (b *AddMembersToGroupBuilder) Run(ctx context.Context) (*AddMembersToGroupResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// Build request body from params
 	body := make(map[string]any)
 	for k, v := range b.params {
@@ -146,6 +164,7 @@ type AddSubgroupsToGroupBuilder struct {
 	client  *Client
 	gid float32
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -161,11 +180,18 @@ func (c *Client) AddSubgroupsToGroup(gid float32) *AddSubgroupsToGroupBuilder {
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *AddSubgroupsToGroupBuilder) RetryPolicy(policy RetryPolicy) *AddSubgroupsToGroupBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the addSubgroupsToGroup request and returns the response data directly.
 func (b *AddSubgroupsToGroupBuilder) Run(ctx context.Context) (*AddSubgroupsToGroupResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// Build request body from params
 	body := make(map[string]any)
 	for k, v := range b.params {
@@ -201,6 +227,7 @@ type AddTrusteesBuilder struct {
 	client  *Client
 	appId string
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -216,11 +243,18 @@ func (c *Client) AddTrustees(appId string) *AddTrusteesBuilder {
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *AddTrusteesBuilder) RetryPolicy(policy RetryPolicy) *AddTrusteesBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the addTrustees request and returns the response data directly.
 func (b *AddTrusteesBuilder) Run(ctx context.Context) (*AddTrusteesResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// Build request body from params
 	body := make(map[string]any)
 	for k, v := range b.params {
@@ -255,6 +289,7 @@ func (b *AddTrusteesBuilder) Run(ctx context.Context) (*AddTrusteesResult, error
 type AuditBuilder struct {
 	client  *Client
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -319,11 +354,18 @@ func (b *AuditBuilder) Topics(values ...string) *AuditBuilder {
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *AuditBuilder) RetryPolicy(policy RetryPolicy) *AuditBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the audit request and returns the response data directly.
 func (b *AuditBuilder) Run(ctx context.Context) (*AuditResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// Build request body from params
 	body := make(map[string]any)
 	for k, v := range b.params {
@@ -351,6 +393,64 @@ func (b *AuditBuilder) Run(ctx context.Context) (*AuditResult, error) {
 	return &AuditResult{resp: resp}, nil
 }
 
+// auditPage adapts a audit response to PaginatedResponse.
+type auditPage struct {
+	items []generated.AuditEventsItem
+	next  string
+}
+
+func (p *auditPage) GetData() []generated.AuditEventsItem {
+	return p.items
+}
+
+func (p *auditPage) GetMetadata() PaginationMetadata {
+	return PaginationMetadata{NextToken: &p.next}
+}
+
+// fetcher returns a PageFetcher that reruns the request with each page's nextToken.
+func (b *AuditBuilder) fetcher() PageFetcher[generated.AuditEventsItem, *auditPage] {
+	params := maps.Clone(b.params)
+	return func(ctx context.Context, skip int, nextToken string) (*auditPage, error) {
+		req := *b
+		req.params = maps.Clone(params)
+		if nextToken != "" {
+			req.params["nextToken"] = nextToken
+		}
+		result, err := req.Run(ctx)
+		if err != nil {
+			return nil, err
+		}
+		resp := result.resp
+
+		page := &auditPage{}
+		if resp.JSON200.Events != nil {
+			page.items = *resp.JSON200.Events
+		}
+		if resp.JSON200.NextToken != nil {
+			page.next = *resp.JSON200.NextToken
+		}
+		if resp.JSON200.QueryId != "" {
+			params["queryId"] = resp.JSON200.QueryId
+		}
+		return page, nil
+	}
+}
+
+// Iter returns an iterator over the items of every page, following nextToken.
+func (b *AuditBuilder) Iter(ctx context.Context) iter.Seq2[generated.AuditEventsItem, error] {
+	return Paginate(ctx, b.fetcher())
+}
+
+// All fetches every page and returns all items.
+func (b *AuditBuilder) All(ctx context.Context) ([]generated.AuditEventsItem, error) {
+	return CollectAll(ctx, b.fetcher())
+}
+
+// N fetches up to n items across pages.
+func (b *AuditBuilder) N(ctx context.Context, n int) ([]generated.AuditEventsItem, error) {
+	return CollectN(ctx, b.fetcher(), n)
+}
+
 
 
 // ChangesetSolutionBuilder provides a fluent API for the changesetSolution operation.
@@ -359,6 +459,7 @@ type ChangesetSolutionBuilder struct {
 	client  *Client
 	solutionId string
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -374,11 +475,18 @@ func (c *Client) ChangesetSolution(solutionId string) *ChangesetSolutionBuilder
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *ChangesetSolutionBuilder) RetryPolicy(policy RetryPolicy) *ChangesetSolutionBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the changesetSolution request and returns the response data directly.
 func (b *ChangesetSolutionBuilder) Run(ctx context.Context) (*generated.ChangesetSolutionResponse, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// Build request body from params
 	body := make(map[string]any)
 	for k, v := range b.params {
@@ -420,6 +528,7 @@ type ChangesetSolutionFromRecordBuilder struct {
 	qpFieldId *int
 	qpRecordId *int
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -467,11 +576,18 @@ func (b *ChangesetSolutionFromRecordBuilder) RecordId(value int) *ChangesetSolut
 }
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *ChangesetSolutionFromRecordBuilder) RetryPolicy(policy RetryPolicy) *ChangesetSolutionFromRecordBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the changesetSolutionFromRecord request and returns the response data directly.
 func (b *ChangesetSolutionFromRecordBuilder) Run(ctx context.Context) (*generated.ChangesetSolutionFromRecordResponse, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// tableId is validated via table resolution
 	if b.qpFieldId == nil {
 		return nil, &core.ValidationError{
@@ -514,6 +630,7 @@ func (b *ChangesetSolutionFromRecordBuilder) Run(ctx context.Context) (*generate
 type CloneUserTokenBuilder struct {
 	client  *Client
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -548,11 +665,18 @@ func (b *CloneUserTokenBuilder) Description(value string) *CloneUserTokenBuilder
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *CloneUserTokenBuilder) RetryPolicy(policy RetryPolicy) *CloneUserTokenBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the cloneUserToken request and returns the response data directly.
 func (b *CloneUserTokenBuilder) Run(ctx context.Context) (*CloneUserTokenResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// Build request body from params
 	body := make(map[string]any)
 	for k, v := range b.params {
@@ -588,6 +712,7 @@ type CopyAppBuilder struct {
 	client  *Client
 	appId string
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -683,11 +808,18 @@ func (b *CopyAppBuilder) UsersAndRoles(value bool) *CopyAppBuilder {
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *CopyAppBuilder) RetryPolicy(policy RetryPolicy) *CopyAppBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the copyApp request and returns the response data directly.
 func (b *CopyAppBuilder) Run(ctx context.Context) (*CopyAppResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	if _, ok := b.params["name"]; !ok {
 		return nil, &core.ValidationError{
 			QuickbaseError: core.QuickbaseError{
@@ -729,6 +861,7 @@ func (b *CopyAppBuilder) Run(ctx context.Context) (*CopyAppResult, error) {
 type CreateAppBuilder struct {
 	client  *Client
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -873,11 +1006,18 @@ func (b *CreateAppBuilder) HideFromPublic(value bool) *CreateAppBuilder {
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *CreateAppBuilder) RetryPolicy(policy RetryPolicy) *CreateAppBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the createApp request and returns the response data directly.
 func (b *CreateAppBuilder) Run(ctx context.Context) (*CreateAppResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	if _, ok := b.params["name"]; !ok {
 		return nil, &core.ValidationError{
 			QuickbaseError: core.QuickbaseError{
@@ -921,6 +1061,7 @@ type CreateFieldBuilder struct {
 	table   string
 	tableID string
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -1932,11 +2073,18 @@ func (b *CreateFieldBuilder) Bold(value bool) *CreateFieldBuilder {
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *CreateFieldBuilder) RetryPolicy(policy RetryPolicy) *CreateFieldBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the createField request and returns the response data directly.
 func (b *CreateFieldBuilder) Run(ctx context.Context) (*CreateFieldResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	if _, ok := b.params["fieldType"]; !ok {
 		return nil, &core.ValidationError{
 			QuickbaseError: core.QuickbaseError{
@@ -1991,6 +2139,7 @@ type CreateRelationshipBuilder struct {
 	table   string
 	tableID string
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -2062,11 +2211,18 @@ func (b *CreateRelationshipBuilder) ParentTableId(value string) *CreateRelations
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *CreateRelationshipBuilder) RetryPolicy(policy RetryPolicy) *CreateRelationshipBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the createRelationship request and returns the response data directly.
 func (b *CreateRelationshipBuilder) Run(ctx context.Context) (*CreateRelationshipResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// Build request body from params
 	body := make(map[string]any)
 	body["tableId"] = b.tableID
@@ -2102,6 +2258,7 @@ func (b *CreateRelationshipBuilder) Run(ctx context.Context) (*CreateRelationshi
 type CreateSolutionBuilder struct {
 	client  *Client
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -2116,11 +2273,18 @@ func (c *Client) CreateSolution() *CreateSolutionBuilder {
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *CreateSolutionBuilder) RetryPolicy(policy RetryPolicy) *CreateSolutionBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the createSolution request and returns the response data directly.
 func (b *CreateSolutionBuilder) Run(ctx context.Context) (*generated.CreateSolutionResponse, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// Build request body from params
 	body := make(map[string]any)
 	for k, v := range b.params {
@@ -2161,6 +2325,7 @@ type CreateSolutionFromRecordBuilder struct {
 	qpFieldId *int
 	qpRecordId *int
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -2207,11 +2372,18 @@ func (b *CreateSolutionFromRecordBuilder) RecordId(value int) *CreateSolutionFro
 }
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *CreateSolutionFromRecordBuilder) RetryPolicy(policy RetryPolicy) *CreateSolutionFromRecordBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the createSolutionFromRecord request and returns the response data directly.
 func (b *CreateSolutionFromRecordBuilder) Run(ctx context.Context) (*generated.CreateSolutionFromRecordResponse, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// tableId is validated via table resolution
 	if b.qpFieldId == nil {
 		return nil, &core.ValidationError{
@@ -2255,6 +2427,7 @@ type CreateTableBuilder struct {
 	client  *Client
 	qpAppId *string
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -2319,11 +2492,18 @@ func (b *CreateTableBuilder) AppId(value string) *CreateTableBuilder {
 }
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *CreateTableBuilder) RetryPolicy(policy RetryPolicy) *CreateTableBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the createTable request and returns the response data directly.
 func (b *CreateTableBuilder) Run(ctx context.Context) (*CreateTableResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	if _, ok := b.params["name"]; !ok {
 		return nil, &core.ValidationError{
 			QuickbaseError: core.QuickbaseError{
@@ -2377,6 +2557,7 @@ func (b *CreateTableBuilder) Run(ctx context.Context) (*CreateTableResult, error
 type DeactivateUserTokenBuilder struct {
 	client  *Client
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -2391,11 +2572,18 @@ func (c *Client) DeactivateUserToken() *DeactivateUserTokenBuilder {
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *DeactivateUserTokenBuilder) RetryPolicy(policy RetryPolicy) *DeactivateUserTokenBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the deactivateUserToken request and returns the response data directly.
 func (b *DeactivateUserTokenBuilder) Run(ctx context.Context) (*DeactivateUserTokenResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 
 	resp, err := b.client.API().DeactivateUserTokenWithResponse(ctx)
 	if err != nil {
@@ -2415,6 +2603,7 @@ type DeleteAppBuilder struct {
 	client  *Client
 	appId string
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -2440,11 +2629,18 @@ func (b *DeleteAppBuilder) Name(value string) *DeleteAppBuilder {
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *DeleteAppBuilder) RetryPolicy(policy RetryPolicy) *DeleteAppBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the deleteApp request and returns the response data directly.
 func (b *DeleteAppBuilder) Run(ctx context.Context) (*DeleteAppResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	if _, ok := b.params["name"]; !ok {
 		return nil, &core.ValidationError{
 			QuickbaseError: core.QuickbaseError{
@@ -2488,6 +2684,7 @@ type DeleteFieldsBuilder struct {
 	table   string
 	tableID string
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -2524,11 +2721,18 @@ func (b *DeleteFieldsBuilder) FieldIds(values ...int) *DeleteFieldsBuilder {
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *DeleteFieldsBuilder) RetryPolicy(policy RetryPolicy) *DeleteFieldsBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the deleteFields request and returns the response data directly.
 func (b *DeleteFieldsBuilder) Run(ctx context.Context) (*DeleteFieldsResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	if _, ok := b.params["fieldIds"]; !ok {
 		return nil, &core.ValidationError{
 			QuickbaseError: core.QuickbaseError{
@@ -2579,6 +2783,7 @@ type DeleteFileBuilder struct {
 	fieldId int
 	versionNumber int
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -2608,11 +2813,18 @@ func (c *Client) DeleteFile(table string, recordId int, fieldId int, versionNumb
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *DeleteFileBuilder) RetryPolicy(policy RetryPolicy) *DeleteFileBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the deleteFile request and returns the response data directly.
 func (b *DeleteFileBuilder) Run(ctx context.Context) (*DeleteFileResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 
 	resp, err := b.client.API().DeleteFileWithResponse(ctx, b.tableID, b.recordId, b.fieldId, b.versionNumber)
 	if err != nil {
@@ -2633,6 +2845,7 @@ type DeleteRecordsBuilder struct {
 	table   string
 	tableID string
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -2663,17 +2876,29 @@ func (b *DeleteRecordsBuilder) Where(value any) *DeleteRecordsBuilder {
 	if b.err != nil {
 		return b
 	}
-	b.params["where"] = value
+	resolved, err := resolveWhere(b.client, b.tableID, value)
+	if err != nil {
+		b.err = err
+		return b
+	}
+	b.params["where"] = resolved
 	return b
 }
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *DeleteRecordsBuilder) RetryPolicy(policy RetryPolicy) *DeleteRecordsBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the deleteRecords request and returns the response data directly.
 func (b *DeleteRecordsBuilder) Run(ctx context.Context) (*DeleteRecordsResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	if _, ok := b.params["where"]; !ok {
 		return nil, &core.ValidationError{
 			QuickbaseError: core.QuickbaseError{
@@ -2719,6 +2944,7 @@ type DeleteRelationshipBuilder struct {
 	tableID string
 	relationshipId float32
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -2746,11 +2972,18 @@ func (c *Client) DeleteRelationship(table string, relationshipId float32) *Delet
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *DeleteRelationshipBuilder) RetryPolicy(policy RetryPolicy) *DeleteRelationshipBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the deleteRelationship request and returns the response data directly.
 func (b *DeleteRelationshipBuilder) Run(ctx context.Context) (*DeleteRelationshipResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 
 	resp, err := b.client.API().DeleteRelationshipWithResponse(ctx, b.tableID, b.relationshipId)
 	if err != nil {
@@ -2772,6 +3005,7 @@ type DeleteTableBuilder struct {
 	tableID string
 	qpAppId *string
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -2808,11 +3042,18 @@ func (b *DeleteTableBuilder) AppId(value string) *DeleteTableBuilder {
 }
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *DeleteTableBuilder) RetryPolicy(policy RetryPolicy) *DeleteTableBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the deleteTable request and returns the response data directly.
 func (b *DeleteTableBuilder) Run(ctx context.Context) (*DeleteTableResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	if b.qpAppId == nil {
 		return nil, &core.ValidationError{
 			QuickbaseError: core.QuickbaseError{
@@ -2843,6 +3084,7 @@ func (b *DeleteTableBuilder) Run(ctx context.Context) (*DeleteTableResult, error
 type DeleteUserTokenBuilder struct {
 	client  *Client
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -2857,11 +3099,18 @@ func (c *Client) DeleteUserToken() *DeleteUserTokenBuilder {
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *DeleteUserTokenBuilder) RetryPolicy(policy RetryPolicy) *DeleteUserTokenBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the deleteUserToken request and returns the response data directly.
 func (b *DeleteUserTokenBuilder) Run(ctx context.Context) (*DeleteUserTokenResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 
 	resp, err := b.client.API().DeleteUserTokenWithResponse(ctx)
 	if err != nil {
@@ -2881,6 +3130,7 @@ type DenyUsersBuilder struct {
 	client  *Client
 	qpAccountId *float32
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -2905,11 +3155,18 @@ func (b *DenyUsersBuilder) AccountId(value float32) *DenyUsersBuilder {
 }
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *DenyUsersBuilder) RetryPolicy(policy RetryPolicy) *DenyUsersBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the denyUsers request and returns the response data directly.
 func (b *DenyUsersBuilder) Run(ctx context.Context) (*DenyUsersResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// Build request body from params
 	body := make(map[string]any)
 	for k, v := range b.params {
@@ -2951,6 +3208,7 @@ type DenyUsersAndGroupsBuilder struct {
 	shouldDeleteFromGroups bool
 	qpAccountId *float32
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -2976,11 +3234,18 @@ func (b *DenyUsersAndGroupsBuilder) AccountId(value float32) *DenyUsersAndGroups
 }
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *DenyUsersAndGroupsBuilder) RetryPolicy(policy RetryPolicy) *DenyUsersAndGroupsBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the denyUsersAndGroups request and returns the response data directly.
 func (b *DenyUsersAndGroupsBuilder) Run(ctx context.Context) (*DenyUsersAndGroupsResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// Build request body from params
 	body := make(map[string]any)
 	for k, v := range b.params {
@@ -3025,6 +3290,7 @@ type DownloadFileBuilder struct {
 	fieldId int
 	versionNumber int
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -3054,11 +3320,18 @@ func (c *Client) DownloadFile(table string, recordId int, fieldId int, versionNu
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *DownloadFileBuilder) RetryPolicy(policy RetryPolicy) *DownloadFileBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the downloadFile request and returns the response data directly.
 func (b *DownloadFileBuilder) Run(ctx context.Context) (*generated.DownloadFileResponse, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 
 	resp, err := b.client.API().DownloadFileWithResponse(ctx, b.tableID, b.recordId, b.fieldId, b.versionNumber)
 	if err != nil {
@@ -3077,6 +3350,7 @@ func (b *DownloadFileBuilder) Run(ctx context.Context) (*generated.DownloadFileR
 type ExchangeSsoTokenBuilder struct {
 	client  *Client
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -3131,11 +3405,18 @@ func (b *ExchangeSsoTokenBuilder) Subject_token_type(value string) *ExchangeSsoT
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *ExchangeSsoTokenBuilder) RetryPolicy(policy RetryPolicy) *ExchangeSsoTokenBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the exchangeSsoToken request and returns the response data directly.
 func (b *ExchangeSsoTokenBuilder) Run(ctx context.Context) (*ExchangeSsoTokenResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	if _, ok := b.params["grant_type"]; !ok {
 		return nil, &core.ValidationError{
 			QuickbaseError: core.QuickbaseError{
@@ -3199,6 +3480,7 @@ type ExportSolutionBuilder struct {
 	client  *Client
 	solutionId string
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -3214,11 +3496,18 @@ func (c *Client) ExportSolution(solutionId string) *ExportSolutionBuilder {
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *ExportSolutionBuilder) RetryPolicy(policy RetryPolicy) *ExportSolutionBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the exportSolution request and returns the response data directly.
 func (b *ExportSolutionBuilder) Run(ctx context.Context) (*generated.ExportSolutionResponse, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// Build query params
 	params := &generated.ExportSolutionParams{}
 
@@ -3243,6 +3532,7 @@ type ExportSolutionToRecordBuilder struct {
 	solutionId string
 	qpFieldId *int
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -3280,11 +3570,18 @@ func (b *ExportSolutionToRecordBuilder) FieldId(value int) *ExportSolutionToReco
 }
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *ExportSolutionToRecordBuilder) RetryPolicy(policy RetryPolicy) *ExportSolutionToRecordBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the exportSolutionToRecord request and returns the response data directly.
 func (b *ExportSolutionToRecordBuilder) Run(ctx context.Context) (*generated.ExportSolutionToRecordResponse, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// tableId is validated via table resolution
 	if b.qpFieldId == nil {
 		return nil, &core.ValidationError{
@@ -3328,6 +3625,7 @@ type GenerateDocumentBuilder struct {
 	qpOrientation *generated.GenerateDocumentParamsOrientation
 	qpRealm *string
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -3435,11 +3733,18 @@ func (b *GenerateDocumentBuilder) Realm(value string) *GenerateDocumentBuilder {
 }
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *GenerateDocumentBuilder) RetryPolicy(policy RetryPolicy) *GenerateDocumentBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the generateDocument request and returns the response data directly.
 func (b *GenerateDocumentBuilder) Run(ctx context.Context) (*GenerateDocumentResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// tableId is validated via table resolution
 	if b.qpFilename == nil {
 		return nil, &core.ValidationError{
@@ -3494,6 +3799,7 @@ type GetAppBuilder struct {
 	client  *Client
 	appId string
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -3509,11 +3815,18 @@ func (c *Client) GetApp(appId string) *GetAppBuilder {
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *GetAppBuilder) RetryPolicy(policy RetryPolicy) *GetAppBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the getApp request and returns the response data directly.
 func (b *GetAppBuilder) Run(ctx context.Context) (*AppResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 
 	resp, err := b.client.API().GetAppWithResponse(ctx, b.appId)
 	if err != nil {
@@ -3533,6 +3846,7 @@ type GetAppEventsBuilder struct {
 	client  *Client
 	appId string
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -3548,11 +3862,18 @@ func (c *Client) GetAppEvents(appId string) *GetAppEventsBuilder {
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *GetAppEventsBuilder) RetryPolicy(policy RetryPolicy) *GetAppEventsBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the getAppEvents request and returns the response data directly.
 func (b *GetAppEventsBuilder) Run(ctx context.Context) ([]*AppEventsItem, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 
 	resp, err := b.client.API().GetAppEventsWithResponse(ctx, b.appId)
 	if err != nil {
@@ -3577,6 +3898,7 @@ type GetAppTablesBuilder struct {
 	client  *Client
 	qpAppId *string
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -3601,11 +3923,18 @@ func (b *GetAppTablesBuilder) AppId(value string) *GetAppTablesBuilder {
 }
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *GetAppTablesBuilder) RetryPolicy(policy RetryPolicy) *GetAppTablesBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the getAppTables request and returns the response data directly.
 func (b *GetAppTablesBuilder) Run(ctx context.Context) ([]*AppTablesItem, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	if b.qpAppId == nil {
 		return nil, &core.ValidationError{
 			QuickbaseError: core.QuickbaseError{
@@ -3645,6 +3974,7 @@ type GetFieldBuilder struct {
 	fieldId int
 	qpIncludeFieldPerms *bool
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -3682,11 +4012,18 @@ func (b *GetFieldBuilder) IncludeFieldPerms(value bool) *GetFieldBuilder {
 }
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *GetFieldBuilder) RetryPolicy(policy RetryPolicy) *GetFieldBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the getField request and returns the response data directly.
 func (b *GetFieldBuilder) Run(ctx context.Context) (*FieldResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// tableId is validated via table resolution
 	// Build query params
 	params := &generated.GetFieldParams{}
@@ -3715,6 +4052,7 @@ type GetFieldUsageBuilder struct {
 	tableID string
 	fieldId int
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -3742,11 +4080,18 @@ func (c *Client) GetFieldUsage(fieldId int, table string) *GetFieldUsageBuilder
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *GetFieldUsageBuilder) RetryPolicy(policy RetryPolicy) *GetFieldUsageBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the getFieldUsage request and returns the response data directly.
 func (b *GetFieldUsageBuilder) Run(ctx context.Context) ([]*FieldUsageItem, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// tableId is validated via table resolution
 	// Build query params
 	params := &generated.GetFieldUsageParams{}
@@ -3777,6 +4122,7 @@ type GetFieldsBuilder struct {
 	tableID string
 	qpIncludeFieldPerms *bool
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -3813,11 +4159,18 @@ func (b *GetFieldsBuilder) IncludeFieldPerms(value bool) *GetFieldsBuilder {
 }
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *GetFieldsBuilder) RetryPolicy(policy RetryPolicy) *GetFieldsBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the getFields request and returns the response data directly.
 func (b *GetFieldsBuilder) Run(ctx context.Context) ([]*FieldsItem, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// tableId is validated via table resolution
 	// Build query params
 	params := &generated.GetFieldsParams{}
@@ -3852,6 +4205,7 @@ type GetFieldsUsageBuilder struct {
 	qpSkip *int
 	qpTop *int
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -3898,11 +4252,18 @@ func (b *GetFieldsUsageBuilder) Top(value int) *GetFieldsUsageBuilder {
 }
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *GetFieldsUsageBuilder) RetryPolicy(policy RetryPolicy) *GetFieldsUsageBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the getFieldsUsage request and returns the response data directly.
 func (b *GetFieldsUsageBuilder) Run(ctx context.Context) ([]*FieldsUsageItem, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// tableId is validated via table resolution
 	// Build query params
 	params := &generated.GetFieldsUsageParams{}
@@ -3939,6 +4300,7 @@ type GetRelationshipsBuilder struct {
 	tableID string
 	qpSkip *int
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -3975,11 +4337,18 @@ func (b *GetRelationshipsBuilder) Skip(value int) *GetRelationshipsBuilder {
 }
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *GetRelationshipsBuilder) RetryPolicy(policy RetryPolicy) *GetRelationshipsBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the getRelationships request and returns the response data directly.
 func (b *GetRelationshipsBuilder) Run(ctx context.Context) (*RelationshipsResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// Build query params
 	params := &generated.GetRelationshipsParams{}
 	if b.qpSkip != nil {
@@ -4006,6 +4375,7 @@ type GetReportBuilder struct {
 	tableID string
 	reportId string
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -4033,11 +4403,18 @@ func (c *Client) GetReport(reportId string, table string) *GetReportBuilder {
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *GetReportBuilder) RetryPolicy(policy RetryPolicy) *GetReportBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the getReport request and returns the response data directly.
 func (b *GetReportBuilder) Run(ctx context.Context) (*ReportResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// tableId is validated via table resolution
 	// Build query params
 	params := &generated.GetReportParams{}
@@ -4061,6 +4438,7 @@ type GetRolesBuilder struct {
 	client  *Client
 	appId string
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -4076,11 +4454,18 @@ func (c *Client) GetRoles(appId string) *GetRolesBuilder {
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *GetRolesBuilder) RetryPolicy(policy RetryPolicy) *GetRolesBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the getRoles request and returns the response data directly.
 func (b *GetRolesBuilder) Run(ctx context.Context) ([]*RolesItem, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 
 	resp, err := b.client.API().GetRolesWithResponse(ctx, b.appId)
 	if err != nil {
@@ -4105,6 +4490,7 @@ type GetSolutionPublicBuilder struct {
 	client  *Client
 	solutionId string
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -4120,11 +4506,18 @@ func (c *Client) GetSolutionPublic(solutionId string) *GetSolutionPublicBuilder
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *GetSolutionPublicBuilder) RetryPolicy(policy RetryPolicy) *GetSolutionPublicBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the getSolutionPublic request and returns the response data directly.
 func (b *GetSolutionPublicBuilder) Run(ctx context.Context) (*generated.GetSolutionPublicResponse, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// Build query params
 	params := &generated.GetSolutionPublicParams{}
 
@@ -4148,6 +4541,7 @@ type GetTableBuilder struct {
 	tableID string
 	qpAppId *string
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -4184,11 +4578,18 @@ func (b *GetTableBuilder) AppId(value string) *GetTableBuilder {
 }
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *GetTableBuilder) RetryPolicy(policy RetryPolicy) *GetTableBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the getTable request and returns the response data directly.
 func (b *GetTableBuilder) Run(ctx context.Context) (*TableResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	if b.qpAppId == nil {
 		return nil, &core.ValidationError{
 			QuickbaseError: core.QuickbaseError{
@@ -4221,6 +4622,7 @@ type GetTableReportsBuilder struct {
 	table   string
 	tableID string
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -4247,11 +4649,18 @@ func (c *Client) GetTableReports(table string) *GetTableReportsBuilder {
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *GetTableReportsBuilder) RetryPolicy(policy RetryPolicy) *GetTableReportsBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the getTableReports request and returns the response data directly.
 func (b *GetTableReportsBuilder) Run(ctx context.Context) ([]*TableReportsItem, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// tableId is validated via table resolution
 	// Build query params
 	params := &generated.GetTableReportsParams{}
@@ -4280,6 +4689,7 @@ type GetTempTokenDBIDBuilder struct {
 	client  *Client
 	dbid string
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -4295,11 +4705,18 @@ func (c *Client) GetTempTokenDBID(dbid string) *GetTempTokenDBIDBuilder {
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *GetTempTokenDBIDBuilder) RetryPolicy(policy RetryPolicy) *GetTempTokenDBIDBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the getTempTokenDBID request and returns the response data directly.
 func (b *GetTempTokenDBIDBuilder) Run(ctx context.Context) (*TempTokenDBIDResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// Build query params
 	params := &generated.GetTempTokenDBIDParams{}
 
@@ -4321,6 +4738,7 @@ type GetTrusteesBuilder struct {
 	client  *Client
 	appId string
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -4336,11 +4754,18 @@ func (c *Client) GetTrustees(appId string) *GetTrusteesBuilder {
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *GetTrusteesBuilder) RetryPolicy(policy RetryPolicy) *GetTrusteesBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the getTrustees request and returns the response data directly.
 func (b *GetTrusteesBuilder) Run(ctx context.Context) (*generated.GetTrusteesResponse, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 
 	resp, err := b.client.API().GetTrusteesWithResponse(ctx, b.appId)
 	if err != nil {
@@ -4360,6 +4785,7 @@ type GetUsersBuilder struct {
 	client  *Client
 	qpAccountId *float32
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -4414,11 +4840,18 @@ func (b *GetUsersBuilder) AccountId(value float32) *GetUsersBuilder {
 }
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *GetUsersBuilder) RetryPolicy(policy RetryPolicy) *GetUsersBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the getUsers request and returns the response data directly.
 func (b *GetUsersBuilder) Run(ctx context.Context) (*UsersResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// Build request body from params
 	body := make(map[string]any)
 	for k, v := range b.params {
@@ -4451,6 +4884,57 @@ func (b *GetUsersBuilder) Run(ctx context.Context) (*UsersResult, error) {
 	return &UsersResult{resp: resp}, nil
 }
 
+// getUsersPage adapts a getUsers response to PaginatedResponse.
+type getUsersPage struct {
+	items []generated.GetUsersUsersItem
+	next  string
+}
+
+func (p *getUsersPage) GetData() []generated.GetUsersUsersItem {
+	return p.items
+}
+
+func (p *getUsersPage) GetMetadata() PaginationMetadata {
+	return PaginationMetadata{NextToken: &p.next}
+}
+
+// fetcher returns a PageFetcher that reruns the request with each page's nextPageToken.
+func (b *GetUsersBuilder) fetcher() PageFetcher[generated.GetUsersUsersItem, *getUsersPage] {
+	params := maps.Clone(b.params)
+	return func(ctx context.Context, skip int, nextToken string) (*getUsersPage, error) {
+		req := *b
+		req.params = maps.Clone(params)
+		if nextToken != "" {
+			req.params["nextPageToken"] = nextToken
+		}
+		result, err := req.Run(ctx)
+		if err != nil {
+			return nil, err
+		}
+		resp := result.resp
+
+		page := &getUsersPage{}
+		page.items = resp.JSON200.Users
+		page.next = resp.JSON200.Metadata.NextPageToken
+		return page, nil
+	}
+}
+
+// Iter returns an iterator over the items of every page, following nextPageToken.
+func (b *GetUsersBuilder) Iter(ctx context.Context) iter.Seq2[generated.GetUsersUsersItem, error] {
+	return Paginate(ctx, b.fetcher())
+}
+
+// All fetches every page and returns all items.
+func (b *GetUsersBuilder) All(ctx context.Context) ([]generated.GetUsersUsersItem, error) {
+	return CollectAll(ctx, b.fetcher())
+}
+
+// N fetches up to n items across pages.
+func (b *GetUsersBuilder) N(ctx context.Context, n int) ([]generated.GetUsersUsersItem, error) {
+	return CollectN(ctx, b.fetcher(), n)
+}
+
 
 
 // PlatformAnalyticEventSummariesBuilder provides a fluent API for the platformAnalyticEventSummaries operation.
@@ -4459,6 +4943,7 @@ type PlatformAnalyticEventSummariesBuilder struct {
 	client  *Client
 	qpAccountId *float32
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -4533,11 +5018,18 @@ func (b *PlatformAnalyticEventSummariesBuilder) AccountId(value float32) *Platfo
 }
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *PlatformAnalyticEventSummariesBuilder) RetryPolicy(policy RetryPolicy) *PlatformAnalyticEventSummariesBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the platformAnalyticEventSummaries request and returns the response data directly.
 func (b *PlatformAnalyticEventSummariesBuilder) Run(ctx context.Context) (*PlatformAnalyticEventSummariesResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	if _, ok := b.params["start"]; !ok {
 		return nil, &core.ValidationError{
 			QuickbaseError: core.QuickbaseError{
@@ -4591,6 +5083,57 @@ func (b *PlatformAnalyticEventSummariesBuilder) Run(ctx context.Context) (*Platf
 	return &PlatformAnalyticEventSummariesResult{resp: resp}, nil
 }
 
+// platformAnalyticEventSummariesPage adapts a platformAnalyticEventSummaries response to PaginatedResponse.
+type platformAnalyticEventSummariesPage struct {
+	items []*PlatformAnalyticEventSummariesResult
+	next  string
+}
+
+func (p *platformAnalyticEventSummariesPage) GetData() []*PlatformAnalyticEventSummariesResult {
+	return p.items
+}
+
+func (p *platformAnalyticEventSummariesPage) GetMetadata() PaginationMetadata {
+	return PaginationMetadata{NextToken: &p.next}
+}
+
+// fetcher returns a PageFetcher that reruns the request with each page's nextToken.
+func (b *PlatformAnalyticEventSummariesBuilder) fetcher() PageFetcher[*PlatformAnalyticEventSummariesResult, *platformAnalyticEventSummariesPage] {
+	params := maps.Clone(b.params)
+	return func(ctx context.Context, skip int, nextToken string) (*platformAnalyticEventSummariesPage, error) {
+		req := *b
+		req.params = maps.Clone(params)
+		if nextToken != "" {
+			req.params["nextToken"] = nextToken
+		}
+		result, err := req.Run(ctx)
+		if err != nil {
+			return nil, err
+		}
+		resp := result.resp
+
+		page := &platformAnalyticEventSummariesPage{}
+		page.items = []*PlatformAnalyticEventSummariesResult{result}
+		page.next = resp.JSON200.Data.EventsSummaries.Metadata.NextToken
+		return page, nil
+	}
+}
+
+// Pages returns an iterator over every page, following nextToken.
+func (b *PlatformAnalyticEventSummariesBuilder) Pages(ctx context.Context) iter.Seq2[*PlatformAnalyticEventSummariesResult, error] {
+	return Paginate(ctx, b.fetcher())
+}
+
+// AllPages fetches and returns every page.
+func (b *PlatformAnalyticEventSummariesBuilder) AllPages(ctx context.Context) ([]*PlatformAnalyticEventSummariesResult, error) {
+	return CollectAll(ctx, b.fetcher())
+}
+
+// NPages fetches up to n pages.
+func (b *PlatformAnalyticEventSummariesBuilder) NPages(ctx context.Context, n int) ([]*PlatformAnalyticEventSummariesResult, error) {
+	return CollectN(ctx, b.fetcher(), n)
+}
+
 
 
 // PlatformAnalyticReadsBuilder provides a fluent API for the platformAnalyticReads operation.
@@ -4599,6 +5142,7 @@ type PlatformAnalyticReadsBuilder struct {
 	client  *Client
 	qpDay *types.Date
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -4623,11 +5167,18 @@ func (b *PlatformAnalyticReadsBuilder) Day(value types.Date) *PlatformAnalyticRe
 }
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *PlatformAnalyticReadsBuilder) RetryPolicy(policy RetryPolicy) *PlatformAnalyticReadsBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the platformAnalyticReads request and returns the response data directly.
 func (b *PlatformAnalyticReadsBuilder) Run(ctx context.Context) (*PlatformAnalyticReadsResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// Build query params
 	params := &generated.PlatformAnalyticReadsParams{}
 	if b.qpDay != nil {
@@ -4651,6 +5202,7 @@ func (b *PlatformAnalyticReadsBuilder) Run(ctx context.Context) (*PlatformAnalyt
 type RecordsModifiedSinceBuilder struct {
 	client  *Client
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -4705,11 +5257,18 @@ func (b *RecordsModifiedSinceBuilder) After(value string) *RecordsModifiedSinceB
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *RecordsModifiedSinceBuilder) RetryPolicy(policy RetryPolicy) *RecordsModifiedSinceBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the recordsModifiedSince request and returns the response data directly.
 func (b *RecordsModifiedSinceBuilder) Run(ctx context.Context) (*RecordsModifiedSinceResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	if _, ok := b.params["after"]; !ok {
 		return nil, &core.ValidationError{
 			QuickbaseError: core.QuickbaseError{
@@ -4752,6 +5311,7 @@ type RemoveManagersFromGroupBuilder struct {
 	client  *Client
 	gid float32
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -4767,11 +5327,18 @@ func (c *Client) RemoveManagersFromGroup(gid float32) *RemoveManagersFromGroupBu
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *RemoveManagersFromGroupBuilder) RetryPolicy(policy RetryPolicy) *RemoveManagersFromGroupBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the removeManagersFromGroup request and returns the response data directly.
 func (b *RemoveManagersFromGroupBuilder) Run(ctx context.Context) (*RemoveManagersFromGroupResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// Build request body from params
 	body := make(map[string]any)
 	for k, v := range b.params {
@@ -4807,6 +5374,7 @@ type RemoveMembersFromGroupBuilder struct {
 	client  *Client
 	gid float32
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -4822,11 +5390,18 @@ func (c *Client) RemoveMembersFromGroup(gid float32) *RemoveMembersFromGroupBuil
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *RemoveMembersFromGroupBuilder) RetryPolicy(policy RetryPolicy) *RemoveMembersFromGroupBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the removeMembersFromGroup request and returns the response data directly.
 func (b *RemoveMembersFromGroupBuilder) Run(ctx context.Context) (*RemoveMembersFromGroupResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// Build request body from params
 	body := make(map[string]any)
 	for k, v := range b.params {
@@ -4862,6 +5437,7 @@ type RemoveSubgroupsFromGroupBuilder struct {
 	client  *Client
 	gid float32
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -4877,11 +5453,18 @@ func (c *Client) RemoveSubgroupsFromGroup(gid float32) *RemoveSubgroupsFromGroup
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *RemoveSubgroupsFromGroupBuilder) RetryPolicy(policy RetryPolicy) *RemoveSubgroupsFromGroupBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the removeSubgroupsFromGroup request and returns the response data directly.
 func (b *RemoveSubgroupsFromGroupBuilder) Run(ctx context.Context) (*RemoveSubgroupsFromGroupResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// Build request body from params
 	body := make(map[string]any)
 	for k, v := range b.params {
@@ -4917,6 +5500,7 @@ type RemoveTrusteesBuilder struct {
 	client  *Client
 	appId string
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -4932,11 +5516,18 @@ func (c *Client) RemoveTrustees(appId string) *RemoveTrusteesBuilder {
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *RemoveTrusteesBuilder) RetryPolicy(policy RetryPolicy) *RemoveTrusteesBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the removeTrustees request and returns the response data directly.
 func (b *RemoveTrusteesBuilder) Run(ctx context.Context) (*RemoveTrusteesResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// Build request body from params
 	body := make(map[string]any)
 	for k, v := range b.params {
@@ -4971,6 +5562,7 @@ func (b *RemoveTrusteesBuilder) Run(ctx context.Context) (*RemoveTrusteesResult,
 type RunFormulaBuilder struct {
 	client  *Client
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -5015,11 +5607,18 @@ func (b *RunFormulaBuilder) From(value string) *RunFormulaBuilder {
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *RunFormulaBuilder) RetryPolicy(policy RetryPolicy) *RunFormulaBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the runFormula request and returns the response data directly.
 func (b *RunFormulaBuilder) Run(ctx context.Context) (*RunFormulaResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	if _, ok := b.params["formula"]; !ok {
 		return nil, &core.ValidationError{
 			QuickbaseError: core.QuickbaseError{
@@ -5066,6 +5665,7 @@ type RunReportBuilder struct {
 	qpSkip *int
 	qpTop *int
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -5113,11 +5713,18 @@ func (b *RunReportBuilder) Top(value int) *RunReportBuilder {
 }
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *RunReportBuilder) RetryPolicy(policy RetryPolicy) *RunReportBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the runReport request and returns the response data directly.
 func (b *RunReportBuilder) Run(ctx context.Context) (*RunReportResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// tableId is validated via table resolution
 	// Build request body from params
 	body := make(map[string]any)
@@ -5152,7 +5759,7 @@ func (b *RunReportBuilder) Run(ctx context.Context) (*RunReportResult, error) {
 	if resp.JSON200 == nil {
 		return nil, parseAPIError(resp.StatusCode(), resp.Body, resp.HTTPResponse)
 	}
-	return &RunReportResult{resp: resp}, nil
+	return &RunReportResult{resp: resp, numbers: b.client.numbers}, nil
 }
 
 
@@ -5162,6 +5769,7 @@ func (b *RunReportBuilder) Run(ctx context.Context) (*RunReportResult, error) {
 type TransferUserTokenBuilder struct {
 	client  *Client
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -5206,11 +5814,18 @@ func (b *TransferUserTokenBuilder) To(value string) *TransferUserTokenBuilder {
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *TransferUserTokenBuilder) RetryPolicy(policy RetryPolicy) *TransferUserTokenBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the transferUserToken request and returns the response data directly.
 func (b *TransferUserTokenBuilder) Run(ctx context.Context) (*TransferUserTokenResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// Build request body from params
 	body := make(map[string]any)
 	for k, v := range b.params {
@@ -5246,6 +5861,7 @@ type UndenyUsersBuilder struct {
 	client  *Client
 	qpAccountId *float32
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -5270,11 +5886,18 @@ func (b *UndenyUsersBuilder) AccountId(value float32) *UndenyUsersBuilder {
 }
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *UndenyUsersBuilder) RetryPolicy(policy RetryPolicy) *UndenyUsersBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the undenyUsers request and returns the response data directly.
 func (b *UndenyUsersBuilder) Run(ctx context.Context) (*UndenyUsersResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// Build request body from params
 	body := make(map[string]any)
 	for k, v := range b.params {
@@ -5315,6 +5938,7 @@ type UpdateAppBuilder struct {
 	client  *Client
 	appId string
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -5450,11 +6074,18 @@ func (b *UpdateAppBuilder) Description(value string) *UpdateAppBuilder {
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *UpdateAppBuilder) RetryPolicy(policy RetryPolicy) *UpdateAppBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the updateApp request and returns the response data directly.
 func (b *UpdateAppBuilder) Run(ctx context.Context) (*UpdateAppResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// Build request body from params
 	body := make(map[string]any)
 	for k, v := range b.params {
@@ -5492,6 +6123,7 @@ type UpdateFieldBuilder struct {
 	tableID string
 	fieldId int
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -6619,11 +7251,18 @@ func (b *UpdateFieldBuilder) FindEnabled(value bool) *UpdateFieldBuilder {
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *UpdateFieldBuilder) RetryPolicy(policy RetryPolicy) *UpdateFieldBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the updateField request and returns the response data directly.
 func (b *UpdateFieldBuilder) Run(ctx context.Context) (*UpdateFieldResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// tableId is validated via table resolution
 	// Build request body from params
 	body := make(map[string]any)
@@ -6665,6 +7304,7 @@ type UpdateRelationshipBuilder struct {
 	tableID string
 	relationshipId float32
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -6712,11 +7352,18 @@ func (b *UpdateRelationshipBuilder) LookupFieldIds(values ...int) *UpdateRelatio
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *UpdateRelationshipBuilder) RetryPolicy(policy RetryPolicy) *UpdateRelationshipBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the updateRelationship request and returns the response data directly.
 func (b *UpdateRelationshipBuilder) Run(ctx context.Context) (*UpdateRelationshipResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// Build request body from params
 	body := make(map[string]any)
 	body["tableId"] = b.tableID
@@ -6753,6 +7400,7 @@ type UpdateSolutionBuilder struct {
 	client  *Client
 	solutionId string
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -6768,11 +7416,18 @@ func (c *Client) UpdateSolution(solutionId string) *UpdateSolutionBuilder {
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *UpdateSolutionBuilder) RetryPolicy(policy RetryPolicy) *UpdateSolutionBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the updateSolution request and returns the response data directly.
 func (b *UpdateSolutionBuilder) Run(ctx context.Context) (*generated.UpdateSolutionResponse, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// Build request body from params
 	body := make(map[string]any)
 	for k, v := range b.params {
@@ -6814,6 +7469,7 @@ type UpdateSolutionToRecordBuilder struct {
 	qpFieldId *int
 	qpRecordId *int
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -6861,11 +7517,18 @@ func (b *UpdateSolutionToRecordBuilder) RecordId(value int) *UpdateSolutionToRec
 }
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *UpdateSolutionToRecordBuilder) RetryPolicy(policy RetryPolicy) *UpdateSolutionToRecordBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the updateSolutionToRecord request and returns the response data directly.
 func (b *UpdateSolutionToRecordBuilder) Run(ctx context.Context) (*generated.UpdateSolutionToRecordResponse, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// tableId is validated via table resolution
 	if b.qpFieldId == nil {
 		return nil, &core.ValidationError{
@@ -6911,6 +7574,7 @@ type UpdateTableBuilder struct {
 	tableID string
 	qpAppId *string
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -6987,11 +7651,18 @@ func (b *UpdateTableBuilder) AppId(value string) *UpdateTableBuilder {
 }
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *UpdateTableBuilder) RetryPolicy(policy RetryPolicy) *UpdateTableBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the updateTable request and returns the response data directly.
 func (b *UpdateTableBuilder) Run(ctx context.Context) (*UpdateTableResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	if b.qpAppId == nil {
 		return nil, &core.ValidationError{
 			QuickbaseError: core.QuickbaseError{
@@ -7040,6 +7711,7 @@ type UpdateTrusteesBuilder struct {
 	client  *Client
 	appId string
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -7055,11 +7727,18 @@ func (c *Client) UpdateTrustees(appId string) *UpdateTrusteesBuilder {
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *UpdateTrusteesBuilder) RetryPolicy(policy RetryPolicy) *UpdateTrusteesBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the updateTrustees request and returns the response data directly.
 func (b *UpdateTrusteesBuilder) Run(ctx context.Context) (*UpdateTrusteesResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// Build request body from params
 	body := make(map[string]any)
 	for k, v := range b.params {
@@ -7096,6 +7775,7 @@ type UpsertBuilder struct {
 	table   string
 	tableID string
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -7152,11 +7832,18 @@ func (b *UpsertBuilder) FieldsToReturn(values ...int) *UpsertBuilder {
 
 
 
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *UpsertBuilder) RetryPolicy(policy RetryPolicy) *UpsertBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the upsert request and returns the response data directly.
 func (b *UpsertBuilder) Run(ctx context.Context) (*UpsertResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 	// Build request body from params
 	body := make(map[string]any)
 	body["to"] = b.tableID
@@ -7182,7 +7869,7 @@ func (b *UpsertBuilder) Run(ctx context.Context) (*UpsertResult, error) {
 	if resp.JSON200 == nil {
 		return nil, parseAPIError(resp.StatusCode(), resp.Body, resp.HTTPResponse)
 	}
-	return &UpsertResult{resp: resp}, nil
+	return &UpsertResult{resp: resp, numbers: b.client.numbers}, nil
 }
 
 
@@ -0,0 +1,365 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"regexp"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/core"
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/generated"
+)
+
+// Bulk upsert defaults, used when the matching BulkUpsertOptions field is 0.
+const (
+	defaultBulkBatchSize  = 1000
+	defaultBulkBatchBytes = 10 << 20 // 10 MB of record JSON per request
+)
+
+// BulkUpsertOptions configures BulkUpsert.
+type BulkUpsertOptions struct {
+	MergeFieldId   int         // Field used to match existing records (0 for record ID)
+	FieldsToReturn []int       // Fields returned for created and updated records
+	BatchSize      int         // Maximum records per request (default 1000)
+	MaxBatchBytes  int         // Maximum JSON size of a request's records (default 10 MB)
+	Concurrency    int         // Maximum requests in flight (default 4)
+	RetryPolicy    RetryPolicy // Overrides the client's retry policy for each batch
+}
+
+// BulkUpsertResult aggregates the responses of every batch sent by BulkUpsert.
+// Record IDs and returned records are in input order.
+type BulkUpsertResult struct {
+	CreatedRecordIds   []int
+	UpdatedRecordIds   []int
+	UnchangedRecordIds []int
+	Processed          int             // Records processed, including those with line errors
+	LineErrors         []BulkLineError // Records QuickBase rejected, sorted by Index
+	Records            []Record        // FieldsToReturn data for created and updated records
+	Batches            int             // Requests that completed
+}
+
+// BulkLineError is an error QuickBase reported for one input record.
+type BulkLineError struct {
+	Index   int    // Position of the record in the input (0-based)
+	FieldId int    // Field named in the message, or 0
+	Field   string // Schema alias of FieldId, if any
+	Message string
+}
+
+func (e BulkLineError) Error() string {
+	if e.Field != "" {
+		return fmt.Sprintf("record %d (%s): %s", e.Index, e.Field, e.Message)
+	}
+	return fmt.Sprintf("record %d: %s", e.Index, e.Message)
+}
+
+// BulkBatchError is returned by BulkUpsert when a batch request fails after
+// retries. The records at input positions Start through Start+Count-1 were not
+// written.
+type BulkBatchError struct {
+	Start int
+	Count int
+	Err   error
+}
+
+func (e *BulkBatchError) Error() string {
+	return fmt.Sprintf("bulk upsert of records %d-%d: %v", e.Start, e.Start+e.Count-1, e.Err)
+}
+
+func (e *BulkBatchError) Unwrap() error {
+	return e.Err
+}
+
+// lineErrorField finds the field ID in a QuickBase line error message, such as
+// `Incompatible value for field with ID "6".`
+var lineErrorField = regexp.MustCompile(`(?i)field with ID "?(\d+)`)
+
+// BulkUpsert inserts or updates any number of records. Records are read from
+// the iterator as batches are sent, so the input never has to fit in memory.
+//
+// Each request holds up to BatchSize records and MaxBatchBytes of record JSON,
+// and up to Concurrency requests run at once through the client's throttle.
+// Failed batches are retried under the client's retry policy, or
+// opts.RetryPolicy. Use NeverRetryWrites if records have no merge field, since
+// a replayed batch may create duplicates.
+//
+// Record keys are field IDs ("6") or schema aliases ("name"). Values are plain
+// Go values or wrapped values from Value and Row.
+//
+// Records QuickBase rejects are reported in the result's LineErrors, mapped to
+// their position in the input. If a batch fails outright, no new batches are
+// started and BulkUpsert returns the result so far along with a
+// *BulkBatchError for each failed batch.
+//
+// Example:
+//
+//	result, err := client.BulkUpsert(ctx, "projects", rows, quickbase.BulkUpsertOptions{
+//	    MergeFieldId: 9,
+//	})
+//	for _, lineErr := range result.LineErrors {
+//	    log.Printf("row %d: %s: %s", lineErr.Index, lineErr.Field, lineErr.Message)
+//	}
+func (c *Client) BulkUpsert(ctx context.Context, table string, records iter.Seq[Record], opts BulkUpsertOptions) (*BulkUpsertResult, error) {
+	tableID := table
+	if c.schema != nil {
+		resolved, err := core.ResolveTableAlias(c.schema, table)
+		if err != nil {
+			return nil, err
+		}
+		tableID = resolved
+	}
+	batchSize := orDefault(opts.BatchSize, defaultBulkBatchSize)
+	maxBytes := orDefault(opts.MaxBatchBytes, defaultBulkBatchBytes)
+	concurrency := orDefault(opts.Concurrency, defaultQueryConcurrency)
+	ctx = withRetryPolicy(ctx, opts.RetryPolicy)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		outcomes []*upsertOutcome
+		errs     []error
+		stopOnce sync.Once
+		stop     = make(chan struct{})
+		next     = make(chan *upsertBatch)
+	)
+	fail := func(err error) {
+		mu.Lock()
+		errs = append(errs, err)
+		mu.Unlock()
+		stopOnce.Do(func() { close(stop) })
+	}
+
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range next {
+				outcome, err := c.upsertBatch(ctx, tableID, opts, batch)
+				if err != nil {
+					fail(&BulkBatchError{Start: batch.start, Count: len(batch.records), Err: err})
+					continue
+				}
+				mu.Lock()
+				outcomes = append(outcomes, outcome)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	// Read and encode records, sending each batch once it is full
+	batch := &upsertBatch{}
+	size := 0
+	send := func() bool {
+		if len(batch.records) == 0 {
+			return true
+		}
+		select {
+		case <-stop:
+			return false
+		default:
+		}
+		select {
+		case next <- batch:
+		case <-stop:
+			return false
+		case <-ctx.Done():
+			fail(ctx.Err())
+			return false
+		}
+		batch = &upsertBatch{start: batch.start + len(batch.records)}
+		size = 0
+		return true
+	}
+
+	i := 0
+	for record := range records {
+		encoded, n, err := c.encodeBulkRecord(tableID, record, i)
+		if err != nil {
+			fail(err)
+			break
+		}
+		if len(batch.records) > 0 && (len(batch.records) >= batchSize || size+n > maxBytes) {
+			if !send() {
+				break
+			}
+		}
+		batch.records = append(batch.records, encoded)
+		size += n
+		i++
+	}
+	send()
+	close(next)
+	wg.Wait()
+
+	result := c.mergeUpsertOutcomes(tableID, outcomes)
+	if len(errs) > 0 {
+		slices.SortFunc(errs, func(a, b error) int {
+			return batchStart(a) - batchStart(b)
+		})
+		return result, errors.Join(errs...)
+	}
+	return result, nil
+}
+
+// upsertBatch is a group of encoded records sent in one request. start is the
+// input position of the first record.
+type upsertBatch struct {
+	start   int
+	records []generated.QuickbaseRecord
+}
+
+// upsertOutcome holds the parts of one batch's response that BulkUpsert keeps.
+type upsertOutcome struct {
+	start      int
+	created    []int
+	updated    []int
+	unchanged  []int
+	processed  int
+	lineErrors map[string][]string
+	data       []generated.QuickbaseRecord
+}
+
+// upsertBatch sends one batch. A 207 response with line errors is a success;
+// the line errors are reported in the outcome.
+func (c *Client) upsertBatch(ctx context.Context, tableID string, opts BulkUpsertOptions, batch *upsertBatch) (*upsertOutcome, error) {
+	body := generated.UpsertJSONRequestBody{
+		To:   tableID,
+		Data: &batch.records,
+	}
+	if opts.MergeFieldId != 0 {
+		body.MergeFieldId = &opts.MergeFieldId
+	}
+	if len(opts.FieldsToReturn) > 0 {
+		body.FieldsToReturn = &opts.FieldsToReturn
+	}
+
+	resp, err := c.API().UpsertWithResponse(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+
+	// The 200 and 207 metadata have the same fields
+	var metadata any
+	var data any
+	switch {
+	case resp.JSON200 != nil:
+		metadata, data = resp.JSON200.Metadata, resp.JSON200.Data
+	case resp.JSON207 != nil:
+		metadata, data = resp.JSON207.Metadata, resp.JSON207.Data
+	default:
+		return nil, parseAPIError(resp.StatusCode(), resp.Body, resp.HTTPResponse)
+	}
+
+	var meta generated.UpsertMetadata
+	var records []generated.QuickbaseRecord
+	if err := remarshal(metadata, &meta); err != nil {
+		return nil, err
+	}
+	if err := remarshal(data, &records); err != nil {
+		return nil, err
+	}
+
+	outcome := &upsertOutcome{start: batch.start, data: records}
+	outcome.created = Deref(meta.CreatedRecordIds)
+	outcome.updated = Deref(meta.UpdatedRecordIds)
+	outcome.unchanged = Deref(meta.UnchangedRecordIds)
+	outcome.processed = DerefOr(meta.TotalNumberOfRecordsProcessed, len(batch.records))
+	outcome.lineErrors = Deref(meta.LineErrors)
+	return outcome, nil
+}
+
+// mergeUpsertOutcomes combines batch outcomes in input order, mapping line
+// error sequence numbers (1-based within a batch) to input positions.
+func (c *Client) mergeUpsertOutcomes(tableID string, outcomes []*upsertOutcome) *BulkUpsertResult {
+	slices.SortFunc(outcomes, func(a, b *upsertOutcome) int { return a.start - b.start })
+
+	result := &BulkUpsertResult{Batches: len(outcomes)}
+	for _, o := range outcomes {
+		result.CreatedRecordIds = append(result.CreatedRecordIds, o.created...)
+		result.UpdatedRecordIds = append(result.UpdatedRecordIds, o.updated...)
+		result.UnchangedRecordIds = append(result.UnchangedRecordIds, o.unchanged...)
+		result.Processed += o.processed
+		result.Records = append(result.Records, unwrapRecordsAs(o.data, c.numbers)...)
+
+		for seq, messages := range o.lineErrors {
+			line, err := strconv.Atoi(seq)
+			if err != nil {
+				continue
+			}
+			for _, msg := range messages {
+				lineErr := BulkLineError{Index: o.start + line - 1, Message: msg}
+				if m := lineErrorField.FindStringSubmatch(msg); m != nil {
+					lineErr.FieldId, _ = strconv.Atoi(m[1])
+					if c.schema != nil {
+						lineErr.Field = core.GetFieldAlias(c.schema, tableID, lineErr.FieldId)
+					}
+				}
+				result.LineErrors = append(result.LineErrors, lineErr)
+			}
+		}
+	}
+	slices.SortStableFunc(result.LineErrors, func(a, b BulkLineError) int { return a.Index - b.Index })
+	return result
+}
+
+// encodeBulkRecord converts a record to the API format, resolving alias keys
+// and wrapping plain values. It also returns the record's JSON size.
+func (c *Client) encodeBulkRecord(tableID string, record Record, index int) (generated.QuickbaseRecord, int, error) {
+	encoded := make(generated.QuickbaseRecord, len(record))
+	for key, value := range record {
+		fieldID, err := strconv.Atoi(key)
+		if err != nil {
+			if c.schema == nil {
+				return nil, 0, fmt.Errorf("record %d: field %q is not a field ID and no schema is configured", index, key)
+			}
+			if fieldID, err = core.ResolveFieldAlias(c.schema, tableID, key); err != nil {
+				return nil, 0, fmt.Errorf("record %d: %w", index, err)
+			}
+		}
+		encoded[strconv.Itoa(fieldID)] = toFieldValue(value)
+	}
+
+	b, err := json.Marshal(encoded)
+	if err != nil {
+		return nil, 0, fmt.Errorf("record %d: %w", index, err)
+	}
+	return encoded, len(b) + 1, nil
+}
+
+// toFieldValue wraps a plain value, passing through already-wrapped values.
+func toFieldValue(v any) generated.FieldValue {
+	switch fv := v.(type) {
+	case generated.FieldValue:
+		return fv
+	case *generated.FieldValue:
+		return *fv
+	case map[string]any:
+		if inner, ok := fv["value"]; ok && len(fv) == 1 {
+			return wrapValue(inner)
+		}
+	}
+	return wrapValue(v)
+}
+
+// remarshal converts between JSON-compatible types by round-tripping through JSON.
+func remarshal(src, dst any) error {
+	b, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, dst)
+}
+
+// batchStart orders errors by batch position; other errors sort last.
+func batchStart(err error) int {
+	var batchErr *BulkBatchError
+	if errors.As(err, &batchErr) {
+		return batchErr.Start
+	}
+	return int(^uint(0) >> 1)
+}
@@ -0,0 +1,167 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/core"
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/generated"
+)
+
+// ErrTooManyRecords is returned by BulkDelete when more records match than
+// BulkDeleteOptions.MaxRecords allows.
+var ErrTooManyRecords = errors.New("bulk delete matches more records than allowed")
+
+// defaultDeleteChunkSize is the number of records BulkDelete removes per
+// request when no chunk size is given.
+const defaultDeleteChunkSize = 1000
+
+// BulkDeleteOptions configures BulkDelete.
+type BulkDeleteOptions struct {
+	// MaxRecords is the most records the call may delete. If the preview
+	// count is higher, nothing is deleted. Required unless DryRun is set.
+	MaxRecords int
+
+	ChunkSize int  // Maximum records per delete request (default 1000)
+	DryRun    bool // Only count the matching records
+
+	// After resumes an interrupted delete: only records with a higher record
+	// ID are deleted. Pass the LastRecordId of the last progress report.
+	After int
+
+	// Progress is called after each chunk with the totals so far.
+	Progress func(BulkDeleteResult)
+}
+
+// BulkDeleteResult reports the progress or outcome of BulkDelete.
+type BulkDeleteResult struct {
+	Matched      int // Records matching when the delete started
+	Deleted      int // Records deleted so far
+	LastRecordId int // Every matching record up to this ID has been deleted
+}
+
+// BulkDelete deletes the records matching where in record ID order, one chunk
+// per request. where is a query string or a core.Condition.
+//
+// It first counts the matching records and fails with ErrTooManyRecords,
+// deleting nothing, if the count exceeds opts.MaxRecords. Each chunk deletes
+// only the record IDs scanned for it, so the limit also holds if records
+// start matching during the delete. Each chunk's request re-applies where,
+// so records edited to no longer match are kept.
+//
+// After each chunk, opts.Progress receives the totals so far. If the call
+// fails part way, the returned result holds the totals at the failure; pass
+// its LastRecordId as opts.After to resume.
+//
+// Example:
+//
+//	result, err := client.BulkDelete(ctx, "tasks", "{'status'.EX.'Archived'}", quickbase.BulkDeleteOptions{
+//	    MaxRecords: 50000,
+//	    Progress: func(p quickbase.BulkDeleteResult) {
+//	        log.Printf("deleted %d of %d", p.Deleted, p.Matched)
+//	    },
+//	})
+func (c *Client) BulkDelete(ctx context.Context, table string, where any, opts BulkDeleteOptions) (*BulkDeleteResult, error) {
+	tableID := table
+	if c.schema != nil {
+		resolved, err := core.ResolveTableAlias(c.schema, table)
+		if err != nil {
+			return nil, err
+		}
+		tableID = resolved
+	}
+	base, err := whereString(c, tableID, where)
+	if err != nil {
+		return nil, err
+	}
+	if base == "" {
+		return nil, &core.ValidationError{
+			QuickbaseError: core.QuickbaseError{
+				Message: "where is required for BulkDelete; use {3.GT.0} to delete every record",
+			},
+		}
+	}
+	if opts.MaxRecords <= 0 && !opts.DryRun {
+		return nil, &core.ValidationError{
+			QuickbaseError: core.QuickbaseError{
+				Message: "MaxRecords is required for BulkDelete",
+			},
+		}
+	}
+	chunkSize := orDefault(opts.ChunkSize, defaultDeleteChunkSize)
+
+	// Skip records already deleted by an earlier run
+	lastID := opts.After
+	scanWhere := base
+	if lastID > 0 {
+		scanWhere = andWhere(base, core.F(3).GT(lastID).String())
+	}
+	body := generated.RunQueryJSONRequestBody{From: tableID, Select: &[]int{3}}
+	if body.Where, err = StringToWhereUnion(scanWhere); err != nil {
+		return nil, err
+	}
+
+	// Preview the match count
+	one := 1
+	_, meta, err := c.runQueryPage(ctx, body, 0, &one)
+	if err != nil {
+		return nil, err
+	}
+	result := &BulkDeleteResult{LastRecordId: lastID}
+	if meta != nil {
+		result.Matched = meta.TotalRecords
+	}
+	if opts.MaxRecords > 0 && result.Matched > opts.MaxRecords {
+		return result, fmt.Errorf("%w: %d records match, limit is %d", ErrTooManyRecords, result.Matched, opts.MaxRecords)
+	}
+	if opts.DryRun || result.Matched == 0 {
+		return result, nil
+	}
+
+	// Scan record IDs by keyset, deleting each page's records by ID
+	body.Options = &generated.RunQueryJSONBody_Options{Top: &chunkSize}
+	fetcher, err := c.keysetFetcher(body, 3)
+	if err != nil {
+		return result, err
+	}
+	token := ""
+	for {
+		page, err := fetcher(ctx, 0, token)
+		if err != nil {
+			return result, err
+		}
+		if len(page.data) == 0 {
+			return result, nil
+		}
+		if result.Deleted+len(page.data) > opts.MaxRecords {
+			return result, fmt.Errorf("%w: more than %d records matched during the delete", ErrTooManyRecords, opts.MaxRecords)
+		}
+
+		ids := make([]core.Condition, len(page.data))
+		last := 0
+		for i, record := range page.data {
+			id, ok := unwrapRecord(record)["3"].(float64)
+			if !ok {
+				return result, errors.New("bulk delete: query returned no record ID")
+			}
+			last = int(id)
+			ids[i] = core.F(3).EX(last)
+		}
+		deleted, err := c.DeleteRecords(tableID).Where(andWhere(base, "("+core.Or(ids...).String()+")")).Run(ctx)
+		if err != nil {
+			return result, err
+		}
+
+		result.Deleted += deleted.NumberDeleted()
+		result.LastRecordId = last
+		if opts.Progress != nil {
+			opts.Progress(*result)
+		}
+
+		if page.metadata.NextToken == nil {
+			return result, nil
+		}
+		token = *page.metadata.NextToken
+	}
+}
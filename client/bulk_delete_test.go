@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/qbtest"
+)
+
+// newDeleteTestServer starts a fake server with 30 records, every other one
+// with status "old", and returns a client for it.
+func newDeleteTestServer(t *testing.T, opts ...Option) (*qbtest.Server, *Client, string) {
+	t.Helper()
+	srv, tableID := newFakeTable(t, "Tasks",
+		[]qbtest.Field{
+			{Label: "Name", Type: "text"},   // 6
+			{Label: "Status", Type: "text"}, // 7
+		})
+	for i := range 30 {
+		status := "new"
+		if i%2 == 0 {
+			status = "old"
+		}
+		srv.AddRecords(tableID, map[int]any{6: "task", 7: status})
+	}
+	c := newFakeClient(t, srv, opts...)
+	return srv, c, tableID
+}
+
+func countStatus(srv *qbtest.Server, tableID, status string) int {
+	n := 0
+	for _, r := range srv.Records(tableID) {
+		if r[7] == status {
+			n++
+		}
+	}
+	return n
+}
+
+func TestBulkDelete(t *testing.T) {
+	srv, c, tableID := newDeleteTestServer(t)
+
+	var progress []BulkDeleteResult
+	result, err := c.BulkDelete(context.Background(), tableID, "{7.EX.'old'}OR{6.EX.'missing'}", BulkDeleteOptions{
+		MaxRecords: 15,
+		ChunkSize:  4,
+		Progress:   func(p BulkDeleteResult) { progress = append(progress, p) },
+	})
+	if err != nil {
+		t.Fatalf("BulkDelete() error = %v", err)
+	}
+	if result.Matched != 15 || result.Deleted != 15 {
+		t.Errorf("Matched = %d, Deleted = %d, want 15 and 15", result.Matched, result.Deleted)
+	}
+	if countStatus(srv, tableID, "old") != 0 || countStatus(srv, tableID, "new") != 15 {
+		t.Errorf("records left: %d old, %d new", countStatus(srv, tableID, "old"), countStatus(srv, tableID, "new"))
+	}
+	if len(progress) != 4 {
+		t.Fatalf("got %d progress reports, want 4", len(progress))
+	}
+	if progress[0].Deleted != 4 || progress[0].LastRecordId != 7 {
+		t.Errorf("first progress = %+v, want 4 deleted up to record 7", progress[0])
+	}
+}
+
+func TestBulkDelete_TooMany(t *testing.T) {
+	srv, c, tableID := newDeleteTestServer(t)
+
+	result, err := c.BulkDelete(context.Background(), tableID, "{7.EX.'old'}", BulkDeleteOptions{MaxRecords: 10})
+	if !errors.Is(err, ErrTooManyRecords) {
+		t.Fatalf("BulkDelete() error = %v, want ErrTooManyRecords", err)
+	}
+	if result.Matched != 15 || result.Deleted != 0 {
+		t.Errorf("result = %+v", result)
+	}
+	if len(srv.Records(tableID)) != 30 {
+		t.Error("records were deleted despite exceeding MaxRecords")
+	}
+}
+
+func TestBulkDelete_DryRun(t *testing.T) {
+	srv, c, tableID := newDeleteTestServer(t)
+
+	result, err := c.BulkDelete(context.Background(), tableID, "{7.EX.'old'}", BulkDeleteOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("BulkDelete() error = %v", err)
+	}
+	if result.Matched != 15 || len(srv.Records(tableID)) != 30 {
+		t.Errorf("Matched = %d with %d records left, want 15 with 30", result.Matched, len(srv.Records(tableID)))
+	}
+}
+
+func TestBulkDelete_RequiresMaxRecords(t *testing.T) {
+	srv, c, tableID := newDeleteTestServer(t)
+
+	if _, err := c.BulkDelete(context.Background(), tableID, "{7.EX.'old'}", BulkDeleteOptions{}); err == nil {
+		t.Error("BulkDelete() without MaxRecords succeeded, want error")
+	}
+	if len(srv.Records(tableID)) != 30 {
+		t.Error("records were deleted")
+	}
+}
+
+func TestBulkDelete_Resume(t *testing.T) {
+	// Fail the second delete request
+	deletes := 0
+	srv, c, tableID := newDeleteTestServer(t, WithMiddleware(func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Method == http.MethodDelete {
+				deletes++
+				if deletes == 2 {
+					return nil, errors.New("connection reset")
+				}
+			}
+			return next.Do(req)
+		})
+	}))
+	opts := BulkDeleteOptions{MaxRecords: 15, ChunkSize: 5}
+
+	result, err := c.BulkDelete(context.Background(), tableID, "{7.EX.'old'}", opts)
+	if err == nil {
+		t.Fatal("BulkDelete() succeeded, want injected error")
+	}
+	if result.Deleted != 5 || result.LastRecordId != 9 {
+		t.Fatalf("result after failure = %+v, want 5 deleted up to record 9", result)
+	}
+
+	opts.After = result.LastRecordId
+	resumed, err := c.BulkDelete(context.Background(), tableID, "{7.EX.'old'}", opts)
+	if err != nil {
+		t.Fatalf("resumed BulkDelete() error = %v", err)
+	}
+	if resumed.Matched != 10 || resumed.Deleted != 10 {
+		t.Errorf("resumed = %+v, want 10 matched and deleted", resumed)
+	}
+	if countStatus(srv, tableID, "old") != 0 {
+		t.Errorf("%d old records left", countStatus(srv, tableID, "old"))
+	}
+}
+
+func TestBulkDelete_RecordMatchesDuringDelete(t *testing.T) {
+	// Before the first delete, record 2 (status "new") inside the first
+	// chunk's ID range is changed to match
+	var c *Client
+	flipped := false
+	srv, c, tableID := newDeleteTestServer(t, WithMiddleware(func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Method == http.MethodDelete && !flipped {
+				flipped = true
+				data := map[string]any{"3": map[string]any{"value": 2}, "7": map[string]any{"value": "old"}}
+				if _, err := c.Upsert(RequestDBID(req)).Data(data).Run(req.Context()); err != nil {
+					return nil, err
+				}
+			}
+			return next.Do(req)
+		})
+	}))
+
+	result, err := c.BulkDelete(context.Background(), tableID, "{7.EX.'old'}", BulkDeleteOptions{MaxRecords: 15, ChunkSize: 5})
+	if err != nil {
+		t.Fatalf("BulkDelete() error = %v", err)
+	}
+	if result.Deleted != 15 {
+		t.Errorf("Deleted = %d, want 15", result.Deleted)
+	}
+	if srv.Record(tableID, 2) == nil {
+		t.Error("record 2, which was not scanned, was deleted")
+	}
+}
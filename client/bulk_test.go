@@ -0,0 +1,229 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"iter"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/core"
+)
+
+// upsertServer creates a record for each row of an upsert, except rows whose
+// field 6 is "bad", which get a line error. Field 7 carries the row's input
+// position so tests can check the record ID order.
+type upsertServer struct {
+	mu    sync.Mutex
+	sizes []int
+	concurrencyTracker
+
+	// status may fail the nth request by returning a non-zero status code
+	status func(n int) int
+}
+
+func (s *upsertServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	defer s.enter()()
+	time.Sleep(5 * time.Millisecond)
+
+	var body struct {
+		Data []map[string]struct {
+			Value any `json:"value"`
+		} `json:"data"`
+	}
+	json.NewDecoder(r.Body).Decode(&body)
+
+	s.mu.Lock()
+	s.sizes = append(s.sizes, len(body.Data))
+	requestNum := len(s.sizes)
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if s.status != nil {
+		if code := s.status(requestNum); code != 0 {
+			w.WriteHeader(code)
+			w.Write([]byte(`{"message":"Failed","description":"Injected failure"}`))
+			return
+		}
+	}
+
+	created := []int{}
+	lineErrors := map[string][]string{}
+	for i, record := range body.Data {
+		if record["6"].Value == "bad" {
+			lineErrors[strconv.Itoa(i+1)] = []string{`Incompatible value for field with ID "6".`}
+			continue
+		}
+		pos, _ := record["7"].Value.(float64)
+		created = append(created, 1000+int(pos))
+	}
+	metadata := map[string]any{
+		"createdRecordIds":              created,
+		"updatedRecordIds":              []int{},
+		"unchangedRecordIds":            []int{},
+		"totalNumberOfRecordsProcessed": len(body.Data),
+	}
+	if len(lineErrors) > 0 {
+		metadata["lineErrors"] = lineErrors
+		w.WriteHeader(http.StatusMultiStatus)
+	}
+	json.NewEncoder(w).Encode(map[string]any{"data": []any{}, "metadata": metadata})
+}
+
+// bulkRows yields n rows with field 6 set by name(i) and field 7 set to i.
+func bulkRows(n int, name func(i int) string) iter.Seq[Record] {
+	return func(yield func(Record) bool) {
+		for i := range n {
+			if !yield(Record{"6": name(i), "7": i}) {
+				return
+			}
+		}
+	}
+}
+
+func TestBulkUpsert(t *testing.T) {
+	s := &upsertServer{}
+	c := newHandlerClient(t, s)
+
+	rows := bulkRows(25, func(i int) string { return "row" })
+	result, err := c.BulkUpsert(context.Background(), "bqxyz123", rows, BulkUpsertOptions{BatchSize: 10, Concurrency: 2})
+	if err != nil {
+		t.Fatalf("BulkUpsert() error = %v", err)
+	}
+
+	sizes := slices.Clone(s.sizes)
+	slices.Sort(sizes)
+	if !slices.Equal(sizes, []int{5, 10, 10}) {
+		t.Errorf("batch sizes = %v, want [5 10 10]", sizes)
+	}
+	if result.Batches != 3 || result.Processed != 25 {
+		t.Errorf("Batches = %d, Processed = %d", result.Batches, result.Processed)
+	}
+	for i, id := range result.CreatedRecordIds {
+		if id != 1000+i {
+			t.Fatalf("CreatedRecordIds out of input order: %v", result.CreatedRecordIds)
+		}
+	}
+	if peak := s.peak(); peak > 2 {
+		t.Errorf("peak concurrency = %d, want <= 2", peak)
+	}
+}
+
+func TestBulkUpsert_BatchBytes(t *testing.T) {
+	s := &upsertServer{}
+	c := newHandlerClient(t, s)
+
+	rows := bulkRows(10, func(i int) string { return "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx" })
+	// Each record is about 70 bytes, so two fit under 150
+	if _, err := c.BulkUpsert(context.Background(), "bqxyz123", rows, BulkUpsertOptions{MaxBatchBytes: 150}); err != nil {
+		t.Fatalf("BulkUpsert() error = %v", err)
+	}
+	if len(s.sizes) != 5 {
+		t.Errorf("batch sizes = %v, want 5 batches of 2", s.sizes)
+	}
+}
+
+func TestBulkUpsert_LineErrors(t *testing.T) {
+	s := &upsertServer{}
+	schema := core.NewSchema().Table("projects", "bqxyz123").Field("name", 6).Field("pos", 7).Build()
+	c := newHandlerClient(t, s, WithSchema(schema))
+
+	rows := func(yield func(Record) bool) {
+		for i := range 12 {
+			name := "ok"
+			if i == 3 || i == 11 {
+				name = "bad"
+			}
+			if !yield(Record{"name": name, "pos": i}) {
+				return
+			}
+		}
+	}
+	result, err := c.BulkUpsert(context.Background(), "projects", rows, BulkUpsertOptions{BatchSize: 5})
+	if err != nil {
+		t.Fatalf("BulkUpsert() error = %v", err)
+	}
+
+	if len(result.LineErrors) != 2 {
+		t.Fatalf("LineErrors = %v, want 2", result.LineErrors)
+	}
+	for i, want := range []int{3, 11} {
+		got := result.LineErrors[i]
+		if got.Index != want || got.FieldId != 6 || got.Field != "name" {
+			t.Errorf("LineErrors[%d] = %+v, want index %d on field 6 (name)", i, got, want)
+		}
+	}
+	if len(result.CreatedRecordIds) != 10 || result.Processed != 12 {
+		t.Errorf("created %d of %d processed, want 10 of 12", len(result.CreatedRecordIds), result.Processed)
+	}
+}
+
+func TestBulkUpsert_RetriesFailedBatch(t *testing.T) {
+	s := &upsertServer{status: func(n int) int {
+		if n == 2 {
+			return http.StatusServiceUnavailable
+		}
+		return 0
+	}}
+	c := newHandlerClient(t, s)
+
+	rows := bulkRows(20, func(i int) string { return "row" })
+	result, err := c.BulkUpsert(context.Background(), "bqxyz123", rows, BulkUpsertOptions{
+		BatchSize:   10,
+		Concurrency: 1,
+		RetryPolicy: ExponentialBackoff{InitialDelay: time.Millisecond},
+	})
+	if err != nil {
+		t.Fatalf("BulkUpsert() error = %v", err)
+	}
+	if len(s.sizes) != 3 || len(result.CreatedRecordIds) != 20 {
+		t.Errorf("made %d requests creating %d records, want 3 creating 20", len(s.sizes), len(result.CreatedRecordIds))
+	}
+}
+
+func TestBulkUpsert_BatchFailure(t *testing.T) {
+	s := &upsertServer{status: func(n int) int {
+		if n == 2 {
+			return http.StatusBadRequest
+		}
+		return 0
+	}}
+	c := newHandlerClient(t, s)
+
+	rows := bulkRows(50, func(i int) string { return "row" })
+	result, err := c.BulkUpsert(context.Background(), "bqxyz123", rows, BulkUpsertOptions{BatchSize: 10, Concurrency: 1})
+
+	var batchErr *BulkBatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("BulkUpsert() error = %v, want BulkBatchError", err)
+	}
+	if batchErr.Start != 10 || batchErr.Count != 10 {
+		t.Errorf("failed batch = %d+%d, want 10+10", batchErr.Start, batchErr.Count)
+	}
+	var validationErr *core.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("error does not wrap the API error: %v", err)
+	}
+	if len(s.sizes) > 3 {
+		t.Errorf("made %d requests after failure, want no new batches", len(s.sizes))
+	}
+	if len(result.CreatedRecordIds) != 10 {
+		t.Errorf("result has %d created IDs, want the first batch's 10", len(result.CreatedRecordIds))
+	}
+}
+
+func TestBulkUpsert_UnknownAlias(t *testing.T) {
+	c := newHandlerClient(t, &upsertServer{})
+
+	rows := func(yield func(Record) bool) {
+		yield(Record{"name": "Alpha"})
+	}
+	if _, err := c.BulkUpsert(context.Background(), "bqxyz123", rows, BulkUpsertOptions{}); err == nil {
+		t.Error("BulkUpsert() with alias and no schema succeeded, want error")
+	}
+}
@@ -0,0 +1,299 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// CassetteMode selects whether a Cassette records or replays.
+type CassetteMode int
+
+const (
+	// CassetteRecord sends requests to the server and records every exchange.
+	CassetteRecord CassetteMode = iota
+
+	// CassetteReplay serves recorded responses without network access.
+	CassetteReplay
+)
+
+// ErrCassetteMiss is returned in replay mode when no unused recorded
+// interaction matches a request. It is not retried.
+var ErrCassetteMiss = errors.New("cassette: no recorded interaction matches request")
+
+// redacted replaces credentials in recorded requests and responses.
+const redacted = "REDACTED"
+
+// sensitiveHeaders are request and response headers that are never recorded.
+var sensitiveHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// sensitiveXML matches XML elements holding credentials.
+var sensitiveXML = regexp.MustCompile(`(?s)<(usertoken|ticket|apptoken|password)>.*?</(usertoken|ticket|apptoken|password)>`)
+
+// sensitiveJSON are JSON keys whose values are credentials.
+var sensitiveJSON = map[string]bool{"temporaryAuthorization": true}
+
+// Cassette records the HTTP exchanges made by a client to a file, and replays
+// them offline for deterministic tests. Every attempt is recorded, so replay
+// exercises retries and 401 token refreshes exactly as they happened.
+//
+// Authorization headers, cookies, and <usertoken>, <ticket>, <apptoken> and
+// <password> XML elements are replaced with "REDACTED" before recording.
+// In replay mode, requests are matched on method, path (with query string),
+// XML action and normalized body; identical requests are served in recorded
+// order.
+//
+// Example:
+//
+//	cassette, err := quickbase.NewCassette("testdata/app.cassette.json", quickbase.CassetteReplay)
+//	client, _ := quickbase.New(realm, quickbase.WithUserToken(token), quickbase.WithCassette(cassette))
+//	defer cassette.Save() // writes the file in record mode
+type Cassette struct {
+	mu           sync.Mutex
+	path         string
+	mode         CassetteMode
+	interactions []Interaction
+	used         []bool
+}
+
+// Interaction is one recorded request and response.
+type Interaction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+// CassetteRequest is a recorded request.
+type CassetteRequest struct {
+	Method  string      `json:"method"`
+	Path    string      `json:"path"` // Path and query string
+	Action  string      `json:"action,omitempty"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// CassetteResponse is a recorded response.
+type CassetteResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// NewCassette creates a cassette backed by the file at path. In replay mode
+// the file is loaded and must exist; in record mode it is written by Save.
+func NewCassette(path string, mode CassetteMode) (*Cassette, error) {
+	c := &Cassette{path: path, mode: mode}
+	if mode != CassetteReplay {
+		return c, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("loading cassette: %w", err)
+	}
+	var file struct {
+		Interactions []Interaction `json:"interactions"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parsing cassette %s: %w", path, err)
+	}
+	c.interactions = file.Interactions
+	c.used = make([]bool, len(file.Interactions))
+	return c, nil
+}
+
+// WithCassette records or replays the client's JSON and XML API traffic with
+// the given cassette. A cassette may be shared by several clients.
+func WithCassette(cassette *Cassette) Option {
+	return func(c *Client) {
+		c.cassette = cassette
+	}
+}
+
+// Mode returns the cassette's mode.
+func (c *Cassette) Mode() CassetteMode {
+	return c.mode
+}
+
+// Interactions returns a copy of the recorded interactions.
+func (c *Cassette) Interactions() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Interaction(nil), c.interactions...)
+}
+
+// Save writes the recorded interactions to the cassette file, creating its
+// directory if needed. It does nothing in replay mode.
+func (c *Cassette) Save() error {
+	if c.mode == CassetteReplay {
+		return nil
+	}
+
+	c.mu.Lock()
+	data, err := json.MarshalIndent(struct {
+		Interactions []Interaction `json:"interactions"`
+	}{c.interactions}, "", "  ")
+	c.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("encoding cassette: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("saving cassette: %w", err)
+	}
+	if err := os.WriteFile(c.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("saving cassette: %w", err)
+	}
+	return nil
+}
+
+// Transport returns a RoundTripper that records requests sent through next,
+// or replays them. Use it to cover HTTP clients the SDK client does not own,
+// such as the one passed to auth.WithTicketHTTPClient.
+func (c *Cassette) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &cassetteTransport{cassette: c, next: next}
+}
+
+type cassetteTransport struct {
+	cassette *Cassette
+	next     http.RoundTripper
+}
+
+func (t *cassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading request body: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	recorded := CassetteRequest{
+		Method:  req.Method,
+		Path:    req.URL.RequestURI(),
+		Action:  req.Header.Get("QUICKBASE-ACTION"),
+		Headers: scrubHeaders(req.Header),
+		Body:    scrubBody(body),
+	}
+
+	if t.cassette.mode == CassetteReplay {
+		return t.cassette.replay(req, recorded)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("reading response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	t.cassette.record(Interaction{
+		Request: recorded,
+		Response: CassetteResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    scrubHeaders(resp.Header),
+			Body:       scrubBody(respBody),
+		},
+	})
+	return resp, nil
+}
+
+func (c *Cassette) record(i Interaction) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.interactions = append(c.interactions, i)
+}
+
+// replay serves the first unused interaction matching the request.
+func (c *Cassette) replay(req *http.Request, r CassetteRequest) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	want := normalizeBody(r.Body)
+	for i, in := range c.interactions {
+		if c.used[i] || in.Request.Method != r.Method || in.Request.Path != r.Path ||
+			in.Request.Action != r.Action || normalizeBody(in.Request.Body) != want {
+			continue
+		}
+		c.used[i] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        in.Response.Headers.Clone(),
+			Body:          io.NopCloser(strings.NewReader(in.Response.Body)),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %s %s", ErrCassetteMiss, r.Method, r.Path)
+}
+
+// scrubHeaders copies h without credential headers.
+func scrubHeaders(h http.Header) http.Header {
+	out := h.Clone()
+	for _, name := range sensitiveHeaders {
+		if out.Get(name) != "" {
+			out.Set(name, redacted)
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// scrubBody replaces credentials in an XML or JSON body.
+func scrubBody(body []byte) string {
+	s := sensitiveXML.ReplaceAllString(string(body), "<$1>"+redacted+"</$2>")
+
+	var v any
+	if json.Unmarshal([]byte(s), &v) != nil {
+		return s
+	}
+	if obj, ok := v.(map[string]any); ok {
+		changed := false
+		for key := range obj {
+			if sensitiveJSON[key] {
+				obj[key] = redacted
+				changed = true
+			}
+		}
+		if changed {
+			if b, err := json.Marshal(obj); err == nil {
+				return string(b)
+			}
+		}
+	}
+	return s
+}
+
+// normalizeBody canonicalizes a body for matching: JSON is re-encoded with
+// sorted keys, and other bodies have surrounding whitespace removed.
+func normalizeBody(body string) string {
+	var v any
+	if err := json.Unmarshal([]byte(body), &v); err == nil {
+		if b, err := json.Marshal(v); err == nil {
+			return string(b)
+		}
+	}
+	return strings.TrimSpace(body)
+}
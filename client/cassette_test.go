@@ -0,0 +1,198 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/auth"
+)
+
+func TestCassette_RecordReplay(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusUnauthorized)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id":"bqapp123","name":"App"}`))
+		}
+	}))
+
+	path := filepath.Join(t.TempDir(), "cassettes", "app.json")
+	recorder, err := NewCassette(path, CassetteRecord)
+	if err != nil {
+		t.Fatalf("NewCassette() error = %v", err)
+	}
+	policy := WithRetryPolicy(ExponentialBackoff{MaxAttempts: 3, InitialDelay: time.Millisecond})
+	c, err := New("myrealm", auth.NewUserTokenStrategy("secret-token"),
+		WithBaseURL(srv.URL), WithCassette(recorder), policy)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if _, err := c.GetApp("bqapp123").Run(context.Background()); err != nil {
+		t.Fatalf("recording Run() error = %v", err)
+	}
+	srv.Close()
+
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading cassette: %v", err)
+	}
+	if strings.Contains(string(data), "secret-token") {
+		t.Errorf("cassette contains the user token:\n%s", data)
+	}
+	if n := len(recorder.Interactions()); n != 3 {
+		t.Fatalf("recorded %d interactions, want 3", n)
+	}
+
+	// Replay offline: the 401 and 503 are served again, in order
+	player, err := NewCassette(path, CassetteReplay)
+	if err != nil {
+		t.Fatalf("NewCassette() error = %v", err)
+	}
+	var statuses []int
+	c, err = New("myrealm", auth.NewUserTokenStrategy("other-token"),
+		WithBaseURL("http://127.0.0.1:1"), WithCassette(player), policy,
+		WithOnRequest(func(info RequestInfo) { statuses = append(statuses, info.StatusCode) }))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	app, err := c.GetApp("bqapp123").Run(context.Background())
+	if err != nil {
+		t.Fatalf("replay Run() error = %v", err)
+	}
+	if app.Name() != "App" {
+		t.Errorf("Name() = %q, want App", app.Name())
+	}
+	// The 401 is retried after a token refresh, which onRequest does not report
+	if len(statuses) != 2 || statuses[0] != 503 || statuses[1] != 200 {
+		t.Errorf("statuses = %v, want [503 200]", statuses)
+	}
+
+	// Every interaction, including the 401, is used up, and a miss is not retried
+	statuses = nil
+	_, err = c.GetApp("bqapp123").Run(context.Background())
+	if !errors.Is(err, ErrCassetteMiss) {
+		t.Errorf("error = %v, want ErrCassetteMiss", err)
+	}
+	if len(statuses) != 1 {
+		t.Errorf("got %d attempts after a miss, want 1", len(statuses))
+	}
+}
+
+func TestCassette_ScrubsCredentials(t *testing.T) {
+	cassette, _ := NewCassette(filepath.Join(t.TempDir(), "xml.json"), CassetteRecord)
+	next := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Set-Cookie": []string{"session=abc"}},
+			Body:       io.NopCloser(strings.NewReader("<qdbapi><errcode>0</errcode><ticket>ticket-123</ticket></qdbapi>")),
+		}, nil
+	})
+
+	req, _ := http.NewRequest(http.MethodPost, "https://myrealm.quickbase.com/db/main",
+		strings.NewReader("<qdbapi><username>me</username><password>hunter2</password><apptoken>app-456</apptoken></qdbapi>"))
+	req.Header.Set("QUICKBASE-ACTION", "API_Authenticate")
+	req.Header.Set("Authorization", "QB-USER-TOKEN secret")
+	resp, err := cassette.Transport(next).RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(body), "ticket-123") {
+		t.Errorf("caller should see the real response, got %s", body)
+	}
+
+	in := cassette.Interactions()[0]
+	for _, secret := range []string{"hunter2", "app-456"} {
+		if strings.Contains(in.Request.Body, secret) {
+			t.Errorf("request body not scrubbed: %s", in.Request.Body)
+		}
+	}
+	if !strings.Contains(in.Request.Body, "<username>me</username>") {
+		t.Errorf("request body over-scrubbed: %s", in.Request.Body)
+	}
+	if in.Request.Headers.Get("Authorization") != "REDACTED" || in.Response.Headers.Get("Set-Cookie") != "REDACTED" {
+		t.Errorf("headers not scrubbed: %v %v", in.Request.Headers, in.Response.Headers)
+	}
+	if strings.Contains(in.Response.Body, "ticket-123") {
+		t.Errorf("response body not scrubbed: %s", in.Response.Body)
+	}
+	if in.Request.Path != "/db/main" || in.Request.Action != "API_Authenticate" {
+		t.Errorf("request = %+v", in.Request)
+	}
+}
+
+func TestCassette_ReplayMatching(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "match.json")
+	os.WriteFile(path, []byte(`{"interactions": [
+		{"request": {"method": "POST", "path": "/v1/records/query", "body": "{\"from\":\"bqtable\",\"select\":[3,6]}"},
+		 "response": {"statusCode": 200, "body": "first"}},
+		{"request": {"method": "POST", "path": "/db/bqapp", "action": "API_GetRoleInfo", "body": "<qdbapi><usertoken>REDACTED</usertoken></qdbapi>"},
+		 "response": {"statusCode": 200, "body": "xml"}}
+	]}`), 0o644)
+
+	cassette, err := NewCassette(path, CassetteReplay)
+	if err != nil {
+		t.Fatalf("NewCassette() error = %v", err)
+	}
+	rt := cassette.Transport(nil)
+
+	tests := []struct {
+		name    string
+		path    string
+		action  string
+		body    string
+		want    string
+		wantErr bool
+	}{
+		{"wrong body", "/v1/records/query", "", `{"from":"bqother"}`, "", true},
+		{"json keys in any order", "/v1/records/query", "", `{ "select": [3, 6], "from": "bqtable" }`, "first", false},
+		{"already used", "/v1/records/query", "", `{"from":"bqtable","select":[3,6]}`, "", true},
+		{"wrong action", "/db/bqapp", "API_GetSchema", "<qdbapi><usertoken>real</usertoken></qdbapi>", "", true},
+		{"xml with real token", "/db/bqapp", "API_GetRoleInfo", "<qdbapi><usertoken>real</usertoken></qdbapi>", "xml", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodPost, "https://example.com"+tt.path, strings.NewReader(tt.body))
+			if tt.action != "" {
+				req.Header.Set("QUICKBASE-ACTION", tt.action)
+			}
+			resp, err := rt.RoundTrip(req)
+			if tt.wantErr {
+				if !errors.Is(err, ErrCassetteMiss) {
+					t.Errorf("error = %v, want ErrCassetteMiss", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RoundTrip() error = %v", err)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			if string(body) != tt.want {
+				t.Errorf("body = %q, want %q", body, tt.want)
+			}
+		})
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
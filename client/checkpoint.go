@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// CheckpointStore persists Watch checkpoints so a restarted process resumes
+// where it left off. Implementations must be safe for concurrent use.
+//
+// The SDK provides [MemoryCheckpointStore] and [FileCheckpointStore]; back it
+// with a database or key-value store to share checkpoints between hosts.
+type CheckpointStore interface {
+	// Load returns the checkpoint saved for key, or ok = false if none exists.
+	Load(ctx context.Context, key string) (checkpoint time.Time, ok bool, err error)
+
+	// Save stores the checkpoint for key.
+	Save(ctx context.Context, key string, checkpoint time.Time) error
+}
+
+// MemoryCheckpointStore keeps checkpoints in memory. It survives restarting a
+// watch within a process, but not restarting the process.
+type MemoryCheckpointStore struct {
+	mu          sync.Mutex
+	checkpoints map[string]time.Time
+}
+
+// NewMemoryCheckpointStore creates an empty in-memory checkpoint store.
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{checkpoints: make(map[string]time.Time)}
+}
+
+// Load implements CheckpointStore.
+func (s *MemoryCheckpointStore) Load(ctx context.Context, key string) (time.Time, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.checkpoints[key]
+	return t, ok, nil
+}
+
+// Save implements CheckpointStore.
+func (s *MemoryCheckpointStore) Save(ctx context.Context, key string, checkpoint time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkpoints[key] = checkpoint
+	return nil
+}
+
+// FileCheckpointStore keeps checkpoints in a JSON file, keyed by watch. Saves
+// replace the file atomically, so a crash never leaves it half written.
+type FileCheckpointStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileCheckpointStore creates a checkpoint store backed by the file at
+// path. The file is created on the first save.
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{path: path}
+}
+
+// Load implements CheckpointStore.
+func (s *FileCheckpointStore) Load(ctx context.Context, key string) (time.Time, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	checkpoints, err := s.read()
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	t, ok := checkpoints[key]
+	return t, ok, nil
+}
+
+// Save implements CheckpointStore.
+func (s *FileCheckpointStore) Save(ctx context.Context, key string, checkpoint time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	checkpoints, err := s.read()
+	if err != nil {
+		return err
+	}
+	checkpoints[key] = checkpoint
+
+	data, err := json.MarshalIndent(checkpoints, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// read loads every checkpoint in the file. A missing file has none.
+func (s *FileCheckpointStore) read() (map[string]time.Time, error) {
+	checkpoints := make(map[string]time.Time)
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return checkpoints, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &checkpoints); err != nil {
+		return nil, err
+	}
+	return checkpoints, nil
+}
@@ -25,14 +25,15 @@ import (
 	"context"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
-	"math"
-	"math/rand"
+	"log/slog"
 	"net/http"
 	"regexp"
-	"strconv"
+	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/DrewBradfordXYZ/quickbase-go/v2/auth"
@@ -45,7 +46,7 @@ import (
 //
 // The Client automatically:
 //   - Adds authentication headers to all requests
-//   - Retries failed requests with exponential backoff and jitter
+//   - Retries failed requests per its RetryPolicy (exponential backoff with jitter by default)
 //   - Handles rate limiting (429) responses with automatic retry
 //   - Refreshes expired tokens for temp token and SSO authentication
 //   - Applies proactive throttling to avoid hitting rate limits
@@ -63,6 +64,7 @@ type Client struct {
 	initialDelay   time.Duration
 	maxDelay       time.Duration
 	backoffMult    float64
+	retryPolicy    RetryPolicy
 
 	// Request timeout
 	timeout time.Duration
@@ -73,17 +75,37 @@ type Client struct {
 	idleConnTimeout     time.Duration
 
 	// Rate limiting / throttling
-	throttle Throttle
+	throttle         Throttle
+	throttleRate     int               // Set by WithProactiveThrottle
+	throttleRegistry *ThrottleRegistry // Shares proactive throttles by credentials
+	throttleKey      string            // Registry entry held until Close
+	throttleCleanup  runtime.Cleanup   // Releases the registry entry if Close is never called
+	closeOnce        sync.Once
+
+	// Concurrency limit and priority scheduling
+	maxConcurrency int
+	scheduler      *scheduler
 
 	// Logging
-	logger *core.Logger
+	logger    *core.Logger
+	slogger   *slog.Logger
+	logLevels *core.LogLevels
 
 	// Date conversion
 	convertDates bool
 
+	// How numbers in records are decoded
+	numbers NumberMode
+
 	// Schema for table/field aliases
 	schema *core.ResolvedSchema
 
+	// Middleware wrapping every JSON and XML call
+	middleware []Middleware
+
+	// Metrics collector (nil if disabled)
+	metrics *Metrics
+
 	// Callbacks
 	onRateLimit func(core.RateLimitInfo)
 	onRequest   func(RequestInfo)
@@ -92,6 +114,15 @@ type Client struct {
 	// Transport for cleanup
 	transport *http.Transport
 
+	// Cassette recording or replaying traffic (nil if disabled)
+	cassette *Cassette
+
+	// Transport set with WithTransport, replacing transport for requests
+	customTransport http.RoundTripper
+
+	// Round tripper used for requests: the transport, wrapped by the cassette
+	roundTripper http.RoundTripper
+
 	// Read-only mode blocks all write operations
 	readOnly bool
 
@@ -103,13 +134,14 @@ type Client struct {
 // RequestInfo contains information about a completed API request.
 // This is passed to the OnRequest callback after each request completes.
 type RequestInfo struct {
-	Method      string        // HTTP method (GET, POST, etc.)
-	Path        string        // URL path (e.g., /v1/apps/bqxyz123)
-	StatusCode  int           // HTTP status code
-	Duration    time.Duration // Total request duration
-	Attempt     int           // Attempt number (1 = first try, 2+ = retries)
-	Error       error         // Non-nil if request failed
-	RequestBody []byte        // Request body bytes (for debugging failed requests)
+	Method       string        // HTTP method (GET, POST, etc.)
+	Path         string        // URL path (e.g., /v1/apps/bqxyz123)
+	StatusCode   int           // HTTP status code
+	Duration     time.Duration // Total request duration
+	Attempt      int           // Attempt number (1 = first try, 2+ = retries)
+	Error        error         // Non-nil if request failed
+	RequestBody  []byte        // Request body bytes (for debugging failed requests)
+	ThrottleWait time.Duration // Time spent waiting on the throttle and a concurrency slot before this attempt
 }
 
 // RetryInfo contains information about a retry attempt.
@@ -125,7 +157,9 @@ type RetryInfo struct {
 // Option configures a Client.
 type Option func(*Client)
 
-// WithMaxRetries sets the maximum number of retry attempts (default 3).
+// WithMaxRetries sets the maximum number of attempts, including the first (default 3).
+// This and the other backoff options configure the default ExponentialBackoff
+// policy and are ignored when WithRetryPolicy is set.
 func WithMaxRetries(n int) Option {
 	return func(c *Client) {
 		c.maxRetries = n
@@ -184,21 +218,39 @@ func WithIdleConnTimeout(d time.Duration) Option {
 	}
 }
 
+// WithTransport replaces the client's HTTP transport. Connection pool options
+// are ignored. Use it for proxies or test transports such as
+// qbtest.FaultInjector; requests pass through it on every attempt, after
+// throttling and authentication.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) {
+		c.customTransport = rt
+	}
+}
+
 // WithThrottle sets a custom throttle.
 func WithThrottle(t Throttle) Option {
 	return func(c *Client) {
 		c.throttle = t
+		c.throttleRate = 0
 	}
 }
 
 // WithProactiveThrottle enables sliding window throttling (100 req/10s by default).
+// Clients with the same realm and credentials share one window through
+// DefaultThrottleRegistry; see WithThrottleRegistry.
 func WithProactiveThrottle(requestsPer10Seconds int) Option {
 	return func(c *Client) {
-		c.throttle = NewSlidingWindowThrottle(requestsPer10Seconds)
+		c.throttle = nil
+		c.throttleRate = requestsPer10Seconds
+		if c.throttleRate <= 0 {
+			c.throttleRate = 100
+		}
 	}
 }
 
-// WithDebug enables debug logging.
+// WithDebug enables debug logging through the standard log package.
+// Use WithLogger for structured logging.
 func WithDebug(enabled bool) Option {
 	return func(c *Client) {
 		c.logger = core.NewLogger(enabled)
@@ -212,6 +264,15 @@ func WithConvertDates(enabled bool) Option {
 	}
 }
 
+// WithNumberMode sets how numbers in unwrapped records are decoded (default
+// NumberFloat64). Use NumberJSON or NumberDecimal for values with more than 15
+// significant digits, such as large record IDs or amounts.
+func WithNumberMode(mode NumberMode) Option {
+	return func(c *Client) {
+		c.numbers = mode
+	}
+}
+
 // WithOnRateLimit sets a callback for rate limit events.
 func WithOnRateLimit(callback func(core.RateLimitInfo)) Option {
 	return func(c *Client) {
@@ -351,16 +412,31 @@ func New(realm string, authStrategy auth.Strategy, opts ...Option) (*Client, err
 		timeout:      30 * time.Second,
 		logger:       core.NewLogger(false),
 		convertDates: true,
+
+		throttleRegistry: DefaultThrottleRegistry,
 	}
 
 	for _, opt := range opts {
 		opt(c)
 	}
 
+	// Structured logging takes precedence over WithDebug
+	if c.slogger != nil {
+		levels := core.DefaultLogLevels()
+		if c.logLevels != nil {
+			levels = *c.logLevels
+		}
+		c.logger = core.NewSlogLogger(c.slogger, levels)
+	}
+
 	// Create throttle if not provided (disabled by default, like JS SDK)
+	if c.throttle == nil && c.throttleRate > 0 {
+		c.throttle = c.proactiveThrottle()
+	}
 	if c.throttle == nil {
 		c.throttle = NewNoOpThrottle()
 	}
+	c.scheduler = newScheduler(c.throttle, c.maxConcurrency)
 
 	// Create HTTP transport with connection pool settings
 	// Go's default MaxIdleConnsPerHost (2) is based on obsolete RFC 2616 (1999).
@@ -380,12 +456,20 @@ func New(realm string, authStrategy auth.Strategy, opts ...Option) (*Client, err
 		transport.IdleConnTimeout = c.idleConnTimeout
 	}
 
+	var roundTripper http.RoundTripper = transport
+	if c.customTransport != nil {
+		roundTripper = c.customTransport
+	}
+	if c.cassette != nil {
+		roundTripper = c.cassette.Transport(roundTripper)
+	}
+
 	// Create the generated client with our custom HTTP doer
 	httpClient := &authHTTPClient{
 		client: c,
 		httpClient: &http.Client{
 			Timeout:   c.timeout,
-			Transport: transport,
+			Transport: roundTripper,
 		},
 	}
 
@@ -395,11 +479,13 @@ func New(realm string, authStrategy auth.Strategy, opts ...Option) (*Client, err
 		generated.WithRequestEditorFn(c.addHeaders),
 	)
 	if err != nil {
+		c.releaseThrottle()
 		return nil, fmt.Errorf("creating client: %w", err)
 	}
 
 	c.generated = genClient
 	c.transport = transport
+	c.roundTripper = roundTripper
 	return c, nil
 }
 
@@ -453,16 +539,41 @@ type authHTTPClient struct {
 
 func (h *authHTTPClient) Do(req *http.Request) (*http.Response, error) {
 	c := h.client
-	ctx := req.Context()
 
-	// Check read-only mode before making request
+	// Extract dbid from request for temp token auth
+	dbid := extractDBID(req)
+
+	// Also check body for dbid if not found elsewhere
+	if dbid == "" && req.Body != nil {
+		bodyBytes, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("reading request body: %w", err)
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+		dbid = extractDBIDFromBody(bodyBytes)
+	}
+
+	req = req.WithContext(withDBID(req.Context(), dbid))
+	resp, err := c.chain(DoerFunc(c.doJSON)).Do(req)
+	if err == nil && resp == nil {
+		return nil, errNilResponse
+	}
+	return resp, err
+}
+
+// errNilResponse is returned when middleware returns neither a response nor
+// an error.
+var errNilResponse = errors.New("middleware returned a nil response without an error")
+
+// doJSON executes a JSON API request after middleware, with retry, throttling
+// and authentication. Read-only mode is enforced here, after middleware, so
+// it applies to the request as middleware left it.
+func (c *Client) doJSON(req *http.Request) (*http.Response, error) {
 	if err := c.checkReadOnly(req); err != nil {
 		return nil, err
 	}
 
-	// Extract dbid from request for temp token auth
-	dbid := extractDBID(req)
-
 	// Read body once for potential retries
 	var bodyBytes []byte
 	if req.Body != nil {
@@ -472,18 +583,15 @@ func (h *authHTTPClient) Do(req *http.Request) (*http.Response, error) {
 			return nil, fmt.Errorf("reading request body: %w", err)
 		}
 		req.Body.Close()
-
-		// Also check body for dbid if not found elsewhere
-		if dbid == "" {
-			dbid = extractDBIDFromBody(bodyBytes)
-		}
 	}
+	req = withResolvedDBID(req, bodyBytes)
+	ctx := req.Context()
 
 	// Request factory for JSON requests
 	requestFactory := func(ctx context.Context, token string) (*http.Request, error) {
 		reqCopy := req.Clone(ctx)
 		if bodyBytes != nil {
-			reqCopy.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+			setRequestBody(reqCopy, bodyBytes)
 		}
 		c.auth.ApplyAuth(reqCopy, token)
 		return reqCopy, nil
@@ -498,7 +606,7 @@ func (h *authHTTPClient) Do(req *http.Request) (*http.Response, error) {
 		return false, nil // No special handling, use standard logic
 	}
 
-	return c.do(ctx, dbid, req.Method, req.URL.Path, bodyBytes, requestFactory, responseHandler)
+	return c.do(ctx, RequestDBID(req), req.Method, req.URL.Path, bodyBytes, requestFactory, responseHandler)
 }
 
 // requestFactory creates an authenticated request for a retry attempt.
@@ -507,7 +615,8 @@ type requestFactory func(ctx context.Context, token string) (*http.Request, erro
 // responseHandler handles a response and returns true if a retry with token refresh is needed.
 type responseHandler func(resp *http.Response) (bool, error)
 
-// do centralizes request execution logic (retry, throttle, auth, callbacks).
+// do centralizes request execution logic (retry, throttle, auth, callbacks)
+// and records the call in the client's metrics.
 func (c *Client) do(
 	ctx context.Context,
 	dbid string,
@@ -517,51 +626,136 @@ func (c *Client) do(
 	factory requestFactory,
 	handler responseHandler,
 ) (*http.Response, error) {
+	var stats callStats
 	startTime := time.Now()
-	var lastResp *http.Response
-	var lastErr error
+	resp, err := c.doAttempts(ctx, dbid, method, path, bodyBytes, factory, handler, &stats)
 
-	for attempt := 1; attempt <= c.maxRetries; attempt++ {
+	if c.metrics != nil {
+		if stats.operation == "" {
+			stats.operation = pathOperation(method, path)
+		}
+		c.metrics.Observe(Observation{
+			Operation:    stats.operation,
+			Table:        dbid,
+			StatusCode:   stats.status,
+			ErrorClass:   errorClass(stats.status, err),
+			Duration:     time.Since(startTime),
+			Retries:      stats.retries,
+			ThrottleWait: stats.throttleWait,
+		})
+	}
+	return resp, err
+}
+
+// doAttempts runs the attempt loop for do, accumulating stats for metrics.
+func (c *Client) doAttempts(
+	ctx context.Context,
+	dbid string,
+	method string,
+	path string,
+	bodyBytes []byte,
+	factory requestFactory,
+	handler responseHandler,
+	stats *callStats,
+) (*http.Response, error) {
+	startTime := time.Now()
+	policy := c.retryPolicyFor(ctx)
+	var prevDelay time.Duration
+
+	// newAttempt fills in the request details the retry policy sees.
+	newAttempt := func(a RetryAttempt) RetryAttempt {
+		a.Method, a.Path, a.PrevDelay = method, path, prevDelay
+		return a
+	}
+
+	// wait logs and reports a retry, then sleeps for its delay. It returns an
+	// error if the context is cancelled while waiting.
+	wait := func(a RetryAttempt, delay time.Duration, reason, logReason string) error {
+		attempt := a.Attempt
+		attrs := append(requestAttrs(method, path, dbid, attempt),
+			slog.Int("max_attempts", maxAttempts(policy)),
+			slog.Duration("delay", delay),
+			slog.String("reason", logReason),
+		)
+		if a.StatusCode != 0 {
+			attrs = append(attrs, slog.Int("status", a.StatusCode))
+		}
+		if a.Err != nil {
+			attrs = append(attrs, slog.String("error", a.Err.Error()))
+		}
+		c.logger.LogAttrs(ctx, c.logger.Levels().Retry, "retrying request", attrs...)
+
+		// Notify onRetry callback
+		if c.onRetry != nil {
+			c.onRetry(RetryInfo{
+				Method:   method,
+				Path:     path,
+				Attempt:  attempt + 1,
+				Reason:   reason,
+				WaitTime: delay,
+			})
+		}
+
+		prevDelay = delay
+		stats.retries++
+		return sleepContext(ctx, delay)
+	}
+
+	for attempt := 1; ; attempt++ {
 		// Check context before each attempt
 		if ctx.Err() != nil {
 			return nil, ctx.Err()
 		}
 
-		// Throttling
-		if err := c.throttle.Acquire(ctx); err != nil {
+		// Throttling and concurrency slot, in priority order
+		throttleStart := time.Now()
+		releaseSlot, err := c.scheduler.acquire(ctx)
+		throttleWait := time.Since(throttleStart)
+		stats.throttleWait += throttleWait
+		if err != nil {
 			return nil, fmt.Errorf("throttle: %w", err)
 		}
 
 		// Get auth token
 		token, err := c.auth.GetToken(ctx, dbid)
 		if err != nil {
+			releaseSlot()
 			return nil, fmt.Errorf("getting auth token: %w", err)
 		}
 
 		// Create authenticated request
 		req, err := factory(ctx, token)
 		if err != nil {
+			releaseSlot()
 			return nil, fmt.Errorf("creating request: %w", err)
 		}
+		write := isWriteRequest(req)
+		if stats.operation == "" {
+			stats.operation = operationName(req)
+		}
 
 		// Make request
 		reqStartTime := time.Now()
 		resp, err := c.httpClient().Do(req)
+		releaseSlot()
 		duration := time.Since(reqStartTime)
+		stats.status = 0
+		if resp != nil {
+			stats.status = resp.StatusCode
+		}
 
 		if err != nil {
-			lastErr = err
-
 			// Notify onRequest callback (with error)
 			if c.onRequest != nil {
 				c.onRequest(RequestInfo{
-					Method:      method,
-					Path:        path,
-					StatusCode:  0,
-					Duration:    duration,
-					Attempt:     attempt,
-					Error:       err,
-					RequestBody: bodyBytes,
+					Method:       method,
+					Path:         path,
+					StatusCode:   0,
+					Duration:     duration,
+					Attempt:      attempt,
+					Error:        err,
+					RequestBody:  bodyBytes,
+					ThrottleWait: throttleWait,
 				})
 			}
 
@@ -570,22 +764,11 @@ func (c *Client) do(
 				return nil, core.NewTimeoutError(int(c.timeout.Milliseconds()))
 			}
 
-			if attempt < c.maxRetries {
-				delay := c.calculateBackoff(attempt)
-				c.logger.Retry(attempt, c.maxRetries, delay, "network error")
-
-				// Notify onRetry callback
-				if c.onRetry != nil {
-					c.onRetry(RetryInfo{
-						Method:   method,
-						Path:     path,
-						Attempt:  attempt + 1,
-						Reason:   "network error",
-						WaitTime: delay,
-					})
+			a := newAttempt(RetryAttempt{Attempt: attempt, Err: err, Write: write})
+			if delay, ok := policy.Retry(a); ok {
+				if err := wait(a, delay, "network error", "network error"); err != nil {
+					return nil, err
 				}
-
-				time.Sleep(delay)
 				continue
 			}
 			return nil, err
@@ -596,12 +779,13 @@ func (c *Client) do(
 			// Notify onRequest callback (429)
 			if c.onRequest != nil {
 				c.onRequest(RequestInfo{
-					Method:      method,
-					Path:        path,
-					StatusCode:  resp.StatusCode,
-					Duration:    duration,
-					Attempt:     attempt,
-					RequestBody: bodyBytes,
+					Method:       method,
+					Path:         path,
+					StatusCode:   resp.StatusCode,
+					Duration:     duration,
+					Attempt:      attempt,
+					RequestBody:  bodyBytes,
+					ThrottleWait: throttleWait,
 				})
 			}
 
@@ -617,38 +801,33 @@ func (c *Client) do(
 				Attempt:    attempt,
 			}
 
-			if ra := resp.Header.Get("Retry-After"); ra != "" {
-				info.RetryAfter, _ = strconv.Atoi(ra)
-			}
+			retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+			info.RetryAfter = int(retryAfter / time.Second)
 
-			c.logger.RateLimit(info)
+			c.logger.LogAttrs(ctx, c.logger.Levels().RateLimit, "rate limited",
+				append(append(requestAttrs(method, path, dbid, attempt),
+					slog.Int("status", resp.StatusCode),
+					slog.Duration("retry_after", retryAfter),
+				), rayAttrs(resp.Header)...)...)
 
-			// Notify callback
+			// Notify callback and adaptive throttle
 			if c.onRateLimit != nil {
 				c.onRateLimit(info)
 			}
+			if feedback, ok := c.throttle.(ThrottleFeedback); ok {
+				feedback.OnRateLimit(info)
+			}
 
-			if attempt < c.maxRetries {
-				var delay time.Duration
-				if info.RetryAfter > 0 {
-					delay = time.Duration(info.RetryAfter) * time.Second
-				} else {
-					delay = c.calculateBackoff(attempt)
-				}
-				c.logger.Retry(attempt, c.maxRetries, delay, "rate limited (429)")
-
-				// Notify onRetry callback
-				if c.onRetry != nil {
-					c.onRetry(RetryInfo{
-						Method:   method,
-						Path:     path,
-						Attempt:  attempt + 1,
-						Reason:   "429",
-						WaitTime: delay,
-					})
+			a := newAttempt(RetryAttempt{
+				Attempt:    attempt,
+				StatusCode: resp.StatusCode,
+				RetryAfter: retryAfter,
+				Write:      write,
+			})
+			if delay, ok := policy.Retry(a); ok {
+				if err := wait(a, delay, "429", "rate limited (429)"); err != nil {
+					return nil, err
 				}
-
-				time.Sleep(delay)
 				continue
 			}
 
@@ -662,80 +841,80 @@ func (c *Client) do(
 		}
 
 		if shouldRefresh {
-			newToken, err := c.auth.HandleAuthError(ctx, resp.StatusCode, dbid, attempt, c.maxRetries)
+			newToken, err := c.auth.HandleAuthError(ctx, resp.StatusCode, dbid, attempt, authAttempts(policy))
 			if err != nil {
 				return nil, err
 			}
 			if newToken != "" {
-				c.logger.Debug("Token refreshed, retrying request")
+				c.logger.LogAttrs(ctx, c.logger.Levels().TokenRefresh, "token refreshed, retrying request",
+					append(requestAttrs(method, path, dbid, attempt), slog.Int("status", resp.StatusCode))...)
 				continue
 			}
 			// If we couldn't refresh but should have, proceed to standard error handling
 		}
 
 		// Handle 5xx server errors with retry
-		if resp.StatusCode >= 500 && attempt < c.maxRetries {
-			// Notify onRequest callback (5xx)
-			if c.onRequest != nil {
-				c.onRequest(RequestInfo{
-					Method:      method,
-					Path:        path,
-					StatusCode:  resp.StatusCode,
-					Duration:    duration,
-					Attempt:     attempt,
-					RequestBody: bodyBytes,
-				})
-			}
+		if resp.StatusCode >= 500 {
+			a := newAttempt(RetryAttempt{
+				Attempt:    attempt,
+				StatusCode: resp.StatusCode,
+				RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+				Write:      write,
+			})
+			if delay, ok := policy.Retry(a); ok {
+				// Notify onRequest callback (5xx)
+				if c.onRequest != nil {
+					c.onRequest(RequestInfo{
+						Method:       method,
+						Path:         path,
+						StatusCode:   resp.StatusCode,
+						Duration:     duration,
+						Attempt:      attempt,
+						RequestBody:  bodyBytes,
+						ThrottleWait: throttleWait,
+					})
+				}
 
-			resp.Body.Close()
-			delay := c.calculateBackoff(attempt)
-			c.logger.Retry(attempt, c.maxRetries, delay, fmt.Sprintf("server error (%d)", resp.StatusCode))
-
-			// Notify onRetry callback
-			if c.onRetry != nil {
-				c.onRetry(RetryInfo{
-					Method:   method,
-					Path:     path,
-					Attempt:  attempt + 1,
-					Reason:   fmt.Sprintf("%d", resp.StatusCode),
-					WaitTime: delay,
-				})
+				resp.Body.Close()
+				if err := wait(a, delay, fmt.Sprintf("%d", resp.StatusCode), fmt.Sprintf("server error (%d)", resp.StatusCode)); err != nil {
+					return nil, err
+				}
+				continue
 			}
+		}
 
-			time.Sleep(delay)
-			continue
+		// Report accepted requests to an adaptive throttle
+		if feedback, ok := c.throttle.(ThrottleFeedback); ok && resp.StatusCode < 500 {
+			feedback.OnSuccess()
 		}
 
 		// Notify onRequest callback (success or final attempt)
 		if c.onRequest != nil {
 			c.onRequest(RequestInfo{
-				Method:      method,
-				Path:        path,
-				StatusCode:  resp.StatusCode,
-				Duration:    duration,
-				Attempt:     attempt,
-				RequestBody: bodyBytes,
+				Method:       method,
+				Path:         path,
+				StatusCode:   resp.StatusCode,
+				Duration:     duration,
+				Attempt:      attempt,
+				RequestBody:  bodyBytes,
+				ThrottleWait: throttleWait,
 			})
 		}
 
-		lastResp = resp
-		lastErr = nil
-		break
-	}
-
-	if lastErr != nil {
-		return nil, lastErr
+		c.logger.LogAttrs(ctx, c.logger.Levels().Request, "request completed",
+			append(append(requestAttrs(method, path, dbid, attempt),
+				slog.Int("status", resp.StatusCode),
+				slog.Duration("duration", time.Since(startTime)),
+			), rayAttrs(resp.Header)...)...)
+		return resp, nil
 	}
-
-	c.logger.Timing(method, path, time.Since(startTime))
-	return lastResp, nil
 }
 
 // httpClient returns the base http.Client.
 func (c *Client) httpClient() *http.Client {
 	return &http.Client{
 		Timeout:   c.timeout,
-		Transport: c.transport,
+		Transport: c.roundTripper,
 	}
 }
 
@@ -756,10 +935,49 @@ func (c *Client) httpClient() *http.Client {
 // Deprecated: This method supports legacy XML API endpoints. Use JSON API methods
 // where possible. This will be removed when QuickBase discontinues the XML API.
 func (c *Client) DoXML(ctx context.Context, dbid, action string, body []byte) ([]byte, error) {
-	// Check read-only mode for XML write actions
-	if c.readOnly && isXMLWriteAction(action) {
-		return nil, core.NewReadOnlyError(http.MethodPost, "/db/"+dbid, action)
+	url := fmt.Sprintf("https://%s.quickbase.com/db/%s", c.realm, dbid)
+	req, err := http.NewRequestWithContext(withDBID(ctx, dbid), http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
 	}
+	req.Header.Set("Content-Type", "application/xml")
+	req.Header.Set("QUICKBASE-ACTION", action)
+
+	resp, err := c.chain(DoerFunc(c.doXML)).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, errNilResponse
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading XML response: %w", err)
+	}
+
+	return respBody, nil
+}
+
+// doXML executes an XML API request after middleware, with retry, throttling
+// and authentication. Like doJSON, it enforces read-only mode after middleware.
+func (c *Client) doXML(req *http.Request) (*http.Response, error) {
+	if err := c.checkReadOnly(req); err != nil {
+		return nil, err
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("reading request body: %w", err)
+		}
+		req.Body.Close()
+	}
+	req = withResolvedDBID(req, body)
+	ctx := req.Context()
 
 	// Request factory for XML requests
 	requestFactory := func(ctx context.Context, token string) (*http.Request, error) {
@@ -779,35 +997,26 @@ func (c *Client) DoXML(ctx context.Context, dbid, action string, body []byte) ([
 			usesXMLAuth = true
 		}
 
-		url := fmt.Sprintf("https://%s.quickbase.com/db/%s", c.realm, dbid)
-		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(xmlBody))
-		if err != nil {
-			return nil, err
-		}
-
-		req.Header.Set("Content-Type", "application/xml")
-		req.Header.Set("QUICKBASE-ACTION", action)
+		reqCopy := req.Clone(ctx)
+		setRequestBody(reqCopy, xmlBody)
 
 		// Apply auth via header only if strategy doesn't support XML body auth
 		if !usesXMLAuth {
-			c.auth.ApplyAuth(req, token)
+			c.auth.ApplyAuth(reqCopy, token)
 		}
 
-		return req, nil
+		return reqCopy, nil
 	}
 
-	var respBody []byte
-
 	// Response handler for XML requests
 	responseHandler := func(resp *http.Response) (bool, error) {
-		defer resp.Body.Close()
-
-		// Read response body
-		var err error
-		respBody, err = io.ReadAll(resp.Body)
+		// Read response body, then restore it for the caller
+		respBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
 		if err != nil {
 			return false, fmt.Errorf("reading XML response: %w", err)
 		}
+		resp.Body = io.NopCloser(bytes.NewReader(respBody))
 
 		// Check for XML-level errors (errcode)
 		// QuickBase XML API often returns 200 OK but with an errcode in the body
@@ -826,27 +1035,38 @@ func (c *Client) DoXML(ctx context.Context, dbid, action string, body []byte) ([
 		return false, nil
 	}
 
-	_, err := c.do(ctx, dbid, http.MethodPost, "/db/"+dbid, body, requestFactory, responseHandler)
+	start := time.Now()
+	resp, err := c.do(ctx, RequestDBID(req), http.MethodPost, req.URL.Path, body, requestFactory, responseHandler)
+
+	attrs := []slog.Attr{
+		slog.String("action", req.Header.Get("QUICKBASE-ACTION")),
+		slog.String("dbid", RequestDBID(req)),
+		slog.Duration("duration", time.Since(start)),
+	}
+	if resp != nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	}
 	if err != nil {
-		return nil, err
+		attrs = append(attrs, slog.String("error", err.Error()))
 	}
+	c.logger.LogAttrs(ctx, c.logger.Levels().XML, "xml request", attrs...)
 
-	return respBody, nil
+	return resp, err
 }
 
-// calculateBackoff calculates exponential backoff with jitter.
-func (c *Client) calculateBackoff(attempt int) time.Duration {
-	delay := float64(c.initialDelay) * math.Pow(c.backoffMult, float64(attempt-1))
-
-	// Add jitter: ±10%
-	jitter := delay * 0.1 * (rand.Float64()*2 - 1)
-	delay += jitter
-
-	if delay > float64(c.maxDelay) {
-		delay = float64(c.maxDelay)
+// setRequestBody replaces a request's body, keeping ContentLength and GetBody
+// consistent with it.
+func setRequestBody(req *http.Request, body []byte) {
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
 	}
+}
 
-	return time.Duration(delay)
+// calculateBackoff calculates exponential backoff with jitter.
+func (c *Client) calculateBackoff(attempt int) time.Duration {
+	return c.defaultRetryPolicy().Backoff(attempt)
 }
 
 // calculateXMLBackoff is kept for backward compatibility but calls calculateBackoff
@@ -887,12 +1107,14 @@ func injectXMLAuth(body []byte, elemName, elemValue string) []byte {
 	return []byte(bodyStr[:insertPos] + authElem + bodyStr[insertPos:])
 }
 
-// Close closes idle connections and releases resources.
+// Close closes idle connections and releases resources, including the
+// client's share of a registered throttle (see ThrottleRegistry).
 // After calling Close, the client should not be used.
 func (c *Client) Close() {
 	if c.transport != nil {
 		c.transport.CloseIdleConnections()
 	}
+	c.closeOnce.Do(c.releaseThrottle)
 }
 
 // SignOut clears credentials from memory if the auth strategy supports it.
@@ -946,6 +1168,23 @@ func extractDBID(req *http.Request) string {
 	return ""
 }
 
+// withResolvedDBID re-resolves the dbid of a request after middleware, which
+// may have rewritten its URL or body, and updates RequestDBID to match.
+func withResolvedDBID(req *http.Request, body []byte) *http.Request {
+	dbid := extractDBID(req)
+	if dbid == "" {
+		if xmlDBID, ok := strings.CutPrefix(req.URL.Path, "/db/"); ok {
+			dbid = xmlDBID
+		} else {
+			dbid = extractDBIDFromBody(body)
+		}
+	}
+	if dbid == RequestDBID(req) {
+		return req
+	}
+	return req.WithContext(withDBID(req.Context(), dbid))
+}
+
 // extractDBIDFromBody extracts dbid from request body JSON.
 func extractDBIDFromBody(body []byte) string {
 	if len(body) == 0 {
@@ -1167,38 +1406,28 @@ func isJSONReadOnlyPOSTEndpoint(path string) bool {
 
 // checkReadOnly returns an error if the request is a write operation and read-only mode is enabled.
 func (c *Client) checkReadOnly(req *http.Request) error {
-	if !c.readOnly {
+	if !c.readOnly || !isWriteRequest(req) {
 		return nil
 	}
+	return core.NewReadOnlyError(req.Method, req.URL.Path, req.Header.Get("QUICKBASE-ACTION"))
+}
 
+// isWriteRequest reports whether a JSON or XML API request modifies data.
+func isWriteRequest(req *http.Request) bool {
 	method := req.Method
 	path := req.URL.Path
 
 	// Check for XML API requests first (they use POST for everything)
 	if action := req.Header.Get("QUICKBASE-ACTION"); action != "" {
-		if isXMLWriteAction(action) {
-			return core.NewReadOnlyError(method, path, action)
-		}
-		return nil // Allow read-only XML actions
+		return isXMLWriteAction(action)
 	}
 
 	// Layer 1: Explicit blocklist check (defense-in-depth)
-	if isJSONWriteEndpoint(method, path) {
-		// Exception: Some POST endpoints are read-only (RunQuery, RunReport, etc.)
-		if method == http.MethodPost && isJSONReadOnlyPOSTEndpoint(path) {
-			return nil
-		}
-		return core.NewReadOnlyError(method, path, "")
-	}
-
 	// Layer 2: HTTP method check (catch-all for any endpoints not in blocklist)
-	if isWriteMethod(method) {
-		// Exception: Some POST endpoints are read-only
-		if method == http.MethodPost && isJSONReadOnlyPOSTEndpoint(path) {
-			return nil
-		}
-		return core.NewReadOnlyError(method, path, "")
+	if isJSONWriteEndpoint(method, path) || isWriteMethod(method) {
+		// Exception: Some POST endpoints are read-only (RunQuery, RunReport, etc.)
+		return !(method == http.MethodPost && isJSONReadOnlyPOSTEndpoint(path))
 	}
 
-	return nil
+	return false
 }
@@ -0,0 +1,355 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/core"
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/generated"
+)
+
+// ExportFormat is the file format written by Export.
+type ExportFormat string
+
+// Export formats.
+const (
+	ExportCSV   ExportFormat = "csv"   // Comma-separated values with a header row
+	ExportJSONL ExportFormat = "jsonl" // One JSON object per line
+)
+
+// HeaderStyle selects the column names Export writes: the CSV header row, or
+// the keys of each JSON Lines object.
+type HeaderStyle string
+
+// Header styles.
+const (
+	HeaderLabels  HeaderStyle = "labels"  // Field labels, as currently shown in QuickBase
+	HeaderAliases HeaderStyle = "aliases" // Schema aliases, falling back to the label
+	HeaderIDs     HeaderStyle = "ids"     // Field IDs
+)
+
+// ExportOptions configures Export.
+type ExportOptions struct {
+	Format      ExportFormat // File format (default ExportCSV)
+	Fields      []any        // Columns, as field IDs or aliases (default: every field in the table)
+	Where       any          // Filter, as a query string or core.Condition (default: all records)
+	HeaderStyle HeaderStyle  // Column names (default HeaderLabels)
+
+	// Location converts timestamp fields to this time zone. By default they
+	// are written in UTC, as QuickBase returns them.
+	Location *time.Location
+}
+
+// Export writes the records of a table to w as CSV or JSON Lines, streaming
+// one page at a time so large tables are never held in memory. It returns the
+// number of records written. If the export fails partway, the records read
+// before the failure are flushed to w and counted in n.
+//
+// Records are exported in record ID order, paging by record ID, so records
+// edited during the export are neither skipped nor repeated. Values are
+// rendered by field type:
+//
+//   - Dates and timestamps as ISO 8601 text (timestamps in opts.Location)
+//   - Multi-select text as a JSON array, or "a; b" in CSV
+//   - User fields as a User object, or the user's email in CSV
+//   - File attachments as the download URL of the latest version
+//
+// Example:
+//
+//	f, _ := os.Create("projects.csv")
+//	defer f.Close()
+//	n, err := client.Export(ctx, "projects", f, quickbase.ExportOptions{
+//	    Fields:      []any{"name", "status", "owner"},
+//	    Where:       "{'status'.XEX.'Closed'}",
+//	    HeaderStyle: quickbase.HeaderAliases,
+//	})
+func (c *Client) Export(ctx context.Context, table string, w io.Writer, opts ExportOptions) (int, error) {
+	tableID := table
+	if c.schema != nil {
+		resolved, err := core.ResolveTableAlias(c.schema, table)
+		if err != nil {
+			return 0, err
+		}
+		tableID = resolved
+	}
+	columns, err := c.exportColumns(ctx, tableID, opts)
+	if err != nil {
+		return 0, err
+	}
+
+	ids := make([]int, len(columns))
+	for i, col := range columns {
+		ids[i] = col.id
+	}
+	body := generated.RunQueryJSONRequestBody{From: tableID, Select: &ids}
+	if opts.Where != nil {
+		where, err := whereString(c, tableID, opts.Where)
+		if err != nil {
+			return 0, err
+		}
+		if body.Where, err = StringToWhereUnion(where); err != nil {
+			return 0, err
+		}
+	}
+	fetcher, err := c.keysetFetcher(body, 3)
+	if err != nil {
+		return 0, err
+	}
+
+	e := &exporter{baseURL: c.baseURL, location: opts.Location, columns: columns, headers: exportHeaders(c, tableID, columns, opts.HeaderStyle)}
+	buf := bufio.NewWriter(w)
+	var cw *csv.Writer
+	switch opts.Format {
+	case ExportCSV, "":
+		cw = csv.NewWriter(buf)
+		if err := cw.Write(e.headers); err != nil {
+			return 0, err
+		}
+	case ExportJSONL:
+	default:
+		return 0, &core.ValidationError{
+			QuickbaseError: core.QuickbaseError{
+				Message: fmt.Sprintf("unknown export format %q", opts.Format),
+			},
+		}
+	}
+
+	flush := func() error {
+		if cw != nil {
+			cw.Flush()
+			if err := cw.Error(); err != nil {
+				return err
+			}
+		}
+		return buf.Flush()
+	}
+
+	n := 0
+	for record, err := range Paginate(ctx, fetcher) {
+		if err != nil {
+			return n, errors.Join(err, flush())
+		}
+		r := unwrapRecordAs(record, NumberJSON) // Write numbers as sent
+		if cw != nil {
+			err = cw.Write(e.csvRow(r))
+		} else {
+			err = e.writeJSONLine(buf, r)
+		}
+		if err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, flush()
+}
+
+// exportColumn is a field exported by Export.
+type exportColumn struct {
+	id        int
+	label     string
+	fieldType string
+}
+
+// exportColumns returns the fields to export, in order, with their live
+// labels and types.
+func (c *Client) exportColumns(ctx context.Context, tableID string, opts ExportOptions) ([]exportColumn, error) {
+	fields, err := c.GetFields(tableID).Run(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int]exportColumn, len(fields))
+	all := make([]exportColumn, 0, len(fields))
+	for _, f := range fields {
+		col := exportColumn{id: int(f.Id()), label: f.Label(), fieldType: f.FieldType()}
+		byID[col.id] = col
+		all = append(all, col)
+	}
+	if len(opts.Fields) == 0 {
+		return all, nil
+	}
+
+	ids, err := resolveFieldIDs(c, tableID, opts.Fields)
+	if err != nil {
+		return nil, err
+	}
+	columns := make([]exportColumn, len(ids))
+	for i, id := range ids {
+		col, ok := byID[id]
+		if !ok {
+			return nil, &core.ValidationError{
+				QuickbaseError: core.QuickbaseError{
+					Message: fmt.Sprintf("field %d does not exist in table %s", id, tableID),
+				},
+			}
+		}
+		columns[i] = col
+	}
+	return columns, nil
+}
+
+// exportHeaders returns the column names for a header style.
+func exportHeaders(c *Client, tableID string, columns []exportColumn, style HeaderStyle) []string {
+	headers := make([]string, len(columns))
+	for i, col := range columns {
+		switch style {
+		case HeaderIDs:
+			headers[i] = strconv.Itoa(col.id)
+		case HeaderAliases:
+			headers[i] = core.GetFieldAlias(c.schema, tableID, col.id)
+			if headers[i] == "" {
+				headers[i] = col.label
+			}
+		default:
+			headers[i] = col.label
+		}
+	}
+	return headers
+}
+
+// exporter renders records for Export.
+type exporter struct {
+	baseURL  string
+	location *time.Location
+	columns  []exportColumn
+	headers  []string
+}
+
+// csvRow renders a record as CSV cells.
+func (e *exporter) csvRow(r Record) []string {
+	row := make([]string, len(e.columns))
+	for i, col := range e.columns {
+		row[i] = csvCell(e.value(col.fieldType, r[strconv.Itoa(col.id)]))
+	}
+	return row
+}
+
+// writeJSONLine writes a record as a JSON object with keys in column order.
+func (e *exporter) writeJSONLine(w io.Writer, r Record) error {
+	var line bytes.Buffer
+	line.WriteByte('{')
+	for i, col := range e.columns {
+		if i > 0 {
+			line.WriteByte(',')
+		}
+		key, err := json.Marshal(e.headers[i])
+		if err != nil {
+			return err
+		}
+		value, err := json.Marshal(e.value(col.fieldType, r[strconv.Itoa(col.id)]))
+		if err != nil {
+			return err
+		}
+		line.Write(key)
+		line.WriteByte(':')
+		line.Write(value)
+	}
+	line.WriteString("}\n")
+	_, err := w.Write(line.Bytes())
+	return err
+}
+
+// value converts a field value for export according to the field type.
+// Values of other types are returned unchanged.
+func (e *exporter) value(fieldType string, v any) any {
+	switch fieldType {
+	case "timestamp":
+		if s, ok := v.(string); ok && s != "" && e.location != nil {
+			if t, err := time.Parse(time.RFC3339, s); err == nil {
+				return t.In(e.location).Format(time.RFC3339)
+			}
+		}
+	case "user":
+		if u, ok := v.(map[string]any); ok {
+			return exportUser(u)
+		}
+	case "multiuser":
+		if list, ok := v.([]any); ok {
+			users := make([]User, 0, len(list))
+			for _, item := range list {
+				if u, ok := item.(map[string]any); ok {
+					users = append(users, exportUser(u))
+				}
+			}
+			return users
+		}
+	case "file":
+		if f, ok := v.(map[string]any); ok {
+			url, _ := f["url"].(string)
+			if url == "" {
+				return nil
+			}
+			if strings.HasPrefix(url, "/") {
+				url = e.baseURL + url
+			}
+			return url
+		}
+	}
+	return v
+}
+
+// exportUser converts a user field value to a User.
+func exportUser(u map[string]any) User {
+	var user User
+	user.ID, _ = u["id"].(string)
+	user.Email, _ = u["email"].(string)
+	user.Name, _ = u["name"].(string)
+	user.UserName, _ = u["userName"].(string)
+	return user
+}
+
+// csvCell formats an exported value as CSV text.
+func csvCell(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case json.Number:
+		return val.String()
+	case bool:
+		return strconv.FormatBool(val)
+	case User:
+		return userText(val)
+	case []User:
+		parts := make([]string, len(val))
+		for i, u := range val {
+			parts[i] = userText(u)
+		}
+		return strings.Join(parts, "; ")
+	case []any:
+		parts := make([]string, len(val))
+		for i, item := range val {
+			parts[i] = csvCell(item)
+		}
+		return strings.Join(parts, "; ")
+	default:
+		data, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Sprint(val)
+		}
+		return string(data)
+	}
+}
+
+// userText identifies a user in CSV: the email, or the name or ID if the
+// email is hidden.
+func userText(u User) string {
+	switch {
+	case u.Email != "":
+		return u.Email
+	case u.Name != "":
+		return u.Name
+	}
+	return u.ID
+}
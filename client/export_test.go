@@ -0,0 +1,137 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/core"
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/qbtest"
+)
+
+// newExportTestServer starts a fake server with two projects, one per page,
+// and returns a client for it with aliases for some of the fields.
+func newExportTestServer(t *testing.T, opts ...Option) (*Client, string) {
+	t.Helper()
+	clock := &testClock{now: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)}
+	srv, tableID := newFakeTable(t, "Projects",
+		[]qbtest.Field{
+			{Label: "Name", Type: "text"},      // 6
+			{Label: "Budget", Type: "numeric"}, // 7
+			{Label: "Due", Type: "date"},       // 8
+			{Label: "Tags", Type: "multitext"}, // 9
+			{Label: "Owner", Type: "user"},     // 10
+		},
+		qbtest.WithClock(clock.Now), qbtest.WithPageSize(1))
+	srv.AddRecords(tableID,
+		map[int]any{6: "Alpha, Inc", 7: 1500000, 8: "2026-03-01", 9: []string{"red", "blue"}, 10: map[string]any{"id": "58.ab", "email": "ann@example.com", "name": "Ann Lee"}},
+		map[int]any{6: "Beta", 7: 2.5},
+	)
+
+	schema := core.NewSchema().Table("projects", tableID).Field("name", 6).Field("budget", 7).Field("owner", 10).Build()
+	c := newFakeClient(t, srv, append([]Option{WithSchema(schema)}, opts...)...)
+	return c, tableID
+}
+
+func TestExport_CSV(t *testing.T) {
+	c, _ := newExportTestServer(t)
+
+	var buf bytes.Buffer
+	n, err := c.Export(context.Background(), "projects", &buf, ExportOptions{
+		Fields: []any{3, "name", "budget", 8, 9, "owner"},
+	})
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	want := "Record ID#,Name,Budget,Due,Tags,Owner\n" +
+		"1,\"Alpha, Inc\",1500000,2026-03-01,red; blue,ann@example.com\n" +
+		"2,Beta,2.5,,,\n"
+	if n != 2 || buf.String() != want {
+		t.Errorf("Export() = %d records:\n%s\nwant 2 records:\n%s", n, buf.String(), want)
+	}
+}
+
+func TestExport_JSONL(t *testing.T) {
+	c, _ := newExportTestServer(t)
+
+	var buf bytes.Buffer
+	_, err := c.Export(context.Background(), "projects", &buf, ExportOptions{
+		Format:      ExportJSONL,
+		Fields:      []any{"name", 1, 9, "owner"},
+		Where:       core.F("budget").GT(100),
+		HeaderStyle: HeaderAliases,
+		Location:    time.FixedZone("EST", -5*60*60),
+	})
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	want := `{"name":"Alpha, Inc","Date Created":"2026-03-01T07:00:00-05:00","Tags":["red","blue"],` +
+		`"owner":{"id":"58.ab","email":"ann@example.com","name":"Ann Lee"}}` + "\n"
+	if buf.String() != want {
+		t.Errorf("Export() =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestExport_HeaderIDs(t *testing.T) {
+	c, _ := newExportTestServer(t)
+
+	var buf bytes.Buffer
+	if _, err := c.Export(context.Background(), "projects", &buf, ExportOptions{
+		Fields:      []any{6, 7},
+		Where:       "{6.EX.'Beta'}",
+		HeaderStyle: HeaderIDs,
+	}); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if want := "6,7\nBeta,2.5\n"; buf.String() != want {
+		t.Errorf("Export() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestExport_UnknownField(t *testing.T) {
+	c, _ := newExportTestServer(t)
+
+	if _, err := c.Export(context.Background(), "projects", &bytes.Buffer{}, ExportOptions{Fields: []any{99}}); err == nil {
+		t.Error("Export() with unknown field succeeded, want error")
+	}
+}
+
+func TestExport_FailsMidStream(t *testing.T) {
+	queries := 0
+	failSecondPage := func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			if strings.HasSuffix(req.URL.Path, "/records/query") {
+				if queries++; queries == 2 {
+					return nil, errors.New("connection reset")
+				}
+			}
+			return next.Do(req)
+		})
+	}
+	c, _ := newExportTestServer(t, WithMiddleware(failSecondPage))
+
+	var buf bytes.Buffer
+	n, err := c.Export(context.Background(), "projects", &buf, ExportOptions{Fields: []any{3, "name"}})
+	if err == nil {
+		t.Fatal("Export() succeeded, want error from the second page")
+	}
+	if want := "Record ID#,Name\n1,\"Alpha, Inc\"\n"; n != 1 || buf.String() != want {
+		t.Errorf("Export() = %d records, wrote %q; want 1 record, %q", n, buf.String(), want)
+	}
+}
+
+func TestExporter_FileURL(t *testing.T) {
+	e := &exporter{baseURL: "https://api.quickbase.com/v1"}
+
+	got := e.value("file", map[string]any{"url": "/files/bqxyz123/1/7/2", "versions": []any{}})
+	if got != "https://api.quickbase.com/v1/files/bqxyz123/1/7/2" {
+		t.Errorf("file value = %v", got)
+	}
+	if got := e.value("file", map[string]any{"url": ""}); got != nil {
+		t.Errorf("empty file value = %v, want nil", got)
+	}
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/auth"
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/qbtest"
+)
+
+// newFakeTable starts a fake server that accepts the user token "token" and
+// creates an app with one table of the given fields. It returns the server
+// and the table ID.
+func newFakeTable(t *testing.T, name string, fields []qbtest.Field, opts ...qbtest.Option) (*qbtest.Server, string) {
+	t.Helper()
+	srv := qbtest.NewServer(append([]qbtest.Option{qbtest.WithUserToken("token")}, opts...)...)
+	t.Cleanup(srv.Close)
+
+	appID := srv.CreateApp("Test App")
+	return srv, srv.CreateTable(appID, name, fields...)
+}
+
+// newFakeClient returns a client for srv that does not retry. opts are
+// applied after the defaults.
+func newFakeClient(t *testing.T, srv *qbtest.Server, opts ...Option) *Client {
+	t.Helper()
+	c, err := New("myrealm", auth.NewUserTokenStrategy("token"),
+		append([]Option{WithBaseURL(srv.BaseURL()), WithRetryPolicy(NeverRetry)}, opts...)...)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return c
+}
+
+// newHandlerClient serves h from a test server and returns a client for it
+// that does not retry. opts are applied after the defaults.
+func newHandlerClient(t *testing.T, h http.Handler, opts ...Option) *Client {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	c, err := New("myrealm", auth.NewUserTokenStrategy("token"),
+		append([]Option{WithBaseURL(srv.URL), WithRetryPolicy(NeverRetry)}, opts...)...)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return c
+}
+
+// concurrencyTracker records the most requests a test server had in flight
+// at once.
+type concurrencyTracker struct {
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+}
+
+// enter counts a request as in flight until the returned func is called.
+func (c *concurrencyTracker) enter() (leave func()) {
+	n := c.inFlight.Add(1)
+	for p := c.maxInFlight.Load(); n > p && !c.maxInFlight.CompareAndSwap(p, n); p = c.maxInFlight.Load() {
+	}
+	return func() { c.inFlight.Add(-1) }
+}
+
+// peak returns the highest number of requests seen in flight at once.
+func (c *concurrencyTracker) peak() int32 {
+	return c.maxInFlight.Load()
+}
@@ -0,0 +1,426 @@
+package client
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/core"
+)
+
+// importDateLayouts are the date formats ImportCSV accepts, tried in order
+// after ImportOptions.DateFormats. Month-first layouts come before day-first.
+var importDateLayouts = []string{
+	"2006-01-02",
+	"1/2/2006",
+	"1-2-2006",
+	"2006/1/2",
+	"Jan 2, 2006",
+	"January 2, 2006",
+	"2 Jan 2006",
+	"2-Jan-2006",
+}
+
+// importTimestampLayouts are the timestamp formats ImportCSV accepts in
+// addition to the date layouts.
+var importTimestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"1/2/2006 15:04:05",
+	"1/2/2006 15:04",
+	"1/2/2006 3:04:05 PM",
+	"1/2/2006 3:04 PM",
+}
+
+// ImportOptions configures ImportCSV. The embedded BulkUpsertOptions control
+// how rows are sent, including MergeFieldId to update existing records.
+type ImportOptions struct {
+	BulkUpsertOptions
+
+	// Columns maps CSV headers to fields by ID or alias, overriding the
+	// automatic match on field ID, alias and label. Map a header to 0 to skip
+	// its column.
+	Columns map[string]any
+
+	// SkipUnknownColumns ignores columns that match no field. By default they
+	// are an error.
+	SkipUnknownColumns bool
+
+	Comma         rune   // Column separator (default ',')
+	ListSeparator string // Separator for multi-select text and list-user cells (default ";")
+
+	// DateFormats are extra time layouts tried before the built-in formats
+	// for date and timestamp cells, such as "02.01.2006" for day-first dates.
+	DateFormats []string
+
+	// Location is the time zone of timestamps without an offset (default UTC).
+	Location *time.Location
+}
+
+// ImportCellError is a CSV cell that could not be converted to its field's
+// type.
+type ImportCellError struct {
+	Line    int    // Line in the CSV input (1-based, the header is line 1)
+	Index   int    // Position of the row among the data rows (0-based)
+	Column  string // CSV header of the cell
+	FieldId int
+	Value   string
+	Message string
+}
+
+func (e ImportCellError) Error() string {
+	return fmt.Sprintf("line %d, column %q: %s", e.Line, e.Column, e.Message)
+}
+
+// ImportError is returned by ImportCSV when cells cannot be converted. No
+// records are sent when it is returned.
+type ImportError struct {
+	Cells []ImportCellError
+}
+
+func (e *ImportError) Error() string {
+	if len(e.Cells) == 1 {
+		return "csv import: " + e.Cells[0].Error()
+	}
+	return fmt.Sprintf("csv import: %d cells cannot be converted; first: %v", len(e.Cells), e.Cells[0])
+}
+
+// ImportCSV reads CSV from r and upserts every row into a table with
+// BulkUpsert. The first row is the header; each column is matched to a field
+// by opts.Columns, or else by field ID, schema alias or field label (ignoring
+// case).
+//
+// Cells are converted to their field's type, as reported by getFields:
+//
+//   - Numbers may contain thousands separators, a leading "$", or a trailing
+//     "%", which divides by 100. Their digits are sent exactly, not rounded
+//     through float64
+//   - Dates and timestamps are accepted in ISO 8601 and common US formats,
+//     and in opts.DateFormats
+//   - Checkboxes accept true/false, yes/no, y/n, 1/0 and x
+//   - Multi-select text and list-user cells are split on opts.ListSeparator
+//   - User cells hold an email address or user ID
+//
+// An empty cell clears the field. The whole file is converted before anything
+// is sent; if any cell fails, ImportCSV returns an *ImportError listing every
+// failed cell. Errors QuickBase reports for individual records are in the
+// result's LineErrors, whose Index is the position among the data rows.
+//
+// Example:
+//
+//	f, _ := os.Open("projects.csv")
+//	defer f.Close()
+//	result, err := client.ImportCSV(ctx, "projects", f, quickbase.ImportOptions{
+//	    BulkUpsertOptions: quickbase.BulkUpsertOptions{MergeFieldId: 9},
+//	})
+//	var importErr *quickbase.ImportError
+//	if errors.As(err, &importErr) {
+//	    for _, cell := range importErr.Cells {
+//	        log.Printf("line %d, %s: %s", cell.Line, cell.Column, cell.Message)
+//	    }
+//	}
+func (c *Client) ImportCSV(ctx context.Context, table string, r io.Reader, opts ImportOptions) (*BulkUpsertResult, error) {
+	tableID := table
+	if c.schema != nil {
+		resolved, err := core.ResolveTableAlias(c.schema, table)
+		if err != nil {
+			return nil, err
+		}
+		tableID = resolved
+	}
+
+	cr := csv.NewReader(r)
+	if opts.Comma != 0 {
+		cr.Comma = opts.Comma
+	}
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, &core.ValidationError{
+			QuickbaseError: core.QuickbaseError{Message: "csv import: the input has no header row"},
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff") // Byte order mark written by Excel
+	}
+
+	columns, err := c.importColumns(ctx, tableID, header, opts)
+	if err != nil {
+		return nil, err
+	}
+	conv := &importConverter{
+		layouts:   opts.DateFormats,
+		separator: opts.ListSeparator,
+		location:  opts.Location,
+	}
+	if conv.separator == "" {
+		conv.separator = ";"
+	}
+	if conv.location == nil {
+		conv.location = time.UTC
+	}
+
+	var (
+		records []Record
+		cells   []ImportCellError
+	)
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+		record := make(Record, len(columns))
+		for i, col := range columns {
+			if col.fieldId == 0 {
+				continue
+			}
+			cell := ""
+			if i < len(row) {
+				cell = row[i]
+			}
+			v, err := conv.convert(col.fieldType, cell)
+			if err != nil {
+				cells = append(cells, ImportCellError{
+					Line:    line,
+					Index:   len(records),
+					Column:  header[i],
+					FieldId: col.fieldId,
+					Value:   cell,
+					Message: err.Error(),
+				})
+				continue
+			}
+			record[strconv.Itoa(col.fieldId)] = v
+		}
+		records = append(records, record)
+	}
+	if len(cells) > 0 {
+		return nil, &ImportError{Cells: cells}
+	}
+
+	return c.BulkUpsert(ctx, tableID, slices.Values(records), opts.BulkUpsertOptions)
+}
+
+// importColumn is the field a CSV column is imported into. fieldId is 0 for
+// skipped columns.
+type importColumn struct {
+	fieldId   int
+	fieldType string
+}
+
+// importColumns matches CSV headers to the table's fields.
+func (c *Client) importColumns(ctx context.Context, tableID string, header []string, opts ImportOptions) ([]importColumn, error) {
+	fields, err := c.GetFields(tableID).Run(ctx)
+	if err != nil {
+		return nil, err
+	}
+	types := make(map[int]string, len(fields))
+	byLabel := make(map[string]int, len(fields))
+	for _, f := range fields {
+		types[int(f.Id())] = f.FieldType()
+		byLabel[strings.ToLower(f.Label())] = int(f.Id())
+	}
+
+	columns := make([]importColumn, len(header))
+	seen := make(map[int]string, len(header))
+	for i, name := range header {
+		if ref, ok := opts.Columns[name]; ok && (ref == nil || ref == 0) {
+			continue
+		}
+		id, err := importFieldID(c, tableID, strings.TrimSpace(name), opts.Columns, types, byLabel)
+		if err != nil {
+			return nil, err
+		}
+		if id == 0 {
+			if opts.SkipUnknownColumns {
+				continue
+			}
+			return nil, &core.ValidationError{
+				QuickbaseError: core.QuickbaseError{
+					Message: fmt.Sprintf("csv import: column %q matches no field; map it in Columns or set SkipUnknownColumns", name),
+				},
+			}
+		}
+		if prev, ok := seen[id]; ok {
+			return nil, &core.ValidationError{
+				QuickbaseError: core.QuickbaseError{
+					Message: fmt.Sprintf("csv import: columns %q and %q both map to field %d", prev, name, id),
+				},
+			}
+		}
+		seen[id] = name
+		columns[i] = importColumn{fieldId: id, fieldType: types[id]}
+	}
+	return columns, nil
+}
+
+// importFieldID returns the field a CSV header maps to, or 0 if none.
+func importFieldID(c *Client, tableID, name string, mapping map[string]any, types map[int]string, byLabel map[string]int) (int, error) {
+	if ref, ok := mapping[name]; ok {
+		ids, err := resolveFieldIDs(c, tableID, []any{ref})
+		if err != nil {
+			return 0, err
+		}
+		if _, ok := types[ids[0]]; !ok {
+			return 0, &core.ValidationError{
+				QuickbaseError: core.QuickbaseError{
+					Message: fmt.Sprintf("csv import: column %q maps to field %d, which does not exist", name, ids[0]),
+				},
+			}
+		}
+		return ids[0], nil
+	}
+	if id, err := strconv.Atoi(name); err == nil {
+		if _, ok := types[id]; ok {
+			return id, nil
+		}
+	}
+	if c.schema != nil {
+		if id, err := core.ResolveFieldAlias(c.schema, tableID, name); err == nil {
+			return id, nil
+		}
+	}
+	return byLabel[strings.ToLower(name)], nil
+}
+
+// importConverter converts CSV cells to field values.
+type importConverter struct {
+	layouts   []string // Custom date layouts, tried first
+	separator string
+	location  *time.Location
+}
+
+// convert converts a cell to the value of a field of the given type.
+func (conv *importConverter) convert(fieldType, cell string) (any, error) {
+	s := strings.TrimSpace(cell)
+	switch fieldType {
+	case "numeric", "currency", "percent", "rating":
+		if s == "" {
+			return nil, nil
+		}
+		return parseImportNumber(s)
+	case "duration":
+		if s == "" {
+			return nil, nil
+		}
+		// Milliseconds, or a Go duration such as "1h30m"
+		if d, err := time.ParseDuration(s); err == nil {
+			return d.Milliseconds(), nil
+		}
+		return parseImportNumber(s)
+	case "checkbox":
+		switch strings.ToLower(s) {
+		case "true", "yes", "y", "1", "x", "checked":
+			return true, nil
+		case "false", "no", "n", "0", "":
+			return false, nil
+		}
+		return nil, fmt.Errorf("%q is not a checkbox value", s)
+	case "date":
+		if s == "" {
+			return nil, nil
+		}
+		t, err := conv.parseTime(s, nil)
+		if err != nil {
+			return nil, err
+		}
+		return t.Format("2006-01-02"), nil
+	case "timestamp":
+		if s == "" {
+			return nil, nil
+		}
+		t, err := conv.parseTime(s, importTimestampLayouts)
+		if err != nil {
+			return nil, err
+		}
+		return t.UTC().Format("2006-01-02T15:04:05.000Z"), nil
+	case "multitext":
+		return conv.split(s), nil
+	case "user":
+		if s == "" {
+			return nil, nil
+		}
+		return map[string]any{"id": s}, nil
+	case "multiuser":
+		users := []map[string]any{}
+		for _, id := range conv.split(s) {
+			users = append(users, map[string]any{"id": id})
+		}
+		return users, nil
+	}
+	return cell, nil
+}
+
+// parseTime parses a date or timestamp with the custom and built-in layouts.
+func (conv *importConverter) parseTime(s string, extra []string) (time.Time, error) {
+	for _, layout := range slices.Concat(conv.layouts, extra, importDateLayouts) {
+		if t, err := time.ParseInLocation(layout, s, conv.location); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q is not a recognized date", s)
+}
+
+// split splits a list cell, dropping blank items.
+func (conv *importConverter) split(s string) []string {
+	items := []string{}
+	for _, item := range strings.Split(s, conv.separator) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// thousandsGrouping matches a number with comma thousands separators, so
+// "1,250.5" is accepted but "1.250,00" is not read as 1.25.
+var thousandsGrouping = regexp.MustCompile(`^-?\d{1,3}(,\d{3})+(\.\d*)?$`)
+
+// decimalNumber matches a plain decimal number, optionally with an exponent.
+var decimalNumber = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)([eE][+-]?\d+)?$`)
+
+// parseImportNumber parses a number that may have thousands separators, a
+// leading "$" or a trailing "%". The digits are kept exactly: the result is a
+// json.Number, and percentages are divided by 100 without rounding.
+func parseImportNumber(s string) (json.Number, error) {
+	clean := strings.TrimPrefix(s, "$")
+	percent := false
+	if trimmed, ok := strings.CutSuffix(clean, "%"); ok {
+		clean, percent = strings.TrimSpace(trimmed), true
+	}
+	if strings.Contains(clean, ",") {
+		if !thousandsGrouping.MatchString(clean) {
+			return "", fmt.Errorf("%q is not a number", s)
+		}
+		clean = strings.ReplaceAll(clean, ",", "")
+	}
+	if !decimalNumber.MatchString(clean) {
+		return "", fmt.Errorf("%q is not a number", s)
+	}
+	d, err := core.ParseDecimal(clean)
+	if err != nil {
+		return "", fmt.Errorf("%q is not a number", s)
+	}
+	if percent {
+		d = core.NewDecimal(new(big.Rat).Quo(d.Rat(), big.NewRat(100, 1)), d.Scale()+2)
+	}
+	return json.Number(d.String()), nil
+}
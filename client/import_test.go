@@ -0,0 +1,176 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/core"
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/qbtest"
+)
+
+// newImportTestServer starts a fake server with an empty projects table and
+// returns a client for it with aliases for some of the fields.
+func newImportTestServer(t *testing.T) (*qbtest.Server, *Client, string) {
+	t.Helper()
+	srv, tableID := newFakeTable(t, "Projects",
+		[]qbtest.Field{
+			{Label: "Name", Type: "text", Unique: true}, // 6
+			{Label: "Budget", Type: "currency"},         // 7
+			{Label: "Due", Type: "date"},                // 8
+			{Label: "Done", Type: "checkbox"},           // 9
+			{Label: "Tags", Type: "multitext"},          // 10
+			{Label: "Owner", Type: "user"},              // 11
+			{Label: "Started", Type: "timestamp"},       // 12
+		})
+
+	schema := core.NewSchema().Table("projects", tableID).Field("name", 6).Field("budget", 7).Build()
+	c := newFakeClient(t, srv, WithSchema(schema))
+	return srv, c, tableID
+}
+
+func TestImportCSV(t *testing.T) {
+	srv, c, tableID := newImportTestServer(t)
+
+	input := "\ufeffname,BUDGET,Due,Done,Tags,Owner,12\n" +
+		"Alpha,\"$1,500.50\",3/14/2026,yes,red; blue,ann@example.com,2026-03-14 09:30\n" +
+		"Beta,25%,2026-03-15,,,,\n"
+	result, err := c.ImportCSV(context.Background(), "projects", strings.NewReader(input), ImportOptions{
+		Location: time.FixedZone("EST", -5*60*60),
+	})
+	if err != nil {
+		t.Fatalf("ImportCSV() error = %v", err)
+	}
+	if len(result.CreatedRecordIds) != 2 {
+		t.Fatalf("CreatedRecordIds = %v, want 2 records", result.CreatedRecordIds)
+	}
+
+	records := srv.Records(tableID)
+	alpha := records[0]
+	want := map[int]any{
+		6:  "Alpha",
+		7:  1500.5,
+		8:  "2026-03-14",
+		9:  true,
+		10: []string{"red", "blue"},
+		12: "2026-03-14T14:30:00.000Z",
+	}
+	for fid, v := range want {
+		if !reflect.DeepEqual(alpha[fid], v) {
+			t.Errorf("field %d = %#v, want %#v", fid, alpha[fid], v)
+		}
+	}
+	if owner, _ := alpha[11].(map[string]any); owner["email"] != "ann@example.com" {
+		t.Errorf("owner = %v", alpha[11])
+	}
+	if records[1][7] != 0.25 || records[1][9] != false || records[1][11] != nil {
+		t.Errorf("second record = %v", records[1])
+	}
+}
+
+func TestImportCSV_ConversionErrors(t *testing.T) {
+	srv, c, tableID := newImportTestServer(t)
+
+	input := "Name,Budget,Due,Done\n" +
+		"Alpha,100,2026-03-14,no\n" +
+		"Beta,lots,2026-03-14,maybe\n" +
+		"Gamma,5,someday,yes\n"
+	_, err := c.ImportCSV(context.Background(), "projects", strings.NewReader(input), ImportOptions{})
+
+	var importErr *ImportError
+	if !errors.As(err, &importErr) {
+		t.Fatalf("ImportCSV() error = %v, want *ImportError", err)
+	}
+	var got []string
+	for _, cell := range importErr.Cells {
+		got = append(got, cell.Error())
+	}
+	want := []string{
+		`line 3, column "Budget": "lots" is not a number`,
+		`line 3, column "Done": "maybe" is not a checkbox value`,
+		`line 4, column "Due": "someday" is not a recognized date`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("cells = %q, want %q", got, want)
+	}
+	if importErr.Cells[2].Index != 2 || importErr.Cells[2].FieldId != 8 {
+		t.Errorf("third cell = %+v", importErr.Cells[2])
+	}
+	if n := len(srv.Records(tableID)); n != 0 {
+		t.Errorf("%d records were created, want none", n)
+	}
+}
+
+func TestParseImportNumber(t *testing.T) {
+	tests := []struct {
+		in   string
+		want json.Number
+	}{
+		{"1500.50", "1500.50"},
+		{"$1,500.50", "1500.50"},
+		{"57%", "0.57"},
+		{"12.5 %", "0.125"},
+		{"-3", "-3"},
+		{"12345678901234567.89", "12345678901234567.89"},
+		{"1.5e3", "1500"},
+	}
+	for _, tt := range tests {
+		got, err := parseImportNumber(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("parseImportNumber(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+	}
+
+	for _, in := range []string{"lots", "1.250,00", "Inf", "NaN", "0x10", "1/2", "%"} {
+		if got, err := parseImportNumber(in); err == nil {
+			t.Errorf("parseImportNumber(%q) = %q, want error", in, got)
+		}
+	}
+}
+
+func TestImportCSV_Merge(t *testing.T) {
+	srv, c, tableID := newImportTestServer(t)
+	srv.AddRecords(tableID, map[int]any{6: "Alpha", 7: 100.0})
+
+	input := "Project;Cost;Notes\n" +
+		"Alpha;1.250,00;ignored\n" +
+		"Beta;7;ignored\n"
+	opts := ImportOptions{
+		BulkUpsertOptions: BulkUpsertOptions{MergeFieldId: 6},
+		Columns:           map[string]any{"Project": "name", "Cost": 7, "Notes": 0},
+		Comma:             ';',
+	}
+	if _, err := c.ImportCSV(context.Background(), "projects", strings.NewReader(input), opts); err == nil {
+		t.Fatal("ImportCSV() with European number succeeded, want error")
+	}
+
+	input = strings.Replace(input, "1.250,00", "1250", 1)
+	result, err := c.ImportCSV(context.Background(), "projects", strings.NewReader(input), opts)
+	if err != nil {
+		t.Fatalf("ImportCSV() error = %v", err)
+	}
+	if len(result.UpdatedRecordIds) != 1 || len(result.CreatedRecordIds) != 1 {
+		t.Errorf("result = %+v, want one update and one create", result)
+	}
+	if records := srv.Records(tableID); len(records) != 2 || records[0][7] != 1250.0 {
+		t.Errorf("records = %v", records)
+	}
+}
+
+func TestImportCSV_UnknownColumn(t *testing.T) {
+	_, c, _ := newImportTestServer(t)
+
+	input := "Name,Color\nAlpha,red\n"
+	_, err := c.ImportCSV(context.Background(), "projects", strings.NewReader(input), ImportOptions{})
+	if err == nil || !strings.Contains(err.Error(), `"Color"`) {
+		t.Errorf("ImportCSV() error = %v, want unknown column error", err)
+	}
+
+	if _, err := c.ImportCSV(context.Background(), "projects", strings.NewReader(input), ImportOptions{SkipUnknownColumns: true}); err != nil {
+		t.Errorf("ImportCSV() with SkipUnknownColumns error = %v", err)
+	}
+}
@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/core"
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/generated"
+)
+
+// RunQueryAllKeyset fetches all records like RunQueryAll, but pages by a
+// unique, sortable key field instead of by skip offset. Each page asks for
+// records whose key is greater than the last one seen ({3.GT.'lastSeen'}),
+// sorted by the key.
+//
+// Skip offsets drift when records are inserted or deleted during a scan, which
+// misses or duplicates records, and get slower at large offsets. Keyset paging
+// does neither: every record that exists for the whole scan is returned exactly
+// once. Use the record ID (field 3) unless another field is unique.
+//
+// The query must not sort or group by other fields. The key field is added to
+// Select if missing, and Options.Skip is ignored.
+//
+// Example:
+//
+//	records, err := client.RunQueryAllKeyset(ctx, quickbase.RunQueryBody{
+//	    From:  tableId,
+//	    Where: quickbase.Ptr(where),
+//	}, 3)
+func (c *Client) RunQueryAllKeyset(ctx context.Context, body generated.RunQueryJSONRequestBody, keyField int) ([]generated.QuickbaseRecord, error) {
+	fetcher, err := c.keysetFetcher(body, keyField)
+	if err != nil {
+		return nil, err
+	}
+	return CollectAll(ctx, fetcher)
+}
+
+// keysetFetcher creates a page fetcher that pages by keyField. The next page
+// token is the formatted key value of the last record on the page.
+func (c *Client) keysetFetcher(body generated.RunQueryJSONRequestBody, keyField int) (PageFetcher[generated.QuickbaseRecord, *runQueryPageResponse], error) {
+	body, _, err := c.transformRunQueryBody(body)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkKeysetBody(body, keyField); err != nil {
+		return nil, err
+	}
+
+	if body.Select != nil && !slices.Contains(*body.Select, keyField) {
+		fields := append(slices.Clone(*body.Select), keyField)
+		body.Select = &fields
+	}
+	sortBy, err := SortFieldsToSortByUnion([]generated.SortField{{FieldId: keyField, Order: generated.SortFieldOrderASC}})
+	if err != nil {
+		return nil, err
+	}
+	body.SortBy = sortBy
+	if body.Options != nil {
+		opts := *body.Options
+		opts.Skip = nil
+		body.Options = &opts
+	}
+	baseWhere, _ := extractWhereString(body.Where)
+	key := strconv.Itoa(keyField)
+
+	return func(ctx context.Context, skip int, lastSeen string) (*runQueryPageResponse, error) {
+		page := body
+		if lastSeen != "" {
+			where := core.F(keyField).GT(lastSeen).String()
+			if baseWhere != "" {
+				where = andWhere(baseWhere, where)
+			}
+			whereUnion, err := StringToWhereUnion(where)
+			if err != nil {
+				return nil, err
+			}
+			page.Where = whereUnion
+		}
+
+		resp, err := c.API().RunQueryWithResponse(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+		if resp.JSON200 == nil {
+			return nil, parseAPIError(resp.StatusCode(), resp.Body, resp.HTTPResponse)
+		}
+
+		var data []generated.QuickbaseRecord
+		if resp.JSON200.Data != nil {
+			data = *resp.JSON200.Data
+		}
+
+		// Records remain past this page if the filtered total exceeds it
+		var metadata PaginationMetadata
+		if m := resp.JSON200.Metadata; m != nil && len(data) > 0 && m.NumRecords < m.TotalRecords {
+			next, err := core.FormatWhereValue(unwrapRecordAs(data[len(data)-1], NumberJSON)[key])
+			if err != nil {
+				return nil, err
+			}
+			if next == "" {
+				return nil, &core.ValidationError{
+					QuickbaseError: core.QuickbaseError{
+						Message: fmt.Sprintf("keyset field %d is empty in a returned record; keyset pagination needs a unique, non-empty field", keyField),
+					},
+				}
+			}
+			metadata.NextToken = &next
+		}
+
+		return &runQueryPageResponse{data: data, metadata: metadata}, nil
+	}, nil
+}
+
+// checkKeysetBody rejects queries whose ordering conflicts with paging by keyField.
+func checkKeysetBody(body generated.RunQueryJSONRequestBody, keyField int) error {
+	invalid := func(msg string) error {
+		return &core.ValidationError{
+			QuickbaseError: core.QuickbaseError{
+				Message: fmt.Sprintf("keyset pagination on field %d: %s", keyField, msg),
+			},
+		}
+	}
+	if body.GroupBy != nil && len(*body.GroupBy) > 0 {
+		return invalid("groupBy is not supported")
+	}
+	if body.SortBy != nil {
+		if sorts, err := body.SortBy.AsSortByUnion0(); err == nil {
+			for _, s := range sorts {
+				if s.FieldId != keyField || s.Order != generated.SortFieldOrderASC {
+					return invalid("results are sorted by the key field; remove other sortBy fields")
+				}
+			}
+		}
+	}
+	return nil
+}
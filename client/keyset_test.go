@@ -0,0 +1,189 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/core"
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/generated"
+)
+
+var keysetClause = regexp.MustCompile(`\{3\.GT\.'(\d+)'\}$`)
+
+// keysetServer serves the sorted record IDs in ids, at most pageSize per
+// response, filtered by a trailing {3.GT.'n'} clause.
+type keysetServer struct {
+	mu       sync.Mutex
+	ids      []int
+	pageSize int
+	bodies   []map[string]any
+
+	// onRequest may change ids after the nth request is served
+	onRequest func(s *keysetServer, n int)
+}
+
+func (s *keysetServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body map[string]any
+	json.NewDecoder(r.Body).Decode(&body)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bodies = append(s.bodies, body)
+
+	after := 0
+	if where, _ := body["where"].(string); where != "" {
+		if m := keysetClause.FindStringSubmatch(where); m != nil {
+			after, _ = strconv.Atoi(m[1])
+		}
+	}
+	var matched []int
+	for _, id := range s.ids {
+		if id > after {
+			matched = append(matched, id)
+		}
+	}
+	data := []map[string]any{}
+	for _, id := range matched[:min(len(matched), s.pageSize)] {
+		data = append(data, map[string]any{"3": map[string]any{"value": id}})
+	}
+	if s.onRequest != nil {
+		s.onRequest(s, len(s.bodies))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"data":     data,
+		"fields":   []any{},
+		"metadata": map[string]any{"totalRecords": len(matched), "numRecords": len(data), "numFields": 1},
+	})
+}
+
+func idRange(from, to int) []int {
+	var ids []int
+	for id := from; id <= to; id++ {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestRunQueryAllKeyset(t *testing.T) {
+	s := &keysetServer{ids: idRange(1, 25), pageSize: 10}
+	c := newHandlerClient(t, s)
+
+	records, err := c.RunQueryAllKeyset(context.Background(), generated.RunQueryJSONRequestBody{From: "bqxyz123"}, 3)
+	if err != nil {
+		t.Fatalf("RunQueryAllKeyset() error = %v", err)
+	}
+	if ids := recordIDs(records); !slices.Equal(ids, idRange(1, 25)) {
+		t.Errorf("got ids %v", ids)
+	}
+	if len(s.bodies) != 3 {
+		t.Fatalf("made %d requests, want 3", len(s.bodies))
+	}
+	if where := s.bodies[2]["where"]; where != "{3.GT.'20'}" {
+		t.Errorf("third request where = %v", where)
+	}
+	sortBy, _ := json.Marshal(s.bodies[0]["sortBy"])
+	if string(sortBy) != `[{"fieldId":3,"order":"ASC"}]` {
+		t.Errorf("sortBy = %s", sortBy)
+	}
+}
+
+func TestRunQueryAllKeyset_ConcurrentWrites(t *testing.T) {
+	// Deleting records before the cursor shifts skip offsets; keyset paging
+	// is unaffected. New records past the cursor are picked up.
+	s := &keysetServer{ids: idRange(1, 30), pageSize: 10}
+	s.onRequest = func(s *keysetServer, n int) {
+		if n == 1 {
+			s.ids = slices.DeleteFunc(s.ids, func(id int) bool { return id <= 5 || id == 25 })
+			s.ids = append(s.ids, 31)
+		}
+	}
+	c := newHandlerClient(t, s)
+
+	records, err := c.RunQueryAllKeyset(context.Background(), generated.RunQueryJSONRequestBody{From: "bqxyz123"}, 3)
+	if err != nil {
+		t.Fatalf("RunQueryAllKeyset() error = %v", err)
+	}
+	want := append(idRange(1, 24), 26, 27, 28, 29, 30, 31)
+	if ids := recordIDs(records); !slices.Equal(ids, want) {
+		t.Errorf("got ids %v, want %v", ids, want)
+	}
+}
+
+func TestRunQueryAllKeyset_CombinesWhere(t *testing.T) {
+	s := &keysetServer{ids: idRange(1, 15), pageSize: 10}
+	c := newHandlerClient(t, s)
+
+	where := "{6.EX.'Active'}OR{7.GT.5}"
+	body := generated.RunQueryJSONRequestBody{From: "bqxyz123", Select: &[]int{6}}
+	body.Where, _ = StringToWhereUnion(where)
+	if _, err := c.RunQueryAllKeyset(context.Background(), body, 3); err != nil {
+		t.Fatalf("RunQueryAllKeyset() error = %v", err)
+	}
+
+	if got := s.bodies[0]["where"]; got != where {
+		t.Errorf("first request where = %v, want %q", got, where)
+	}
+	second, _ := s.bodies[1]["where"].(string)
+	if second != "({6.EX.'Active'}OR{7.GT.5})AND{3.GT.'10'}" {
+		t.Errorf("second request where = %q", second)
+	}
+	if _, err := core.ParseWhere(second); err != nil {
+		t.Errorf("ParseWhere(%q) error = %v", second, err)
+	}
+	selected, _ := json.Marshal(s.bodies[0]["select"])
+	if string(selected) != "[6,3]" {
+		t.Errorf("select = %s, want [6,3]", selected)
+	}
+	if len(*body.Select) != 1 {
+		t.Errorf("caller's select modified: %v", *body.Select)
+	}
+}
+
+func TestRunQueryAllKeyset_RejectsOtherSort(t *testing.T) {
+	c := newHandlerClient(t, &keysetServer{})
+
+	body := generated.RunQueryJSONRequestBody{From: "bqxyz123"}
+	body.SortBy, _ = SortFieldsToSortByUnion([]generated.SortField{{FieldId: 6, Order: generated.SortFieldOrderDESC}})
+	_, err := c.RunQueryAllKeyset(context.Background(), body, 3)
+	var validationErr *core.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("RunQueryAllKeyset() error = %v, want ValidationError", err)
+	}
+}
+
+func TestQueryBuilder_Keyset(t *testing.T) {
+	s := &keysetServer{ids: idRange(1, 12), pageSize: 5}
+	c := newHandlerClient(t, s)
+
+	records, err := c.Query("bqxyz123").Keyset(3).Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(records) != 12 || records[11]["3"] != float64(12) {
+		t.Errorf("Run() returned %d records", len(records))
+	}
+
+	var n int
+	for _, err := range c.Query("bqxyz123").Keyset(3).Iter(context.Background()) {
+		if err != nil {
+			t.Fatalf("Iter() error = %v", err)
+		}
+		n++
+	}
+	if n != 12 {
+		t.Errorf("Iter() yielded %d records, want 12", n)
+	}
+
+	if _, err := c.Query("bqxyz123").Keyset(3).RunParallel(context.Background(), 2); err == nil {
+		t.Error("RunParallel() with Keyset succeeded, want error")
+	}
+}
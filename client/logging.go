@@ -0,0 +1,70 @@
+package client
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/core"
+)
+
+// WithLogger sends the client's logs to a *slog.Logger as structured records.
+// Retries, rate limits, token refreshes, completed requests and XML calls are
+// logged with attributes such as method, path, dbid, attempt, status, ray IDs
+// and duration. It takes precedence over WithDebug.
+//
+// Example:
+//
+//	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+//	quickbase.WithLogger(logger)
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		c.slogger = logger
+	}
+}
+
+// WithSlogHandler sends the client's logs to a slog.Handler. It is shorthand
+// for WithLogger(slog.New(handler)).
+func WithSlogHandler(handler slog.Handler) Option {
+	return WithLogger(slog.New(handler))
+}
+
+// WithLogLevels sets the level of each kind of structured record
+// (default core.DefaultLogLevels). It applies with WithLogger or WithSlogHandler.
+//
+// Example:
+//
+//	levels := core.DefaultLogLevels()
+//	levels.Retry = slog.LevelWarn
+//	quickbase.WithLogLevels(levels)
+func WithLogLevels(levels core.LogLevels) Option {
+	return func(c *Client) {
+		c.logLevels = &levels
+	}
+}
+
+// requestAttrs returns the attributes common to every request record.
+func requestAttrs(method, path, dbid string, attempt int) []slog.Attr {
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("path", path),
+	}
+	if dbid != "" {
+		attrs = append(attrs, slog.String("dbid", dbid))
+	}
+	return append(attrs, slog.Int("attempt", attempt))
+}
+
+// rayAttrs returns the QuickBase and Cloudflare request IDs from a response.
+func rayAttrs(h http.Header) []slog.Attr {
+	var attrs []slog.Attr
+	for _, ray := range []struct{ header, key string }{
+		{"qb-api-ray", "qb_api_ray"},
+		{"cf-ray", "cf_ray"},
+		{"tid", "tid"},
+	} {
+		if v := h.Get(ray.header); v != "" {
+			attrs = append(attrs, slog.String(ray.key, v))
+		}
+	}
+	return attrs
+}
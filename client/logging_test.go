@@ -0,0 +1,124 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/auth"
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/core"
+)
+
+// logRecords decodes JSON log lines.
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var rec map[string]any
+		if err := dec.Decode(&rec); err != nil {
+			t.Fatalf("decoding log record: %v", err)
+		}
+		records = append(records, rec)
+	}
+	return records
+}
+
+func TestWithLogger_StructuredRecords(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("qb-api-ray", "ray-123")
+		switch calls.Add(1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id":"bqapp123","name":"App"}`))
+		}
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	handler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+	c, err := New("myrealm", auth.NewUserTokenStrategy("token"),
+		WithBaseURL(srv.URL),
+		WithSlogHandler(handler),
+		WithRetryPolicy(ExponentialBackoff{MaxAttempts: 3, InitialDelay: time.Millisecond}))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if _, err := c.GetApp("bqapp123").Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	records := logRecords(t, &buf)
+	want := []struct {
+		msg    string
+		level  string
+		status float64
+	}{
+		{"rate limited", "WARN", 429},
+		{"retrying request", "INFO", 429},
+		{"retrying request", "INFO", 503},
+		{"request completed", "DEBUG", 200},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("got %d records, want %d: %v", len(records), len(want), records)
+	}
+	for i, w := range want {
+		rec := records[i]
+		if rec["msg"] != w.msg || rec["level"] != w.level || rec["status"] != w.status {
+			t.Errorf("record %d = %v, want msg %q level %s status %v", i, rec, w.msg, w.level, w.status)
+		}
+		if rec["method"] != "GET" || rec["path"] != "/apps/bqapp123" || rec["dbid"] != "bqapp123" {
+			t.Errorf("record %d missing request attributes: %v", i, rec)
+		}
+	}
+	if records[0]["qb_api_ray"] != "ray-123" || records[3]["qb_api_ray"] != "ray-123" {
+		t.Errorf("expected ray IDs on rate limit and completion records")
+	}
+	if records[3]["attempt"] != 3.0 {
+		t.Errorf("attempt = %v, want 3", records[3]["attempt"])
+	}
+	if _, ok := records[3]["duration"]; !ok {
+		t.Errorf("completion record missing duration: %v", records[3])
+	}
+}
+
+func TestWithLogLevels(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"bqapp123","name":"App"}`))
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	levels := core.DefaultLogLevels()
+	levels.Request = slog.LevelInfo
+
+	c, err := New("myrealm", auth.NewUserTokenStrategy("token"),
+		WithBaseURL(srv.URL),
+		WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))), // Info and above
+		WithLogLevels(levels))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if _, err := c.GetApp("bqapp123").Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	records := logRecords(t, &buf)
+	if len(records) != 1 || records[0]["msg"] != "request completed" || records[0]["level"] != "INFO" {
+		t.Errorf("records = %v, want one INFO request record", records)
+	}
+}
@@ -0,0 +1,479 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/core"
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/generated"
+)
+
+// --- Struct Tag Mapping ---
+// These helpers convert between QuickBase records and Go structs using `qb` struct tags.
+//
+// A tag names the field by ID or by schema alias, optionally followed by options:
+//
+//	type Project struct {
+//	    ID       int        `qb:"3"`
+//	    Name     string     `qb:"name"`              // alias, resolved through the schema
+//	    Budget   *float64   `qb:"7,omitempty"`       // nil pointers are not sent
+//	    Due      time.Time  `qb:"dueDate,date"`      // sent as YYYY-MM-DD
+//	    Tags     []string   `qb:"tags"`              // multi-select text
+//	    Owner    *User      `qb:"owner,omitempty"`   // user field
+//	    Internal string     `qb:"-"`                 // ignored
+//	}
+//
+// Options:
+//   - omitempty: skip zero values (and nil pointers) when encoding for upsert
+//   - date: encode time.Time as a date (YYYY-MM-DD) instead of a timestamp
+//
+// Pointer fields decode to nil when the field is empty. When encoding, a nil
+// pointer is sent as null, which clears the field in QuickBase; add omitempty
+// to leave the field untouched instead. Fields without a qb tag are ignored.
+//
+// Values are type-checked in both directions: decoding a text value into an int
+// field, or a fractional number into an int, returns a *core.MappingError.
+// time.Time accepts QuickBase dates and timestamps, and time.Duration maps to
+// duration fields (milliseconds on the wire).
+
+// User is a QuickBase user field value.
+// When encoding, set ID or Email to identify the user.
+type User struct {
+	ID       string `json:"id,omitempty"`
+	Email    string `json:"email,omitempty"`
+	Name     string `json:"name,omitempty"`
+	UserName string `json:"userName,omitempty"`
+}
+
+// taggedField describes a struct field with a qb tag.
+type taggedField struct {
+	index     []int  // reflect field index path
+	name      string // Go field name
+	ref       string // field ID or alias from the tag
+	omitEmpty bool
+	dateOnly  bool
+}
+
+// mappedField is a tagged field resolved to a QuickBase field ID.
+type mappedField struct {
+	taggedField
+	fieldID int
+}
+
+// recordMapper converts between records of one table and one struct type.
+type recordMapper struct {
+	typ    reflect.Type
+	fields []mappedField
+}
+
+// taggedFieldsCache caches parsed tags per struct type.
+var taggedFieldsCache sync.Map // map[reflect.Type][]taggedField
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// parseTaggedFields returns the qb-tagged fields of a struct type, including
+// fields of embedded structs.
+func parseTaggedFields(t reflect.Type) []taggedField {
+	if cached, ok := taggedFieldsCache.Load(t); ok {
+		return cached.([]taggedField)
+	}
+
+	var fields []taggedField
+	var walk func(t reflect.Type, index []int)
+	walk = func(t reflect.Type, index []int) {
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			tag, hasTag := sf.Tag.Lookup("qb")
+			path := append(append([]int(nil), index...), i)
+
+			if !hasTag {
+				if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+					walk(sf.Type, path)
+				}
+				continue
+			}
+			if tag == "-" || !sf.IsExported() {
+				continue
+			}
+
+			parts := strings.Split(tag, ",")
+			f := taggedField{index: path, name: sf.Name, ref: strings.TrimSpace(parts[0])}
+			for _, opt := range parts[1:] {
+				switch strings.TrimSpace(opt) {
+				case "omitempty":
+					f.omitEmpty = true
+				case "date":
+					f.dateOnly = true
+				}
+			}
+			if f.ref != "" {
+				fields = append(fields, f)
+			}
+		}
+	}
+	walk(t, nil)
+
+	taggedFieldsCache.Store(t, fields)
+	return fields
+}
+
+// newRecordMapper resolves the qb tags of T against a table.
+// Aliases require a schema; numeric tags work without one.
+func newRecordMapper[T any](c *Client, tableID string) (*recordMapper, error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		return nil, &core.ValidationError{
+			QuickbaseError: core.QuickbaseError{
+				Message: fmt.Sprintf("record mapping requires a struct type, got %s", t),
+			},
+		}
+	}
+
+	tagged := parseTaggedFields(t)
+	m := &recordMapper{typ: t, fields: make([]mappedField, 0, len(tagged))}
+	for _, f := range tagged {
+		fieldID, err := strconv.Atoi(f.ref)
+		if err != nil {
+			if c.schema == nil {
+				return nil, &core.SchemaError{
+					Message: "schema required to resolve field alias: " + f.ref,
+				}
+			}
+			fieldID, err = core.ResolveFieldAlias(c.schema, tableID, f.ref)
+			if err != nil {
+				return nil, err
+			}
+		}
+		m.fields = append(m.fields, mappedField{taggedField: f, fieldID: fieldID})
+	}
+	return m, nil
+}
+
+// fieldIDs returns the mapped field IDs in struct order, without duplicates.
+func (m *recordMapper) fieldIDs() []any {
+	seen := make(map[int]bool, len(m.fields))
+	ids := make([]any, 0, len(m.fields))
+	for _, f := range m.fields {
+		if !seen[f.fieldID] {
+			seen[f.fieldID] = true
+			ids = append(ids, f.fieldID)
+		}
+	}
+	return ids
+}
+
+func (m *recordMapper) mappingError(f mappedField, index int, format string, args ...any) error {
+	return &core.MappingError{
+		Type:    m.typ.String(),
+		Field:   f.name,
+		FieldID: f.fieldID,
+		Index:   index,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// --- Decoding ---
+
+// decode fills dst (a settable struct value) from a record.
+func (m *recordMapper) decode(record generated.QuickbaseRecord, index int, dst reflect.Value) error {
+	for _, f := range m.fields {
+		fv, ok := record[strconv.Itoa(f.fieldID)]
+		if !ok {
+			fv, ok = record[f.ref]
+		}
+		if !ok {
+			continue
+		}
+		raw, err := fv.Value.MarshalJSON()
+		if err != nil {
+			return m.mappingError(f, index, "%v", err)
+		}
+		if err := decodeFieldValue(raw, dst.FieldByIndex(f.index)); err != nil {
+			return m.mappingError(f, index, "%v", err)
+		}
+	}
+	return nil
+}
+
+// decodeFieldValue converts a raw JSON field value into v.
+func decodeFieldValue(raw []byte, v reflect.Value) error {
+	if len(raw) == 0 || string(raw) == "null" {
+		v.SetZero()
+		return nil
+	}
+
+	if v.Kind() == reflect.Pointer {
+		p := reflect.New(v.Type().Elem())
+		if err := decodeFieldValue(raw, p.Elem()); err != nil {
+			return err
+		}
+		v.Set(p)
+		return nil
+	}
+
+	switch v.Type() {
+	case timeType:
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return fmt.Errorf("cannot decode %s into time.Time", jsonKind(raw))
+		}
+		if s == "" {
+			v.SetZero()
+			return nil
+		}
+		t, err := core.ParseISODate(s)
+		if err != nil {
+			return fmt.Errorf("cannot parse %q as a date", s)
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	case durationType:
+		var ms float64
+		if err := json.Unmarshal(raw, &ms); err != nil {
+			return fmt.Errorf("cannot decode %s into time.Duration", jsonKind(raw))
+		}
+		v.SetInt(int64(ms * float64(time.Millisecond)))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// Integer literals are parsed exactly; float64 would round past 2^53
+		if n, err := strconv.ParseInt(string(raw), 10, 64); err == nil {
+			if v.OverflowInt(n) {
+				return fmt.Errorf("number %s overflows %s", raw, v.Type())
+			}
+			v.SetInt(n)
+			return nil
+		}
+		n, err := decodeNumber(raw, v.Type())
+		if err != nil {
+			return err
+		}
+		if n != math.Trunc(n) {
+			return fmt.Errorf("cannot decode fractional number %s into %s", raw, v.Type())
+		}
+		if v.OverflowInt(int64(n)) {
+			return fmt.Errorf("number %s overflows %s", raw, v.Type())
+		}
+		v.SetInt(int64(n))
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n, err := strconv.ParseUint(string(raw), 10, 64); err == nil && !v.OverflowUint(n) {
+			v.SetUint(n)
+			return nil
+		}
+		n, err := decodeNumber(raw, v.Type())
+		if err != nil {
+			return err
+		}
+		if n < 0 || n != math.Trunc(n) || v.OverflowUint(uint64(n)) {
+			return fmt.Errorf("cannot decode number %s into %s", raw, v.Type())
+		}
+		v.SetUint(uint64(n))
+		return nil
+	}
+
+	if err := json.Unmarshal(raw, v.Addr().Interface()); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return fmt.Errorf("cannot decode %s into %s", typeErr.Value, v.Type())
+		}
+		return err
+	}
+	return nil
+}
+
+// decodeNumber parses a JSON number for an integer target.
+func decodeNumber(raw []byte, t reflect.Type) (float64, error) {
+	var n float64
+	if err := json.Unmarshal(raw, &n); err != nil {
+		return 0, fmt.Errorf("cannot decode %s into %s", jsonKind(raw), t)
+	}
+	return n, nil
+}
+
+// jsonKind describes the JSON type of a raw value for error messages.
+func jsonKind(raw []byte) string {
+	switch raw[0] {
+	case '"':
+		return "string"
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case 't', 'f':
+		return "bool"
+	default:
+		return "number"
+	}
+}
+
+// --- Encoding ---
+
+// encode converts a struct value into a record keyed by field ID.
+func (m *recordMapper) encode(src reflect.Value, index int) (generated.QuickbaseRecord, error) {
+	record := make(generated.QuickbaseRecord, len(m.fields))
+	for _, f := range m.fields {
+		v := src.FieldByIndex(f.index)
+		if f.omitEmpty && isEmptyValue(v) {
+			continue
+		}
+		value, err := encodeFieldValue(v, f.dateOnly)
+		if err != nil {
+			return nil, m.mappingError(f, index, "%v", err)
+		}
+		data, err := json.Marshal(map[string]any{"value": value})
+		if err != nil {
+			return nil, m.mappingError(f, index, "%v", err)
+		}
+		var fv generated.FieldValue
+		if err := json.Unmarshal(data, &fv); err != nil {
+			return nil, m.mappingError(f, index, "%v", err)
+		}
+		record[strconv.Itoa(f.fieldID)] = fv
+	}
+	return record, nil
+}
+
+// encodeFieldValue converts a struct field to its wire representation.
+func encodeFieldValue(v reflect.Value, dateOnly bool) (any, error) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Type() {
+	case timeType:
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return "", nil
+		}
+		if dateOnly {
+			return t.Format("2006-01-02"), nil
+		}
+		return t.UTC().Format("2006-01-02T15:04:05.000Z"), nil
+	case durationType:
+		return time.Duration(v.Int()).Milliseconds(), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Func, reflect.Chan, reflect.Complex64, reflect.Complex128, reflect.UnsafePointer:
+		return nil, fmt.Errorf("unsupported type %s", v.Type())
+	case reflect.Float32, reflect.Float64:
+		if f := v.Float(); math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, fmt.Errorf("unsupported number %v", f)
+		}
+	}
+	return v.Interface(), nil
+}
+
+// isEmptyValue reports whether v is empty for omitempty, matching encoding/json.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return v.IsNil()
+	}
+	return v.IsZero()
+}
+
+// --- Public helpers ---
+
+// DecodeRecords converts raw QuickBase records into structs using `qb` tags.
+// The table can be an alias or ID; it is used to resolve field aliases in tags.
+//
+// Example:
+//
+//	resp, _ := client.RunQuery(ctx, body)
+//	projects, err := quickbase.DecodeRecords[Project](client, "projects", *resp.Raw().JSON200.Data)
+func DecodeRecords[T any](c *Client, table string, records []generated.QuickbaseRecord) ([]T, error) {
+	tableID, err := c.Table(table)
+	if err != nil {
+		return nil, err
+	}
+	m, err := newRecordMapper[T](c, tableID)
+	if err != nil {
+		return nil, err
+	}
+	return decodeRecords[T](m, records)
+}
+
+func decodeRecords[T any](m *recordMapper, records []generated.QuickbaseRecord) ([]T, error) {
+	result := make([]T, len(records))
+	for i, record := range records {
+		if err := m.decode(record, i, reflect.ValueOf(&result[i]).Elem()); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// EncodeRecords converts structs into QuickBase records using `qb` tags.
+// The table can be an alias or ID; it is used to resolve field aliases in tags.
+func EncodeRecords[T any](c *Client, table string, items []T) ([]generated.QuickbaseRecord, error) {
+	tableID, err := c.Table(table)
+	if err != nil {
+		return nil, err
+	}
+	m, err := newRecordMapper[T](c, tableID)
+	if err != nil {
+		return nil, err
+	}
+	return encodeRecords(m, items)
+}
+
+func encodeRecords[T any](m *recordMapper, items []T) ([]generated.QuickbaseRecord, error) {
+	records := make([]generated.QuickbaseRecord, len(items))
+	for i := range items {
+		record, err := m.encode(reflect.ValueOf(&items[i]).Elem(), i)
+		if err != nil {
+			return nil, err
+		}
+		records[i] = record
+	}
+	return records, nil
+}
+
+// UpsertFrom sets the upsert data from structs using `qb` tags.
+// Encoding errors are returned when the builder runs.
+//
+// Example:
+//
+//	projects := []Project{{Name: "Apollo", Budget: quickbase.Ptr(5000.0)}}
+//	result, err := quickbase.UpsertFrom(client.Upsert("projects"), projects...).
+//	    MergeFieldId(6).
+//	    Run(ctx)
+func UpsertFrom[T any](b *UpsertBuilder, items ...T) *UpsertBuilder {
+	if b.err != nil {
+		return b
+	}
+	m, err := newRecordMapper[T](b.client, b.tableID)
+	if err != nil {
+		b.err = err
+		return b
+	}
+	records, err := encodeRecords(m, items)
+	if err != nil {
+		b.err = err
+		return b
+	}
+	data := make([]any, len(records))
+	for i, record := range records {
+		data[i] = record
+	}
+	return b.Data(data...)
+}
@@ -0,0 +1,275 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/core"
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/generated"
+)
+
+type mappingProject struct {
+	ID       int           `qb:"3"`
+	Name     string        `qb:"name"`
+	Budget   *float64      `qb:"7,omitempty"`
+	Due      time.Time     `qb:"8,date"`
+	Tags     []string      `qb:"9"`
+	Owner    *User         `qb:"10,omitempty"`
+	Estimate time.Duration `qb:"11,omitempty"`
+	Done     bool          `qb:"12"`
+	Internal string        `qb:"-"`
+	Untagged string
+}
+
+func mappingSchema() *core.ResolvedSchema {
+	return testSchema(map[string]core.TableSchema{
+		"projects": {
+			ID:     "bqxyz123",
+			Fields: map[string]int{"id": 3, "name": 6},
+		},
+	})
+}
+
+func TestDecodeRecords(t *testing.T) {
+	c := &Client{schema: mappingSchema()}
+	records := []generated.QuickbaseRecord{
+		wrapRecord(Record{
+			"3":  float64(42),
+			"6":  "Apollo",
+			"7":  1500.5,
+			"8":  "2024-03-15",
+			"9":  []any{"red", "blue"},
+			"10": map[string]any{"id": "123.abcd", "email": "a@example.com", "name": "Ann"},
+			"11": float64(90000),
+			"12": true,
+		}),
+		wrapRecord(Record{"3": float64(43), "6": "Gemini", "7": nil, "10": nil}),
+	}
+
+	projects, err := DecodeRecords[mappingProject](c, "projects", records)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(projects) != 2 {
+		t.Fatalf("expected 2 projects, got %d", len(projects))
+	}
+
+	p := projects[0]
+	if p.ID != 42 || p.Name != "Apollo" || !p.Done {
+		t.Errorf("unexpected scalar values: %+v", p)
+	}
+	if p.Budget == nil || *p.Budget != 1500.5 {
+		t.Errorf("Budget = %v, want 1500.5", p.Budget)
+	}
+	if want := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC); !p.Due.Equal(want) {
+		t.Errorf("Due = %v, want %v", p.Due, want)
+	}
+	if len(p.Tags) != 2 || p.Tags[0] != "red" || p.Tags[1] != "blue" {
+		t.Errorf("Tags = %v, want [red blue]", p.Tags)
+	}
+	if p.Owner == nil || p.Owner.Email != "a@example.com" || p.Owner.Name != "Ann" {
+		t.Errorf("Owner = %+v", p.Owner)
+	}
+	if p.Estimate != 90*time.Second {
+		t.Errorf("Estimate = %v, want 1m30s", p.Estimate)
+	}
+
+	if projects[1].Budget != nil {
+		t.Errorf("expected nil Budget for null value, got %v", *projects[1].Budget)
+	}
+	if projects[1].Owner != nil {
+		t.Errorf("expected nil Owner for null value, got %+v", projects[1].Owner)
+	}
+}
+
+func TestDecodeRecords_TypeErrors(t *testing.T) {
+	type row struct {
+		Count int    `qb:"6"`
+		Name  string `qb:"7"`
+	}
+
+	tests := []struct {
+		name   string
+		record Record
+	}{
+		{"text into int", Record{"6": "twelve"}},
+		{"fraction into int", Record{"6": 1.5}},
+		{"number into string", Record{"7": float64(3)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{}
+			_, err := DecodeRecords[row](c, "bqxyz123", []generated.QuickbaseRecord{wrapRecord(tt.record)})
+			var mapErr *core.MappingError
+			if !errors.As(err, &mapErr) {
+				t.Fatalf("expected MappingError, got %v", err)
+			}
+			if mapErr.Index != 0 || mapErr.FieldID == 0 {
+				t.Errorf("unexpected error details: %+v", mapErr)
+			}
+		})
+	}
+}
+
+func TestDecodeRecords_ExactNumbers(t *testing.T) {
+	type row struct {
+		ID     int64        `qb:"3"`
+		Amount core.Decimal `qb:"6"`
+		Raw    json.Number  `qb:"7"`
+	}
+	records := []generated.QuickbaseRecord{wrapRecord(Record{
+		"3": json.Number("12345678901234567"),
+		"6": json.Number("1234567890.123456789"),
+		"7": json.Number("0.10"),
+	})}
+
+	rows, err := DecodeRecords[row](&Client{}, "bqxyz123", records)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r := rows[0]
+	if r.ID != 12345678901234567 || r.Amount.String() != "1234567890.123456789" || r.Raw != "0.10" {
+		t.Errorf("decoded %+v", r)
+	}
+
+	encoded, err := EncodeRecords(&Client{}, "bqxyz123", rows)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for id, want := range map[string]string{"3": "12345678901234567", "6": "1234567890.123456789", "7": "0.10"} {
+		if got, _ := encoded[0][id].Value.MarshalJSON(); string(got) != want {
+			t.Errorf("field %s encoded as %s, want %s", id, got, want)
+		}
+	}
+}
+
+func TestDecodeRecords_RequiresStruct(t *testing.T) {
+	c := &Client{}
+	_, err := DecodeRecords[int](c, "bqxyz123", nil)
+	if err == nil {
+		t.Fatal("expected error for non-struct type")
+	}
+}
+
+func TestEncodeRecords(t *testing.T) {
+	c := &Client{schema: mappingSchema()}
+	budget := 250.0
+	items := []mappingProject{
+		{
+			ID:       42,
+			Name:     "Apollo",
+			Budget:   &budget,
+			Due:      time.Date(2024, 3, 15, 18, 30, 0, 0, time.UTC),
+			Tags:     []string{"red"},
+			Owner:    &User{Email: "a@example.com"},
+			Estimate: 2 * time.Minute,
+			Internal: "ignored",
+		},
+		{Name: "Gemini"},
+	}
+
+	records, err := EncodeRecords(c, "projects", items)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	first := unwrapRecord(records[0])
+	if first["6"] != "Apollo" {
+		t.Errorf("name = %v, want Apollo", first["6"])
+	}
+	if first["7"] != 250.0 {
+		t.Errorf("budget = %v, want 250", first["7"])
+	}
+	if first["8"] != "2024-03-15" {
+		t.Errorf("due = %v, want 2024-03-15", first["8"])
+	}
+	if first["11"] != float64(120000) {
+		t.Errorf("estimate = %v, want 120000", first["11"])
+	}
+	owner, ok := first["10"].(map[string]any)
+	if !ok || owner["email"] != "a@example.com" {
+		t.Errorf("owner = %v", first["10"])
+	}
+	if len(first) != 8 {
+		t.Errorf("expected 8 fields, got %d: %v", len(first), first)
+	}
+
+	// omitempty fields are left out; other empty values are still sent
+	second := unwrapRecord(records[1])
+	for _, id := range []string{"7", "10", "11"} {
+		if _, ok := second[id]; ok {
+			t.Errorf("expected omitempty field %s to be omitted", id)
+		}
+	}
+	if _, ok := second["3"]; !ok {
+		t.Error("expected field 3 to be sent without omitempty")
+	}
+}
+
+func TestEncodeRecords_NilPointerClearsField(t *testing.T) {
+	type row struct {
+		Budget *float64 `qb:"7"`
+	}
+
+	records, err := EncodeRecords(&Client{}, "bqxyz123", []row{{}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	value, ok := unwrapRecord(records[0])["7"]
+	if !ok || value != nil {
+		t.Errorf("expected field 7 to be sent as null, got %v (present=%v)", value, ok)
+	}
+}
+
+func TestEncodeRecords_AliasWithoutSchema(t *testing.T) {
+	_, err := EncodeRecords(&Client{}, "bqxyz123", []mappingProject{{}})
+	var schemaErr *core.SchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("expected SchemaError, got %v", err)
+	}
+}
+
+func TestQueryInto_SelectsTaggedFields(t *testing.T) {
+	c := &Client{schema: mappingSchema()}
+	b := c.Query("projects")
+
+	m, err := newRecordMapper[mappingProject](c, b.tableID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ids := m.fieldIDs()
+	want := []any{3, 6, 7, 8, 9, 10, 11, 12}
+	if len(ids) != len(want) {
+		t.Fatalf("fieldIDs = %v, want %v", ids, want)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Errorf("fieldIDs[%d] = %v, want %v", i, ids[i], want[i])
+		}
+	}
+}
+
+func TestUpsertFrom(t *testing.T) {
+	c := &Client{schema: mappingSchema()}
+	b := UpsertFrom(c.Upsert("projects"), mappingProject{ID: 1, Name: "Apollo"})
+	if b.err != nil {
+		t.Fatalf("unexpected error: %v", b.err)
+	}
+	data, ok := b.params["data"].([]any)
+	if !ok || len(data) != 1 {
+		t.Fatalf("expected 1 data record, got %v", b.params["data"])
+	}
+
+	// Encoding errors surface on the builder
+	type bad struct {
+		Fn func() `qb:"6"`
+	}
+	b = UpsertFrom(c.Upsert("projects"), bad{Fn: func() {}})
+	var mapErr *core.MappingError
+	if !errors.As(b.err, &mapErr) {
+		t.Fatalf("expected MappingError, got %v", b.err)
+	}
+}
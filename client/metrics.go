@@ -0,0 +1,276 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/core"
+)
+
+// DefaultLatencyBuckets are the default latency histogram upper bounds.
+var DefaultLatencyBuckets = []time.Duration{
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+}
+
+// Metrics collects request counts, error counts by class, retry counts,
+// throttle wait time and latency histograms, keyed by operation and table.
+// Enable it with WithMetrics. It is safe for concurrent use, and one Metrics
+// may be shared by several clients.
+//
+// A call is counted once, however many attempts it takes; its latency includes
+// retries and throttle waits. Export with Snapshot (for example from a
+// Prometheus collector), publish it with expvar.Publish (Metrics implements
+// expvar.Var), or push each call with MetricsOptions.OnObserve.
+type Metrics struct {
+	mu        sync.Mutex
+	buckets   []time.Duration
+	onObserve func(Observation)
+	endpoints map[metricsKey]*EndpointMetrics
+}
+
+// MetricsOptions configures NewMetricsWithOptions.
+type MetricsOptions struct {
+	// Buckets are the latency histogram upper bounds, in increasing order.
+	// Defaults to DefaultLatencyBuckets.
+	Buckets []time.Duration
+
+	// OnObserve is called after every call, for push-based exporters.
+	OnObserve func(Observation)
+}
+
+// Observation describes a completed call. It is passed to MetricsOptions.OnObserve.
+type Observation struct {
+	Operation    string        // Method and path template (e.g. "POST /records/query") or XML action
+	Table        string        // App or table ID ("" if none)
+	StatusCode   int           // Final HTTP status code (0 if no response)
+	ErrorClass   string        // Error class (e.g. "RateLimitError"), "" on success
+	Duration     time.Duration // Total duration, including retries and throttle waits
+	Retries      int           // Number of retries
+	ThrottleWait time.Duration // Time spent waiting on the throttle
+}
+
+// EndpointMetrics holds the metrics for one operation and table.
+type EndpointMetrics struct {
+	Operation    string           `json:"operation"`
+	Table        string           `json:"table"`
+	Requests     int64            `json:"requests"`
+	Errors       map[string]int64 `json:"errors"` // Keyed by error class
+	Retries      int64            `json:"retries"`
+	ThrottleWait time.Duration    `json:"throttleWait"`
+	Latency      Histogram        `json:"latency"`
+}
+
+// Histogram is a latency histogram with cumulative bucket counts, matching
+// the Prometheus histogram model.
+type Histogram struct {
+	Buckets []time.Duration `json:"buckets"` // Upper bounds
+	Counts  []int64         `json:"counts"`  // Counts[i] = observations <= Buckets[i]
+	Count   int64           `json:"count"`
+	Sum     time.Duration   `json:"sum"`
+}
+
+type metricsKey struct {
+	operation string
+	table     string
+}
+
+// NewMetrics creates a metrics collector with the default latency buckets.
+func NewMetrics() *Metrics {
+	return NewMetricsWithOptions(MetricsOptions{})
+}
+
+// NewMetricsWithOptions creates a metrics collector with custom options.
+func NewMetricsWithOptions(opts MetricsOptions) *Metrics {
+	buckets := opts.Buckets
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	return &Metrics{
+		buckets:   append([]time.Duration(nil), buckets...),
+		onObserve: opts.OnObserve,
+		endpoints: make(map[metricsKey]*EndpointMetrics),
+	}
+}
+
+// WithMetrics records request metrics in m.
+//
+// Example:
+//
+//	metrics := quickbase.NewMetrics()
+//	client, _ := quickbase.New(realm, quickbase.WithUserToken(token), quickbase.WithMetrics(metrics))
+//	expvar.Publish("quickbase", metrics)
+func WithMetrics(m *Metrics) Option {
+	return func(c *Client) {
+		c.metrics = m
+	}
+}
+
+// Observe records a completed call.
+func (m *Metrics) Observe(o Observation) {
+	m.mu.Lock()
+	key := metricsKey{o.Operation, o.Table}
+	e := m.endpoints[key]
+	if e == nil {
+		e = &EndpointMetrics{
+			Operation: o.Operation,
+			Table:     o.Table,
+			Errors:    make(map[string]int64),
+			Latency: Histogram{
+				Buckets: m.buckets,
+				Counts:  make([]int64, len(m.buckets)),
+			},
+		}
+		m.endpoints[key] = e
+	}
+
+	e.Requests++
+	if o.ErrorClass != "" {
+		e.Errors[o.ErrorClass]++
+	}
+	e.Retries += int64(o.Retries)
+	e.ThrottleWait += o.ThrottleWait
+	e.Latency.Count++
+	e.Latency.Sum += o.Duration
+	for i, bound := range m.buckets {
+		if o.Duration <= bound {
+			e.Latency.Counts[i]++
+		}
+	}
+	m.mu.Unlock()
+
+	if m.onObserve != nil {
+		m.onObserve(o)
+	}
+}
+
+// Snapshot returns a copy of the current metrics, sorted by operation and table.
+func (m *Metrics) Snapshot() []EndpointMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out := make([]EndpointMetrics, 0, len(m.endpoints))
+	for _, e := range m.endpoints {
+		cp := *e
+		cp.Errors = make(map[string]int64, len(e.Errors))
+		for class, n := range e.Errors {
+			cp.Errors[class] = n
+		}
+		cp.Latency.Counts = append([]int64(nil), e.Latency.Counts...)
+		out = append(out, cp)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Operation != out[j].Operation {
+			return out[i].Operation < out[j].Operation
+		}
+		return out[i].Table < out[j].Table
+	})
+	return out
+}
+
+// Reset clears all recorded metrics.
+func (m *Metrics) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.endpoints = make(map[metricsKey]*EndpointMetrics)
+}
+
+// String returns the snapshot as JSON, so Metrics can be published with
+// expvar.Publish.
+func (m *Metrics) String() string {
+	b, err := json.Marshal(m.Snapshot())
+	if err != nil {
+		return "[]"
+	}
+	return string(b)
+}
+
+// callStats accumulates per-call values for metrics across attempts.
+type callStats struct {
+	operation    string
+	status       int
+	retries      int
+	throttleWait time.Duration
+}
+
+// idCollections are path segments followed by an app, table or other ID.
+var idCollections = map[string]bool{
+	"apps":         true,
+	"tables":       true,
+	"reports":      true,
+	"groups":       true,
+	"solutions":    true,
+	"docTemplates": true,
+	"relationship": true,
+}
+
+// operationName names a request for metrics: the XML action, or the method and
+// path with the /v1 prefix removed and IDs replaced by {id}.
+func operationName(req *http.Request) string {
+	if action := req.Header.Get("QUICKBASE-ACTION"); action != "" {
+		return action
+	}
+	return pathOperation(req.Method, req.URL.Path)
+}
+
+// pathOperation names a JSON API call from its method and path.
+func pathOperation(method, path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) > 0 && segments[0] == "v1" {
+		segments = segments[1:]
+	}
+	for i, seg := range segments {
+		if strings.ContainsAny(seg, "0123456789") || (i > 0 && idCollections[segments[i-1]]) {
+			segments[i] = "{id}"
+		}
+	}
+	return method + " /" + strings.Join(segments, "/")
+}
+
+// errorClass classifies a call's outcome by the SDK error type it maps to.
+// It returns "" for successful calls.
+func errorClass(status int, err error) string {
+	switch {
+	case status == http.StatusBadRequest:
+		return "ValidationError"
+	case status == http.StatusUnauthorized:
+		return "AuthenticationError"
+	case status == http.StatusForbidden:
+		return "AuthorizationError"
+	case status == http.StatusNotFound:
+		return "NotFoundError"
+	case status == http.StatusTooManyRequests:
+		return "RateLimitError"
+	case status >= 500:
+		return "ServerError"
+	case status >= 400:
+		return "QuickbaseError"
+	case err == nil:
+		return ""
+	}
+
+	var rateLimitErr *core.RateLimitError
+	var timeoutErr *core.TimeoutError
+	switch {
+	case errors.As(err, &rateLimitErr):
+		return "RateLimitError"
+	case errors.As(err, &timeoutErr), errors.Is(err, context.DeadlineExceeded):
+		return "TimeoutError"
+	case errors.Is(err, context.Canceled):
+		return "Canceled"
+	}
+	return "NetworkError"
+}
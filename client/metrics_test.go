@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/auth"
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/core"
+)
+
+func TestMetrics_RecordsCalls(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/apps/bqmissing" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"Not found"}`))
+			return
+		}
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"bqapp123","name":"App"}`))
+	}))
+	defer srv.Close()
+
+	var observed []Observation
+	metrics := NewMetricsWithOptions(MetricsOptions{
+		Buckets:   []time.Duration{time.Nanosecond, time.Hour},
+		OnObserve: func(o Observation) { observed = append(observed, o) },
+	})
+	c, err := New("myrealm", auth.NewUserTokenStrategy("token"),
+		WithBaseURL(srv.URL),
+		WithMetrics(metrics),
+		WithRetryPolicy(ExponentialBackoff{MaxAttempts: 3, InitialDelay: time.Millisecond}))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := c.GetApp("bqapp123").Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if _, err := c.GetApp("bqmissing").Run(ctx); err == nil {
+		t.Fatal("expected error for missing app")
+	}
+
+	snap := metrics.Snapshot()
+	if len(snap) != 2 {
+		t.Fatalf("got %d endpoints, want 2: %+v", len(snap), snap)
+	}
+	ok, missing := snap[0], snap[1]
+	if ok.Table != "bqapp123" {
+		ok, missing = missing, ok
+	}
+	if ok.Operation != "GET /apps/{id}" || ok.Requests != 1 || ok.Retries != 1 || len(ok.Errors) != 0 {
+		t.Errorf("success endpoint = %+v", ok)
+	}
+	if ok.Latency.Count != 1 || ok.Latency.Counts[0] != 0 || ok.Latency.Counts[1] != 1 || ok.Latency.Sum <= 0 {
+		t.Errorf("latency = %+v, want one observation in the 1h bucket", ok.Latency)
+	}
+	if missing.Table != "bqmissing" || missing.Errors["NotFoundError"] != 1 {
+		t.Errorf("error endpoint = %+v", missing)
+	}
+
+	if len(observed) != 2 || observed[0].StatusCode != 200 || observed[1].ErrorClass != "NotFoundError" {
+		t.Errorf("observed = %+v", observed)
+	}
+
+	var decoded []EndpointMetrics
+	if err := json.Unmarshal([]byte(metrics.String()), &decoded); err != nil || len(decoded) != 2 {
+		t.Errorf("String() = %s, err = %v", metrics.String(), err)
+	}
+
+	metrics.Reset()
+	if len(metrics.Snapshot()) != 0 {
+		t.Error("Reset() did not clear metrics")
+	}
+}
+
+func TestMetrics_ThrottleWait(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"bqapp123","name":"App"}`))
+	}))
+	defer srv.Close()
+
+	metrics := NewMetrics()
+	c, err := New("myrealm", auth.NewUserTokenStrategy("token"),
+		WithBaseURL(srv.URL),
+		WithMetrics(metrics),
+		WithThrottle(NewSlidingWindowThrottle(1)))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	c.GetApp("bqapp123").Run(ctx)
+	c.GetApp("bqapp123").Run(ctx) // Waits on the throttle until the context expires
+
+	snap := metrics.Snapshot()
+	if len(snap) != 1 || snap[0].Requests != 2 {
+		t.Fatalf("snapshot = %+v", snap)
+	}
+	if snap[0].ThrottleWait < 40*time.Millisecond {
+		t.Errorf("ThrottleWait = %v, want about 50ms", snap[0].ThrottleWait)
+	}
+}
+
+func TestOperationName(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		action string
+		want   string
+	}{
+		{"GET", "/v1/apps/bqapp123", "", "GET /apps/{id}"},
+		{"POST", "/v1/records/query", "", "POST /records/query"},
+		{"GET", "/v1/fields", "", "GET /fields"},
+		{"GET", "/v1/fields/6", "", "GET /fields/{id}"},
+		{"GET", "/v1/tables/bqtable/relationships", "", "GET /tables/{id}/relationships"},
+		{"POST", "/db/bqapp123", "API_GetRoleInfo", "API_GetRoleInfo"},
+	}
+	for _, tt := range tests {
+		req := &http.Request{Method: tt.method, URL: &url.URL{Path: tt.path}, Header: http.Header{}}
+		if tt.action != "" {
+			req.Header.Set("QUICKBASE-ACTION", tt.action)
+		}
+		if got := operationName(req); got != tt.want {
+			t.Errorf("operationName(%s %s) = %q, want %q", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestErrorClass(t *testing.T) {
+	tests := []struct {
+		status int
+		err    error
+		want   string
+	}{
+		{200, nil, ""},
+		{207, nil, ""},
+		{400, nil, "ValidationError"},
+		{401, nil, "AuthenticationError"},
+		{403, nil, "AuthorizationError"},
+		{404, nil, "NotFoundError"},
+		{429, nil, "RateLimitError"},
+		{502, nil, "ServerError"},
+		{409, nil, "QuickbaseError"},
+		{0, &core.RateLimitError{}, "RateLimitError"},
+		{0, &core.TimeoutError{}, "TimeoutError"},
+		{0, context.DeadlineExceeded, "TimeoutError"},
+		{0, context.Canceled, "Canceled"},
+		{0, errors.New("connection refused"), "NetworkError"},
+	}
+	for _, tt := range tests {
+		if got := errorClass(tt.status, tt.err); got != tt.want {
+			t.Errorf("errorClass(%d, %v) = %q, want %q", tt.status, tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// Doer executes an HTTP request. It is the interface wrapped by Middleware.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc adapts a function to the Doer interface.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps a Doer to observe or modify requests and responses.
+//
+// Middleware wraps every JSON and XML API call, including DoXML. The request
+// it sees is unauthenticated; next applies throttling, authentication and
+// retries, so a middleware runs once per call rather than once per attempt.
+// A middleware may modify the request (headers, URL or body), return a
+// response without calling next, or inspect the response and error returned
+// by next. Use RequestDBID to get the app or table ID the request targets.
+type Middleware func(next Doer) Doer
+
+// WithMiddleware adds middleware to the client. Middleware runs in the order
+// given, so the first is the outermost. Repeated calls append to the chain.
+//
+// Example:
+//
+//	quickbase.WithMiddleware(func(next quickbase.Doer) quickbase.Doer {
+//	    return quickbase.DoerFunc(func(req *http.Request) (*http.Response, error) {
+//	        req.Header.Set("X-Request-Source", "nightly-sync")
+//	        resp, err := next.Do(req)
+//	        audit.Record(quickbase.RequestDBID(req), req.Method, req.URL.Path, err)
+//	        return resp, err
+//	    })
+//	})
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, middleware...)
+	}
+}
+
+type dbidKey struct{}
+
+// RequestDBID returns the app or table ID a request targets, as resolved by
+// the client from its path, query parameters or body. It returns "" for
+// requests that target neither, or for requests not made by the client.
+func RequestDBID(req *http.Request) string {
+	dbid, _ := req.Context().Value(dbidKey{}).(string)
+	return dbid
+}
+
+// withDBID records the resolved dbid on a request's context for RequestDBID.
+func withDBID(ctx context.Context, dbid string) context.Context {
+	return context.WithValue(ctx, dbidKey{}, dbid)
+}
+
+// chain wraps a terminal Doer with the client's middleware.
+func (c *Client) chain(terminal Doer) Doer {
+	d := terminal
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		d = c.middleware[i](d)
+	}
+	return d
+}
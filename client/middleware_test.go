@@ -0,0 +1,238 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/auth"
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/core"
+)
+
+// headerMiddleware sets a header and records the order middleware runs in.
+func headerMiddleware(name string, order *[]string) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			*order = append(*order, name)
+			req.Header.Add("X-Middleware", name)
+			return next.Do(req)
+		})
+	}
+}
+
+func TestMiddleware_JSON(t *testing.T) {
+	var gotHeaders []string
+	var gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeaders = r.Header.Values("X-Middleware")
+		gotAuth = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"bqapp123","name":"App"}`))
+	}))
+	defer srv.Close()
+
+	var order []string
+	var dbid string
+	var status int
+	audit := func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			dbid = RequestDBID(req)
+			resp, err := next.Do(req)
+			if resp != nil {
+				status = resp.StatusCode
+			}
+			return resp, err
+		})
+	}
+
+	c, err := New("myrealm", auth.NewUserTokenStrategy("token"),
+		WithBaseURL(srv.URL),
+		WithMiddleware(audit, headerMiddleware("first", &order)),
+		WithMiddleware(headerMiddleware("second", &order)))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if _, err := c.GetApp("bqapp123").Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if strings.Join(order, ",") != "first,second" {
+		t.Errorf("order = %v, want [first second]", order)
+	}
+	if strings.Join(gotHeaders, ",") != "first,second" {
+		t.Errorf("server saw X-Middleware = %v", gotHeaders)
+	}
+	if gotAuth != "QB-USER-TOKEN token" {
+		t.Errorf("Authorization = %q, want auth applied after middleware", gotAuth)
+	}
+	if dbid != "bqapp123" {
+		t.Errorf("RequestDBID() = %q, want bqapp123", dbid)
+	}
+	if status != http.StatusOK {
+		t.Errorf("status = %d, want 200", status)
+	}
+}
+
+func TestMiddleware_ShortCircuit(t *testing.T) {
+	cached := func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(`{"id":"bqapp123","name":"Cached"}`)),
+				Request:    req,
+			}, nil
+		})
+	}
+
+	// No server: the request never leaves the middleware
+	c, err := New("myrealm", auth.NewUserTokenStrategy("token"),
+		WithBaseURL("http://127.0.0.1:1"),
+		WithMiddleware(cached))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	app, err := c.GetApp("bqapp123").Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if app.Name() != "Cached" {
+		t.Errorf("Name() = %q, want Cached", app.Name())
+	}
+}
+
+func TestMiddleware_XML(t *testing.T) {
+	var dbid, action, body string
+	fake := func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			dbid = RequestDBID(req)
+			action = req.Header.Get("QUICKBASE-ACTION")
+			b, _ := io.ReadAll(req.Body)
+			body = string(b)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewReader([]byte("<qdbapi><errcode>0</errcode></qdbapi>"))),
+				Request:    req,
+			}, nil
+		})
+	}
+
+	c, err := New("myrealm", auth.NewUserTokenStrategy("token"), WithMiddleware(fake))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	resp, err := c.DoXML(context.Background(), "bqapp123", "API_GetRoleInfo", []byte("<qdbapi></qdbapi>"))
+	if err != nil {
+		t.Fatalf("DoXML() error = %v", err)
+	}
+	if string(resp) != "<qdbapi><errcode>0</errcode></qdbapi>" {
+		t.Errorf("DoXML() = %s", resp)
+	}
+	if dbid != "bqapp123" || action != "API_GetRoleInfo" {
+		t.Errorf("dbid = %q, action = %q", dbid, action)
+	}
+	if strings.Contains(body, "usertoken") {
+		t.Errorf("middleware saw credentials in body: %s", body)
+	}
+}
+
+// rewriteMiddleware replaces the request URL path with path.
+func rewriteMiddleware(method, path string) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			req.Method = method
+			req.URL.Path = path
+			return next.Do(req)
+		})
+	}
+}
+
+func TestMiddleware_ReadOnlyAfterRewrite(t *testing.T) {
+	hits := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"deletedAppId":"bqapp123"}`))
+	}))
+	defer srv.Close()
+
+	c, err := New("myrealm", auth.NewUserTokenStrategy("token"),
+		WithBaseURL(srv.URL), WithReadOnly(),
+		WithMiddleware(rewriteMiddleware(http.MethodDelete, "/apps/bqapp123")))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	_, err = c.GetApp("bqapp123").Run(context.Background())
+	var roErr *core.ReadOnlyError
+	if !errors.As(err, &roErr) {
+		t.Errorf("Run() error = %v, want ReadOnlyError", err)
+	}
+
+	setAction := func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("QUICKBASE-ACTION", "API_PurgeRecords")
+			return next.Do(req)
+		})
+	}
+	c, err = New("myrealm", auth.NewUserTokenStrategy("token"),
+		WithBaseURL(srv.URL), WithReadOnly(), WithMiddleware(setAction))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if _, err := c.DoXML(context.Background(), "bqtable1", "API_GetSchema", nil); !errors.As(err, &roErr) {
+		t.Errorf("DoXML() error = %v, want ReadOnlyError", err)
+	}
+	if hits != 0 {
+		t.Errorf("server received %d requests in read-only mode", hits)
+	}
+}
+
+func TestMiddleware_DBIDAfterRewrite(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"bqother","name":"App"}`))
+	}))
+	defer srv.Close()
+
+	metrics := NewMetrics()
+	c, err := New("myrealm", auth.NewUserTokenStrategy("token"),
+		WithBaseURL(srv.URL), WithMetrics(metrics),
+		WithMiddleware(rewriteMiddleware(http.MethodGet, "/apps/bqother")))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if _, err := c.GetApp("bqapp123").Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	snap := metrics.Snapshot()
+	if len(snap) != 1 || snap[0].Table != "bqother" {
+		t.Errorf("metrics = %+v, want the rewritten dbid bqother", snap)
+	}
+}
+
+func TestMiddleware_NilResponse(t *testing.T) {
+	empty := func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			return nil, nil
+		})
+	}
+	c, err := New("myrealm", auth.NewUserTokenStrategy("token"),
+		WithBaseURL("http://127.0.0.1:1"), WithMiddleware(empty))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if _, err := c.GetApp("bqapp123").Run(context.Background()); err == nil {
+		t.Error("Run() succeeded, want error for nil response")
+	}
+	if _, err := c.DoXML(context.Background(), "bqapp123", "API_GetRoleInfo", nil); err == nil {
+		t.Error("DoXML() succeeded, want error for nil response")
+	}
+}
@@ -2,6 +2,8 @@ package client
 
 import (
 	"context"
+	"iter"
+	"reflect"
 
 	"github.com/DrewBradfordXYZ/quickbase-go/v2/core"
 	"github.com/DrewBradfordXYZ/quickbase-go/v2/generated"
@@ -29,6 +31,8 @@ type QueryBuilder struct {
 	groupBy []any // Field IDs or aliases
 	top     *int
 	skip    *int
+	keyset  any // Key field for keyset pagination (nil pages by skip)
+	retry   RetryPolicy
 	err     error
 }
 
@@ -75,19 +79,25 @@ func (b *QueryBuilder) Select(fields ...any) *QueryBuilder {
 	return b
 }
 
-// Where sets the filter query string. Field aliases in the where clause are
-// automatically resolved if schema is configured.
+// Where sets the filter. Accepts a query string or a core.Condition built with
+// quickbase.F. Field aliases are resolved if schema is configured.
 //
 // Example:
 //
 //	Where("{'status'.EX.'Active'}")           // Using field alias
 //	Where("{7.EX.'Active'}")                  // Using field ID
 //	Where("{status.EX.'Active'} AND {dueDate.LT.'2024-01-01'}")
-func (b *QueryBuilder) Where(query string) *QueryBuilder {
+//	Where(quickbase.F("status").EX("Active").And(quickbase.F("dueDate").BF(time.Now())))
+func (b *QueryBuilder) Where(query any) *QueryBuilder {
 	if b.err != nil {
 		return b
 	}
-	b.where = query
+	where, err := whereString(b.client, b.tableID, query)
+	if err != nil {
+		b.err = err
+		return b
+	}
+	b.where = where
 	return b
 }
 
@@ -154,6 +164,26 @@ func (b *QueryBuilder) Skip(n int) *QueryBuilder {
 	return b
 }
 
+// Keyset pages through results by a unique, sortable field instead of by skip
+// offset, so records inserted or deleted during the scan are not missed or
+// duplicated. Accepts a field ID (int) or alias (string); use 3 for the record
+// ID. Results are sorted by the key field, so SortBy and GroupBy cannot be
+// combined with it. See Client.RunQueryAllKeyset.
+//
+// Example:
+//
+//	records, err := client.Query("projects").
+//	    Where("{'status'.EX.'Active'}").
+//	    Keyset(3).
+//	    Run(ctx)
+func (b *QueryBuilder) Keyset(field any) *QueryBuilder {
+	if b.err != nil {
+		return b
+	}
+	b.keyset = field
+	return b
+}
+
 // buildBody constructs the RunQueryJSONRequestBody from builder state.
 func (b *QueryBuilder) buildBody() (generated.RunQueryJSONRequestBody, error) {
 	body := generated.RunQueryJSONRequestBody{
@@ -222,9 +252,28 @@ func (b *QueryBuilder) buildBody() (generated.RunQueryJSONRequestBody, error) {
 	return body, nil
 }
 
+// fetcher returns the page fetcher for body, paging by keyset if one is set.
+func (b *QueryBuilder) fetcher(body generated.RunQueryJSONRequestBody) (PageFetcher[generated.QuickbaseRecord, *runQueryPageResponse], error) {
+	if b.keyset == nil {
+		return b.client.runQueryFetcher(body), nil
+	}
+	ids, err := b.resolveFieldIDs([]any{b.keyset})
+	if err != nil {
+		return nil, err
+	}
+	return b.client.keysetFetcher(body, ids[0])
+}
+
 // resolveFieldIDs converts a slice of field references (int IDs or string aliases)
 // to a slice of int field IDs.
 func (b *QueryBuilder) resolveFieldIDs(fields []any) ([]int, error) {
+	// Use tableID (resolved) for field lookup, not table (alias)
+	return resolveFieldIDs(b.client, b.tableID, fields)
+}
+
+// resolveFieldIDs converts field references (int IDs or string aliases) for
+// a table to field IDs.
+func resolveFieldIDs(c *Client, tableID string, fields []any) ([]int, error) {
 	ids := make([]int, 0, len(fields))
 
 	for _, field := range fields {
@@ -232,9 +281,8 @@ func (b *QueryBuilder) resolveFieldIDs(fields []any) ([]int, error) {
 		case int:
 			ids = append(ids, f)
 		case string:
-			if b.client.schema != nil {
-				// Use tableID (resolved) for field lookup, not table (alias)
-				fieldID, err := core.ResolveFieldAlias(b.client.schema, b.tableID, f)
+			if c.schema != nil {
+				fieldID, err := core.ResolveFieldAlias(c.schema, tableID, f)
 				if err != nil {
 					return nil, err
 				}
@@ -296,6 +344,13 @@ func (b *QueryBuilder) resolveSortFields() ([]generated.SortField, error) {
 	return sortFields, nil
 }
 
+// RetryPolicy overrides the client's retry policy for this query, including
+// every page fetched by Run.
+func (b *QueryBuilder) RetryPolicy(policy RetryPolicy) *QueryBuilder {
+	b.retry = policy
+	return b
+}
+
 // Run executes the query and returns all records as unwrapped maps.
 // This is a convenience method that auto-unwraps records since you're opting
 // into the Query builder's fluent API. For raw generated types, use RunRaw().
@@ -303,18 +358,24 @@ func (b *QueryBuilder) Run(ctx context.Context) ([]Record, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 
 	body, err := b.buildBody()
 	if err != nil {
 		return nil, err
 	}
 
-	records, err := b.client.RunQueryAll(ctx, body)
+	fetcher, err := b.fetcher(body)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := CollectAll(ctx, fetcher)
 	if err != nil {
 		return nil, err
 	}
 
-	return unwrapRecords(records), nil
+	return unwrapRecordsAs(records, b.client.numbers), nil
 }
 
 // RunRaw executes the query and returns the result with convenience methods.
@@ -323,6 +384,7 @@ func (b *QueryBuilder) RunRaw(ctx context.Context) (*RunQueryResult, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 
 	body, err := b.buildBody()
 	if err != nil {
@@ -338,16 +400,204 @@ func (b *QueryBuilder) RunN(ctx context.Context, n int) ([]Record, error) {
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
+
+	body, err := b.buildBody()
+	if err != nil {
+		return nil, err
+	}
+
+	fetcher, err := b.fetcher(body)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := CollectN(ctx, fetcher, n)
+	if err != nil {
+		return nil, err
+	}
+
+	return unwrapRecordsAs(records, b.client.numbers), nil
+}
+
+// RunParallel executes the query like Run, fetching the pages after the first
+// concurrently. See Client.RunQueryAllParallel.
+func (b *QueryBuilder) RunParallel(ctx context.Context, concurrency int) ([]Record, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	ctx = withRetryPolicy(ctx, b.retry)
 
 	body, err := b.buildBody()
 	if err != nil {
 		return nil, err
 	}
 
-	records, err := b.client.RunQueryN(ctx, body, n)
+	if b.keyset != nil {
+		return nil, &core.ValidationError{
+			QuickbaseError: core.QuickbaseError{
+				Message: "keyset pagination fetches pages in sequence and cannot run in parallel",
+			},
+		}
+	}
+
+	records, err := b.client.RunQueryAllParallel(ctx, body, concurrency)
 	if err != nil {
 		return nil, err
 	}
 
-	return unwrapRecords(records), nil
+	return unwrapRecordsAs(records, b.client.numbers), nil
+}
+
+// Iter executes the query and returns an iterator over unwrapped records.
+// Pages are fetched lazily as the loop advances, so only one page is held in
+// memory at a time. Breaking out of the loop stops fetching.
+//
+// Example:
+//
+//	for record, err := range client.Query("projects").Select("name").Iter(ctx) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    fmt.Println(record["name"])
+//	}
+func (b *QueryBuilder) Iter(ctx context.Context) iter.Seq2[Record, error] {
+	return func(yield func(Record, error) bool) {
+		if b.err != nil {
+			yield(nil, b.err)
+			return
+		}
+		ctx := withRetryPolicy(ctx, b.retry)
+
+		body, err := b.buildBody()
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		fetcher, err := b.fetcher(body)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		for record, err := range Paginate(ctx, fetcher) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(unwrapRecordAs(record, b.client.numbers), nil) {
+				return
+			}
+		}
+	}
+}
+
+// QueryInto executes the query and decodes all records into structs using
+// `qb` struct tags. If no fields were selected, the struct's tagged fields
+// are selected automatically. See UpsertFrom for the encoding counterpart.
+//
+// Example:
+//
+//	type Project struct {
+//	    ID     int       `qb:"3"`
+//	    Name   string    `qb:"name"`
+//	    Due    time.Time `qb:"dueDate"`
+//	}
+//
+//	projects, err := quickbase.QueryInto[Project](ctx, client.Query("projects").Where("{status.EX.'Active'}"))
+func QueryInto[T any](ctx context.Context, b *QueryBuilder) ([]T, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	ctx = withRetryPolicy(ctx, b.retry)
+
+	m, err := newRecordMapper[T](b.client, b.tableID)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := b.mappedBody(m)
+	if err != nil {
+		return nil, err
+	}
+
+	fetcher, err := b.fetcher(body)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := CollectAll(ctx, fetcher)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeRecords[T](m, records)
+}
+
+// IterInto executes the query and returns an iterator that decodes each record
+// into a struct using `qb` struct tags, fetching pages lazily like
+// QueryBuilder.Iter. Field selection follows QueryInto.
+//
+// Example:
+//
+//	for project, err := range quickbase.IterInto[Project](ctx, client.Query("projects")) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    export(project)
+//	}
+func IterInto[T any](ctx context.Context, b *QueryBuilder) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		if b.err != nil {
+			yield(zero, b.err)
+			return
+		}
+		ctx := withRetryPolicy(ctx, b.retry)
+
+		m, err := newRecordMapper[T](b.client, b.tableID)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		body, err := b.mappedBody(m)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+
+		fetcher, err := b.fetcher(body)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+
+		i := 0
+		for record, err := range Paginate(ctx, fetcher) {
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			var item T
+			if err := m.decode(record, i, reflect.ValueOf(&item).Elem()); err != nil {
+				yield(zero, err)
+				return
+			}
+			i++
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
+
+// mappedBody builds the request body for a struct-mapped query, selecting the
+// struct's tagged fields if no fields were selected.
+func (b *QueryBuilder) mappedBody(m *recordMapper) (generated.RunQueryJSONRequestBody, error) {
+	q := *b
+	if len(q.fields) == 0 {
+		q.fields = m.fieldIDs()
+	}
+	return q.buildBody()
 }
@@ -1,8 +1,16 @@
 package client
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/auth"
+
 	"github.com/DrewBradfordXYZ/quickbase-go/v2/core"
 	"github.com/DrewBradfordXYZ/quickbase-go/v2/generated"
 )
@@ -88,6 +96,78 @@ func TestQueryBuilder_Where(t *testing.T) {
 	}
 }
 
+func TestQueryBuilder_WhereCondition(t *testing.T) {
+	schema := core.NewSchema().
+		Table("projects", "bqxyz123").
+		Field("name", 6).
+		Field("status", 7).
+		Build()
+
+	client := &Client{schema: core.ResolveSchema(schema)}
+	qb := client.Query("projects").
+		Where(core.F("status").EX("Active").And(core.F("name").SW("O'B")))
+
+	if qb.err != nil {
+		t.Fatalf("unexpected error: %v", qb.err)
+	}
+	want := `{7.EX.'Active'}AND{6.SW.'O\'B'}`
+	if qb.where != want {
+		t.Errorf("expected where %s, got %s", want, qb.where)
+	}
+
+	// Unknown aliases and unsupported types surface as builder errors
+	if qb := client.Query("projects").Where(core.F("stauts").EX("x")); qb.err == nil {
+		t.Error("expected error for unknown alias in condition")
+	}
+	if qb := client.Query("projects").Where(42); qb.err == nil {
+		t.Error("expected error for non-string where")
+	}
+}
+
+func TestRunQuery_InvalidWhereFailsFast(t *testing.T) {
+	schema := core.NewSchema().
+		Table("projects", "bqxyz123").
+		Field("status", 7).
+		Build()
+
+	tests := []struct {
+		name   string
+		schema *core.ResolvedSchema
+		where  string
+	}{
+		{"unknown alias", core.ResolveSchema(schema), "{'stauts'.EX.'Open'}"},
+		{"unknown operator", core.ResolveSchema(schema), "{7.EQ.'Open'}"},
+		{"unbalanced braces", nil, "{7.EX.'Open'"},
+		{"alias without schema", nil, "{status.EX.'Open'}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{schema: tt.schema}
+			_, _, err := c.transformRunQueryBody(generated.RunQueryJSONRequestBody{
+				From:  "bqxyz123",
+				Where: mustWhereUnion(t, tt.where),
+			})
+			var schemaErr *core.SchemaError
+			if !errors.As(err, &schemaErr) {
+				t.Fatalf("expected SchemaError, got %v", err)
+			}
+			if schemaErr.Query != tt.where {
+				t.Errorf("Query = %q, want %q", schemaErr.Query, tt.where)
+			}
+		})
+	}
+}
+
+func mustWhereUnion(t *testing.T, s string) *generated.RunQueryJSONBody_Where {
+	t.Helper()
+	where, err := StringToWhereUnion(s)
+	if err != nil {
+		t.Fatalf("StringToWhereUnion() error = %v", err)
+	}
+	return where
+}
+
 func TestQueryBuilder_SortBy(t *testing.T) {
 	client := &Client{}
 	qb := client.Query("bqxyz123").
@@ -339,3 +419,126 @@ func isSchemaError(err error, target **core.SchemaError) bool {
 	}
 	return false
 }
+
+// newQueryPagingServer serves total records with IDs 1..total in pages of
+// pageSize and counts requests. Field 6 holds "name-<id>".
+func newQueryPagingServer(t *testing.T, total, pageSize int) (*Client, *int) {
+	t.Helper()
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var body struct {
+			Options struct {
+				Skip int `json:"skip"`
+			} `json:"options"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		var data []map[string]any
+		for id := body.Options.Skip + 1; id <= min(total, body.Options.Skip+pageSize); id++ {
+			data = append(data, map[string]any{
+				"3": map[string]any{"value": id},
+				"6": map[string]any{"value": fmt.Sprintf("name-%d", id)},
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"data":     data,
+			"fields":   []any{},
+			"metadata": map[string]any{"totalRecords": total, "numRecords": len(data), "numFields": 2, "skip": body.Options.Skip},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := New("myrealm", auth.NewUserTokenStrategy("token"), WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return c, &requests
+}
+
+func TestQueryBuilder_Iter(t *testing.T) {
+	c, requests := newQueryPagingServer(t, 5, 2)
+
+	var names []any
+	for record, err := range c.Query("bqxyz123").Select(3, 6).Iter(context.Background()) {
+		if err != nil {
+			t.Fatalf("Iter() error = %v", err)
+		}
+		names = append(names, record["6"])
+	}
+	if len(names) != 5 || names[4] != "name-5" {
+		t.Errorf("Iter() yielded %v, want name-1..name-5", names)
+	}
+	if *requests != 3 {
+		t.Errorf("made %d requests, want 3", *requests)
+	}
+}
+
+func TestQueryBuilder_IterStopsFetching(t *testing.T) {
+	c, requests := newQueryPagingServer(t, 100, 10)
+
+	count := 0
+	for _, err := range c.Query("bqxyz123").Iter(context.Background()) {
+		if err != nil {
+			t.Fatalf("Iter() error = %v", err)
+		}
+		count++
+		if count == 15 {
+			break
+		}
+	}
+	if *requests != 2 {
+		t.Errorf("made %d requests for 15 records, want 2", *requests)
+	}
+}
+
+func TestQueryBuilder_IterError(t *testing.T) {
+	c := &Client{}
+	b := c.Query("projects")
+	b.err = errors.New("bad builder")
+
+	for _, err := range b.Iter(context.Background()) {
+		if err == nil || err.Error() != "bad builder" {
+			t.Errorf("Iter() error = %v, want bad builder", err)
+		}
+	}
+}
+
+func TestIterInto(t *testing.T) {
+	c, requests := newQueryPagingServer(t, 3, 2)
+
+	type row struct {
+		ID   int    `qb:"3"`
+		Name string `qb:"6"`
+	}
+	var rows []row
+	for r, err := range IterInto[row](context.Background(), c.Query("bqxyz123")) {
+		if err != nil {
+			t.Fatalf("IterInto() error = %v", err)
+		}
+		rows = append(rows, r)
+	}
+	if len(rows) != 3 || rows[2] != (row{ID: 3, Name: "name-3"}) {
+		t.Errorf("IterInto() yielded %+v", rows)
+	}
+	if *requests != 2 {
+		t.Errorf("made %d requests, want 2", *requests)
+	}
+
+	// Decoding errors end the iteration
+	type badRow struct {
+		Name int `qb:"6"`
+	}
+	var errs int
+	for _, err := range IterInto[badRow](context.Background(), c.Query("bqxyz123")) {
+		var mappingErr *core.MappingError
+		if !errors.As(err, &mappingErr) {
+			t.Errorf("IterInto() error = %v, want MappingError", err)
+		}
+		errs++
+	}
+	if errs != 1 {
+		t.Errorf("got %d errors, want 1", errs)
+	}
+}
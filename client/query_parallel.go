@@ -0,0 +1,175 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/generated"
+)
+
+// ErrResultSetChanged is returned by RunQueryAllParallel when the query's
+// totalRecords changes between pages, meaning records were added or removed
+// during the scan and the combined result would have gaps or duplicates.
+var ErrResultSetChanged = errors.New("result set changed during parallel query")
+
+// defaultQueryConcurrency is the number of pages RunQueryAllParallel fetches
+// at once when no concurrency is given.
+const defaultQueryConcurrency = 4
+
+// RunQueryAllParallel fetches all records like RunQueryAll, but fetches the
+// pages after the first concurrently. The first page's metadata gives the
+// total and the page size, so the remaining skip offsets are known up front.
+//
+// At most concurrency pages are in flight at once (default 4 if <= 0), and
+// requests still pass through the client's throttle. Records are returned in
+// the same order as RunQueryAll. If totalRecords changes mid-scan, it returns
+// an error wrapping ErrResultSetChanged and no records.
+//
+// Example:
+//
+//	records, err := client.RunQueryAllParallel(ctx, body, 8)
+//	if errors.Is(err, quickbase.ErrResultSetChanged) {
+//	    // The table changed while reading; retry or fall back to RunQueryAll
+//	}
+func (c *Client) RunQueryAllParallel(ctx context.Context, body generated.RunQueryJSONRequestBody, concurrency int) ([]generated.QuickbaseRecord, error) {
+	if concurrency <= 0 {
+		concurrency = defaultQueryConcurrency
+	}
+
+	first, meta, err := c.runQueryPage(ctx, body, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+	if meta == nil || meta.NumRecords == 0 || meta.NumRecords >= meta.TotalRecords {
+		return first, nil
+	}
+	total, pageSize := meta.TotalRecords, meta.NumRecords
+
+	// Offsets of the remaining pages, each fetched into its own slot
+	var offsets []int
+	for skip := pageSize; skip < total; skip += pageSize {
+		offsets = append(offsets, skip)
+	}
+	pages := make([][]generated.QuickbaseRecord, len(offsets))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+		next     = make(chan int)
+	)
+	fail := func(err error) {
+		errOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	for range min(concurrency, len(offsets)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				count := min(pageSize, total-offsets[i])
+				records, err := c.runQueryRange(ctx, body, offsets[i], count, total)
+				if err != nil {
+					fail(err)
+					continue
+				}
+				pages[i] = records
+			}
+		}()
+	}
+
+dispatch:
+	for i := range offsets {
+		select {
+		case next <- i:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(next)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	records := make([]generated.QuickbaseRecord, 0, total)
+	records = append(records, first...)
+	for _, page := range pages {
+		records = append(records, page...)
+	}
+	return records, nil
+}
+
+// runQueryRange fetches count records starting at skip. QuickBase may return
+// fewer records than requested when they are large, so it keeps fetching from
+// where the last response ended. Every response must report total records.
+func (c *Client) runQueryRange(ctx context.Context, body generated.RunQueryJSONRequestBody, skip, count, total int) ([]generated.QuickbaseRecord, error) {
+	records := make([]generated.QuickbaseRecord, 0, count)
+	for len(records) < count {
+		top := count - len(records)
+		data, meta, err := c.runQueryPage(ctx, body, skip+len(records), &top)
+		if err != nil {
+			return nil, err
+		}
+		if meta == nil || meta.TotalRecords != total {
+			got := -1
+			if meta != nil {
+				got = meta.TotalRecords
+			}
+			return nil, fmt.Errorf("%w: totalRecords was %d, page at skip %d reported %d",
+				ErrResultSetChanged, total, skip+len(records), got)
+		}
+		if len(data) == 0 {
+			return nil, fmt.Errorf("%w: page at skip %d is empty but %d of %d records remain",
+				ErrResultSetChanged, skip+len(records), count-len(records), total)
+		}
+		records = append(records, data...)
+	}
+	return records[:count], nil
+}
+
+// runQueryPage fetches one page of a query at the given skip, optionally
+// limited to top records.
+func (c *Client) runQueryPage(ctx context.Context, body generated.RunQueryJSONRequestBody, skip int, top *int) ([]generated.QuickbaseRecord, *generated.RunQueryMetadata, error) {
+	bodyCopy := body
+	bodyCopy.Options = withSkip(body.Options, skip)
+	if top != nil {
+		bodyCopy.Options.Top = top
+	}
+
+	resp, err := c.API().RunQueryWithResponse(ctx, bodyCopy)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.JSON200 == nil {
+		return nil, nil, parseAPIError(resp.StatusCode(), resp.Body, resp.HTTPResponse)
+	}
+
+	var data []generated.QuickbaseRecord
+	if resp.JSON200.Data != nil {
+		data = *resp.JSON200.Data
+	}
+	return data, resp.JSON200.Metadata, nil
+}
+
+// withSkip returns a copy of opts with Skip set.
+func withSkip(opts *generated.RunQueryJSONBody_Options, skip int) *generated.RunQueryJSONBody_Options {
+	var o generated.RunQueryJSONBody_Options
+	if opts != nil {
+		o = *opts
+	}
+	o.Skip = &skip
+	return &o
+}
@@ -0,0 +1,179 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/generated"
+)
+
+// parallelQueryServer serves records with IDs 1..total, at most pageSize per
+// response (less if the request's top is smaller).
+type parallelQueryServer struct {
+	mu       sync.Mutex
+	total    int
+	pageSize int
+	skips    []int
+	concurrencyTracker
+
+	// onRequest may change total after a request is served
+	onRequest func(s *parallelQueryServer, n int)
+}
+
+func (s *parallelQueryServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	defer s.enter()()
+	time.Sleep(5 * time.Millisecond)
+
+	var body struct {
+		Options struct {
+			Skip int  `json:"skip"`
+			Top  *int `json:"top"`
+		} `json:"options"`
+	}
+	json.NewDecoder(r.Body).Decode(&body)
+	skip := body.Options.Skip
+
+	s.mu.Lock()
+	s.skips = append(s.skips, skip)
+	total, size := s.total, s.pageSize
+	if s.onRequest != nil {
+		s.onRequest(s, len(s.skips))
+	}
+	s.mu.Unlock()
+
+	if top := body.Options.Top; top != nil && *top < size {
+		size = *top
+	}
+	data := []map[string]any{}
+	for id := skip + 1; id <= min(total, skip+size); id++ {
+		data = append(data, map[string]any{"3": map[string]any{"value": id}})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"data":     data,
+		"fields":   []any{},
+		"metadata": map[string]any{"totalRecords": total, "numRecords": len(data), "numFields": 1, "skip": skip},
+	})
+}
+
+func recordIDs(records []generated.QuickbaseRecord) []int {
+	ids := make([]int, len(records))
+	for i, r := range records {
+		ids[i] = int(unwrapRecord(r)["3"].(float64))
+	}
+	return ids
+}
+
+func TestRunQueryAllParallel(t *testing.T) {
+	s := &parallelQueryServer{total: 95, pageSize: 10}
+	c := newHandlerClient(t, s)
+
+	records, err := c.RunQueryAllParallel(context.Background(), generated.RunQueryJSONRequestBody{From: "bqxyz123"}, 3)
+	if err != nil {
+		t.Fatalf("RunQueryAllParallel() error = %v", err)
+	}
+	ids := recordIDs(records)
+	if len(ids) != 95 {
+		t.Fatalf("got %d records, want 95", len(ids))
+	}
+	for i, id := range ids {
+		if id != i+1 {
+			t.Fatalf("records out of order at %d: got id %d", i, id)
+		}
+	}
+	if len(s.skips) != 10 {
+		t.Errorf("made %d requests, want 10", len(s.skips))
+	}
+	if peak := s.peak(); peak > 3 || peak < 2 {
+		t.Errorf("peak concurrency = %d, want 2-3", peak)
+	}
+}
+
+func TestRunQueryAllParallel_SinglePage(t *testing.T) {
+	s := &parallelQueryServer{total: 5, pageSize: 10}
+	c := newHandlerClient(t, s)
+
+	records, err := c.RunQueryAllParallel(context.Background(), generated.RunQueryJSONRequestBody{From: "bqxyz123"}, 0)
+	if err != nil {
+		t.Fatalf("RunQueryAllParallel() error = %v", err)
+	}
+	if len(records) != 5 || len(s.skips) != 1 {
+		t.Errorf("got %d records from %d requests, want 5 from 1", len(records), len(s.skips))
+	}
+}
+
+func TestRunQueryAllParallel_ShortPages(t *testing.T) {
+	// Later pages return fewer records than the first, as QuickBase does for
+	// large records; the gaps are filled with follow-up requests
+	s := &parallelQueryServer{total: 40, pageSize: 10}
+	s.onRequest = func(s *parallelQueryServer, n int) {
+		if n == 1 {
+			s.pageSize = 4
+		}
+	}
+	c := newHandlerClient(t, s)
+
+	records, err := c.RunQueryAllParallel(context.Background(), generated.RunQueryJSONRequestBody{From: "bqxyz123"}, 2)
+	if err != nil {
+		t.Fatalf("RunQueryAllParallel() error = %v", err)
+	}
+	ids := recordIDs(records)
+	for i, id := range ids {
+		if id != i+1 {
+			t.Fatalf("records out of order at %d: got id %d", i, id)
+		}
+	}
+	if len(ids) != 40 {
+		t.Errorf("got %d records, want 40", len(ids))
+	}
+}
+
+func TestRunQueryAllParallel_TotalChanged(t *testing.T) {
+	s := &parallelQueryServer{total: 50, pageSize: 10}
+	s.onRequest = func(s *parallelQueryServer, n int) {
+		if n == 3 {
+			s.total = 51
+		}
+	}
+	c := newHandlerClient(t, s)
+
+	records, err := c.RunQueryAllParallel(context.Background(), generated.RunQueryJSONRequestBody{From: "bqxyz123"}, 2)
+	if !errors.Is(err, ErrResultSetChanged) {
+		t.Fatalf("RunQueryAllParallel() error = %v, want ErrResultSetChanged", err)
+	}
+	if records != nil {
+		t.Errorf("got %d records with error, want none", len(records))
+	}
+}
+
+func TestRunQueryAllParallel_DoesNotMutateBody(t *testing.T) {
+	s := &parallelQueryServer{total: 30, pageSize: 10}
+	c := newHandlerClient(t, s)
+
+	skip := 0
+	body := generated.RunQueryJSONRequestBody{From: "bqxyz123", Options: &generated.RunQueryJSONBody_Options{Skip: &skip}}
+	if _, err := c.RunQueryAllParallel(context.Background(), body, 2); err != nil {
+		t.Fatalf("RunQueryAllParallel() error = %v", err)
+	}
+	if *body.Options.Skip != 0 || body.Options.Top != nil {
+		t.Errorf("body options modified: skip=%d top=%v", *body.Options.Skip, body.Options.Top)
+	}
+}
+
+func TestQueryBuilder_RunParallel(t *testing.T) {
+	s := &parallelQueryServer{total: 25, pageSize: 10}
+	c := newHandlerClient(t, s)
+
+	records, err := c.Query("bqxyz123").Select(3).RunParallel(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("RunParallel() error = %v", err)
+	}
+	if len(records) != 25 || records[24]["3"] != float64(25) {
+		t.Errorf("RunParallel() returned %d records", len(records))
+	}
+}
@@ -1979,6 +1979,7 @@ func (r *RunFormulaResult) Result() string {
 // RunQueryResult wraps RunQueryResponse with convenience methods.
 type RunQueryResult struct {
 	resp *generated.RunQueryResponse
+	numbers NumberMode // How Records decodes numbers
 }
 
 // Raw returns the underlying generated response.
@@ -2019,13 +2020,14 @@ func (r *RunQueryResult) Records() []Record {
 	if r == nil || r.resp == nil || r.resp.JSON200 == nil || r.resp.JSON200.Data == nil {
 		return nil
 	}
-	return unwrapRecords(*r.resp.JSON200.Data)
+	return unwrapRecordsAs(*r.resp.JSON200.Data, r.numbers)
 }
 
 
 // RunReportResult wraps RunReportResponse with convenience methods.
 type RunReportResult struct {
 	resp *generated.RunReportResponse
+	numbers NumberMode // How Records decodes numbers
 }
 
 // Raw returns the underlying generated response.
@@ -2066,7 +2068,7 @@ func (r *RunReportResult) Records() []Record {
 	if r == nil || r.resp == nil || r.resp.JSON200 == nil || r.resp.JSON200.Data == nil {
 		return nil
 	}
-	return unwrapRecords(*r.resp.JSON200.Data)
+	return unwrapRecordsAs(*r.resp.JSON200.Data, r.numbers)
 }
 
 
@@ -2669,6 +2671,7 @@ func (r *UpdateTrusteesResult) Raw() *generated.UpdateTrusteesResponse {
 // UpsertResult wraps UpsertResponse with convenience methods.
 type UpsertResult struct {
 	resp *generated.UpsertResponse
+	numbers NumberMode // How Records decodes numbers
 }
 
 // Raw returns the underlying generated response.
@@ -2694,7 +2697,7 @@ func (r *UpsertResult) Records() []Record {
 	if r == nil || r.resp == nil || r.resp.JSON200 == nil || r.resp.JSON200.Data == nil {
 		return nil
 	}
-	return unwrapRecords(*r.resp.JSON200.Data)
+	return unwrapRecordsAs(*r.resp.JSON200.Data, r.numbers)
 }
 
 
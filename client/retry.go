@@ -0,0 +1,271 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy decides whether a failed request is retried and how long to
+// wait first.
+//
+// The client consults the policy after every network error, 429 and 5xx
+// response. Policies are shared by concurrent requests, so per-request state
+// (such as the previous delay) is passed in the RetryAttempt instead of being
+// stored on the policy.
+//
+// The SDK provides these implementations:
+//   - [ExponentialBackoff]: Exponential delays with ±10% jitter (default)
+//   - [DecorrelatedJitter]: Randomized delays that spread out retry storms
+//   - [NeverRetryWrites]: Wraps a policy so writes are never replayed
+//   - [NeverRetry]: Fails on the first error
+//
+// Set a policy for the client with WithRetryPolicy, for a single request with
+// a builder's RetryPolicy method, or for any call with ContextWithRetryPolicy.
+type RetryPolicy interface {
+	// Retry returns the delay before the next attempt and whether to retry.
+	Retry(attempt RetryAttempt) (time.Duration, bool)
+}
+
+// RetryPolicyFunc adapts a function to the RetryPolicy interface.
+type RetryPolicyFunc func(attempt RetryAttempt) (time.Duration, bool)
+
+// Retry calls f(attempt).
+func (f RetryPolicyFunc) Retry(attempt RetryAttempt) (time.Duration, bool) {
+	return f(attempt)
+}
+
+// RetryAttempt describes a failed attempt. It is passed to RetryPolicy.Retry.
+type RetryAttempt struct {
+	Method     string        // HTTP method
+	Path       string        // URL path
+	Attempt    int           // Attempt that just failed (1 = first try)
+	StatusCode int           // HTTP status code (0 for network errors)
+	Err        error         // Network error (nil if a response was received)
+	RetryAfter time.Duration // Parsed Retry-After header (0 if absent)
+	PrevDelay  time.Duration // Delay before this attempt (0 for the first try)
+	Write      bool          // Whether the request modifies data (see WithReadOnly)
+}
+
+// Retryable reports whether the failure is transient: a network error, a 429
+// or a 5xx response. Built-in policies only retry retryable failures. A
+// cassette replay miss is not retryable.
+func (a RetryAttempt) Retryable() bool {
+	if errors.Is(a.Err, ErrCassetteMiss) {
+		return false
+	}
+	return a.Err != nil || a.StatusCode == http.StatusTooManyRequests || a.StatusCode >= 500
+}
+
+// ExponentialBackoff retries transient failures with delays of
+// InitialDelay * Multiplier^(attempt-1), capped at MaxDelay, with ±10% jitter.
+// A Retry-After header on a 429 response takes precedence.
+//
+// This is the default policy, configured by WithMaxRetries, WithRetryDelay,
+// WithMaxRetryDelay and WithBackoffMultiplier. Zero fields use those defaults.
+type ExponentialBackoff struct {
+	MaxAttempts  int           // Total attempts including the first (default 3)
+	InitialDelay time.Duration // Delay before the first retry (default 1s)
+	MaxDelay     time.Duration // Maximum delay between retries (default 30s)
+	Multiplier   float64       // Delay growth per attempt (default 2)
+}
+
+// Retry implements RetryPolicy.
+func (p ExponentialBackoff) Retry(a RetryAttempt) (time.Duration, bool) {
+	if !a.Retryable() || a.Attempt >= p.attempts() {
+		return 0, false
+	}
+	if a.StatusCode == http.StatusTooManyRequests && a.RetryAfter > 0 {
+		return a.RetryAfter, true
+	}
+	return p.Backoff(a.Attempt), true
+}
+
+// attempts returns MaxAttempts or its default.
+func (p ExponentialBackoff) attempts() int {
+	return orDefault(p.MaxAttempts, 3)
+}
+
+// Backoff returns the jittered delay after the given failed attempt.
+func (p ExponentialBackoff) Backoff(attempt int) time.Duration {
+	initial := orDefault(p.InitialDelay, time.Second)
+	maxDelay := orDefault(p.MaxDelay, 30*time.Second)
+	mult := orDefault(p.Multiplier, 2)
+
+	delay := float64(initial) * math.Pow(mult, float64(attempt-1))
+
+	// Add jitter: ±10%
+	jitter := delay * 0.1 * (rand.Float64()*2 - 1)
+	delay += jitter
+
+	if delay > float64(maxDelay) {
+		delay = float64(maxDelay)
+	}
+
+	return time.Duration(delay)
+}
+
+// DecorrelatedJitter retries transient failures with randomized delays between
+// BaseDelay and three times the previous delay, capped at MaxDelay. Compared
+// to ExponentialBackoff, it spreads out retries from many concurrent clients,
+// which suits batch jobs that share a rate limit. A Retry-After header on a
+// 429 response takes precedence.
+type DecorrelatedJitter struct {
+	MaxAttempts int           // Total attempts including the first (default 5)
+	BaseDelay   time.Duration // Minimum delay (default 1s)
+	MaxDelay    time.Duration // Maximum delay (default 30s)
+}
+
+// Retry implements RetryPolicy.
+func (p DecorrelatedJitter) Retry(a RetryAttempt) (time.Duration, bool) {
+	if !a.Retryable() || a.Attempt >= orDefault(p.MaxAttempts, 5) {
+		return 0, false
+	}
+	if a.StatusCode == http.StatusTooManyRequests && a.RetryAfter > 0 {
+		return a.RetryAfter, true
+	}
+
+	base := orDefault(p.BaseDelay, time.Second)
+	maxDelay := orDefault(p.MaxDelay, 30*time.Second)
+	prev := max(a.PrevDelay, base)
+
+	delay := base + time.Duration(rand.Int63n(int64(3*prev-base)+1))
+	return min(delay, maxDelay), true
+}
+
+// NeverRetryWrites wraps a policy so that requests which modify data are not
+// retried after a network error or 5xx response, when the server may already
+// have applied them. 429 responses are still retried, since QuickBase rejects
+// those requests without processing them. Reads use the wrapped policy.
+//
+// Example:
+//
+//	quickbase.WithRetryPolicy(quickbase.NeverRetryWrites(quickbase.ExponentialBackoff{}))
+func NeverRetryWrites(policy RetryPolicy) RetryPolicy {
+	return RetryPolicyFunc(func(a RetryAttempt) (time.Duration, bool) {
+		if a.Write && a.StatusCode != http.StatusTooManyRequests {
+			return 0, false
+		}
+		return policy.Retry(a)
+	})
+}
+
+// NeverRetry is a policy that returns the first failure, for interactive
+// callers that prefer a fast error to a slow success.
+var NeverRetry RetryPolicy = RetryPolicyFunc(func(RetryAttempt) (time.Duration, bool) {
+	return 0, false
+})
+
+// WithRetryPolicy sets the policy that decides which failed requests are
+// retried and for how long to wait (default ExponentialBackoff). It replaces
+// WithMaxRetries, WithRetryDelay, WithMaxRetryDelay and WithBackoffMultiplier.
+//
+// Example:
+//
+//	quickbase.WithRetryPolicy(quickbase.DecorrelatedJitter{MaxAttempts: 8})
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+type retryPolicyKey struct{}
+
+// ContextWithRetryPolicy returns a context that overrides the client's retry
+// policy for requests made with it, including raw API() calls.
+func ContextWithRetryPolicy(ctx context.Context, policy RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyKey{}, policy)
+}
+
+// withRetryPolicy applies a builder's retry policy override, if any.
+func withRetryPolicy(ctx context.Context, policy RetryPolicy) context.Context {
+	if policy == nil {
+		return ctx
+	}
+	return ContextWithRetryPolicy(ctx, policy)
+}
+
+// retryPolicyFor returns the retry policy for a request: the context override,
+// the configured policy, or exponential backoff from the client's settings.
+func (c *Client) retryPolicyFor(ctx context.Context) RetryPolicy {
+	if policy, ok := ctx.Value(retryPolicyKey{}).(RetryPolicy); ok && policy != nil {
+		return policy
+	}
+	if c.retryPolicy != nil {
+		return c.retryPolicy
+	}
+	return c.defaultRetryPolicy()
+}
+
+// defaultRetryPolicy builds the exponential backoff policy from the client's
+// retry options.
+func (c *Client) defaultRetryPolicy() ExponentialBackoff {
+	return ExponentialBackoff{
+		MaxAttempts:  c.maxRetries,
+		InitialDelay: c.initialDelay,
+		MaxDelay:     c.maxDelay,
+		Multiplier:   c.backoffMult,
+	}
+}
+
+// maxAttempts returns the attempt limit of built-in policies, for logging.
+// It returns 0 for custom policies.
+func maxAttempts(policy RetryPolicy) int {
+	switch p := policy.(type) {
+	case ExponentialBackoff:
+		return p.attempts()
+	case DecorrelatedJitter:
+		return orDefault(p.MaxAttempts, 5)
+	}
+	return 0
+}
+
+// authAttempts returns the attempt limit for requests retried after a 401
+// token refresh: the policy's own limit, or the ExponentialBackoff default for
+// custom policies.
+func authAttempts(policy RetryPolicy) int {
+	if n := maxAttempts(policy); n > 0 {
+		return n
+	}
+	return ExponentialBackoff{}.attempts()
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(time.Until(t), 0)
+	}
+	return 0
+}
+
+// sleepContext waits for d or until ctx is done, returning ctx.Err() if cancelled.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func orDefault[T int | float64 | time.Duration](v, def T) T {
+	if v <= 0 {
+		return def
+	}
+	return v
+}
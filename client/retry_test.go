@@ -0,0 +1,201 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/auth"
+)
+
+func TestRetryPolicies(t *testing.T) {
+	netErr := errors.New("connection reset")
+	exp := ExponentialBackoff{MaxAttempts: 3, InitialDelay: 100 * time.Millisecond, MaxDelay: time.Second, Multiplier: 2}
+	jitter := DecorrelatedJitter{MaxAttempts: 4, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	tests := []struct {
+		name      string
+		policy    RetryPolicy
+		attempt   RetryAttempt
+		wantRetry bool
+		minDelay  time.Duration
+		maxDelay  time.Duration
+	}{
+		{"exponential 503", exp, RetryAttempt{Attempt: 1, StatusCode: 503}, true, 90 * time.Millisecond, 110 * time.Millisecond},
+		{"exponential second retry", exp, RetryAttempt{Attempt: 2, Err: netErr}, true, 180 * time.Millisecond, 220 * time.Millisecond},
+		{"exponential last attempt", exp, RetryAttempt{Attempt: 3, StatusCode: 503}, false, 0, 0},
+		{"exponential 400", exp, RetryAttempt{Attempt: 1, StatusCode: 400}, false, 0, 0},
+		{"exponential Retry-After", exp, RetryAttempt{Attempt: 1, StatusCode: 429, RetryAfter: 5 * time.Second}, true, 5 * time.Second, 5 * time.Second},
+		{"exponential defaults", ExponentialBackoff{}, RetryAttempt{Attempt: 2, StatusCode: 500}, true, 1800 * time.Millisecond, 2200 * time.Millisecond},
+		{"jitter first retry", jitter, RetryAttempt{Attempt: 1, StatusCode: 502}, true, 100 * time.Millisecond, 300 * time.Millisecond},
+		{"jitter grows from previous", jitter, RetryAttempt{Attempt: 2, StatusCode: 502, PrevDelay: 300 * time.Millisecond}, true, 100 * time.Millisecond, 900 * time.Millisecond},
+		{"jitter capped", jitter, RetryAttempt{Attempt: 3, Err: netErr, PrevDelay: time.Second}, true, 100 * time.Millisecond, time.Second},
+		{"jitter last attempt", jitter, RetryAttempt{Attempt: 4, StatusCode: 502}, false, 0, 0},
+		{"never retry writes blocks 5xx", NeverRetryWrites(exp), RetryAttempt{Attempt: 1, StatusCode: 503, Write: true}, false, 0, 0},
+		{"never retry writes blocks network", NeverRetryWrites(exp), RetryAttempt{Attempt: 1, Err: netErr, Write: true}, false, 0, 0},
+		{"never retry writes allows 429", NeverRetryWrites(exp), RetryAttempt{Attempt: 1, StatusCode: 429, RetryAfter: time.Second, Write: true}, true, time.Second, time.Second},
+		{"never retry writes allows reads", NeverRetryWrites(exp), RetryAttempt{Attempt: 1, StatusCode: 503}, true, 90 * time.Millisecond, 110 * time.Millisecond},
+		{"never retry", NeverRetry, RetryAttempt{Attempt: 1, StatusCode: 503}, false, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				delay, retry := tt.policy.Retry(tt.attempt)
+				if retry != tt.wantRetry {
+					t.Fatalf("Retry() retry = %v, want %v", retry, tt.wantRetry)
+				}
+				if retry && (delay < tt.minDelay || delay > tt.maxDelay) {
+					t.Fatalf("Retry() delay = %v, want between %v and %v", delay, tt.minDelay, tt.maxDelay)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if got := parseRetryAfter("7"); got != 7*time.Second {
+		t.Errorf("parseRetryAfter(7) = %v, want 7s", got)
+	}
+	if got := parseRetryAfter(""); got != 0 {
+		t.Errorf("parseRetryAfter('') = %v, want 0", got)
+	}
+	date := time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)
+	if got := parseRetryAfter(date); got <= 8*time.Second || got > 10*time.Second {
+		t.Errorf("parseRetryAfter(date) = %v, want about 10s", got)
+	}
+}
+
+// newRetryTestClient returns a client pointed at a server that fails with
+// status until fails requests have been served, then returns an empty app.
+func newRetryTestClient(t *testing.T, status, fails int, opts ...Option) (*Client, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if int(calls.Add(1)) <= fails {
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"bqapp123","name":"App"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	opts = append([]Option{WithBaseURL(srv.URL)}, opts...)
+	c, err := New("myrealm", auth.NewUserTokenStrategy("token"), opts...)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return c, &calls
+}
+
+func TestRetryPolicy_Client(t *testing.T) {
+	fast := ExponentialBackoff{MaxAttempts: 3, InitialDelay: time.Millisecond}
+
+	t.Run("retries server errors", func(t *testing.T) {
+		var retries []RetryInfo
+		c, calls := newRetryTestClient(t, http.StatusServiceUnavailable, 2,
+			WithRetryPolicy(fast),
+			WithOnRetry(func(info RetryInfo) { retries = append(retries, info) }))
+
+		if _, err := c.GetApp("bqapp123").Run(context.Background()); err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		if calls.Load() != 3 || len(retries) != 2 {
+			t.Errorf("calls = %d, retries = %d; want 3, 2", calls.Load(), len(retries))
+		}
+		if retries[0].Reason != "503" || retries[0].Attempt != 2 {
+			t.Errorf("retry info = %+v", retries[0])
+		}
+	})
+
+	t.Run("builder override", func(t *testing.T) {
+		c, calls := newRetryTestClient(t, http.StatusServiceUnavailable, 1, WithRetryPolicy(fast))
+
+		if _, err := c.GetApp("bqapp123").RetryPolicy(NeverRetry).Run(context.Background()); err == nil {
+			t.Fatal("expected error with NeverRetry")
+		}
+		if calls.Load() != 1 {
+			t.Errorf("calls = %d, want 1", calls.Load())
+		}
+	})
+
+	t.Run("QueryInto builder override", func(t *testing.T) {
+		c, calls := newRetryTestClient(t, http.StatusServiceUnavailable, 1, WithRetryPolicy(fast))
+
+		type row struct {
+			ID int `qb:"3"`
+		}
+		if _, err := QueryInto[row](context.Background(), c.Query("bqxyz").RetryPolicy(NeverRetry)); err == nil {
+			t.Fatal("expected error with NeverRetry")
+		}
+		if calls.Load() != 1 {
+			t.Errorf("calls = %d, want 1", calls.Load())
+		}
+	})
+
+	t.Run("401 refreshes bounded by policy", func(t *testing.T) {
+		// The legacy WithMaxRetries setting no longer bounds token refreshes
+		c, calls := newRetryTestClient(t, http.StatusUnauthorized, 100,
+			WithMaxRetries(10), WithRetryPolicy(ExponentialBackoff{MaxAttempts: 4}))
+
+		if _, err := c.GetApp("bqapp123").Run(context.Background()); err == nil {
+			t.Fatal("expected error for repeated 401s")
+		}
+		if calls.Load() != 3 {
+			t.Errorf("calls = %d, want 3", calls.Load())
+		}
+
+		calls.Store(0)
+		if _, err := c.GetApp("bqapp123").RetryPolicy(ExponentialBackoff{MaxAttempts: 1}).Run(context.Background()); err == nil {
+			t.Fatal("expected error for 401")
+		}
+		if calls.Load() != 1 {
+			t.Errorf("calls with builder override = %d, want 1", calls.Load())
+		}
+	})
+
+	t.Run("context override", func(t *testing.T) {
+		c, calls := newRetryTestClient(t, http.StatusBadGateway, 1, WithRetryPolicy(NeverRetry))
+
+		ctx := ContextWithRetryPolicy(context.Background(), fast)
+		if _, err := c.GetApp("bqapp123").Run(ctx); err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		if calls.Load() != 2 {
+			t.Errorf("calls = %d, want 2", calls.Load())
+		}
+	})
+
+	t.Run("never retry writes", func(t *testing.T) {
+		c, calls := newRetryTestClient(t, http.StatusInternalServerError, 1, WithRetryPolicy(NeverRetryWrites(fast)))
+
+		if _, err := c.DeleteApp("bqapp123").Name("App").Run(context.Background()); err == nil {
+			t.Fatal("expected error for write")
+		}
+		if calls.Load() != 1 {
+			t.Errorf("calls = %d, want 1", calls.Load())
+		}
+	})
+
+	t.Run("wait honors context cancellation", func(t *testing.T) {
+		slow := ExponentialBackoff{MaxAttempts: 3, InitialDelay: time.Minute}
+		c, _ := newRetryTestClient(t, http.StatusServiceUnavailable, 3, WithRetryPolicy(slow))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, err := c.GetApp("bqapp123").Run(ctx)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Run() error = %v, want context.DeadlineExceeded", err)
+		}
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("Run() took %v, want it to stop at the deadline", elapsed)
+		}
+	})
+}
@@ -0,0 +1,238 @@
+package client
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+)
+
+// Priority orders requests waiting for a concurrency slot or the throttle.
+// Higher priorities are served first; requests of equal priority are served
+// in arrival order.
+type Priority int
+
+const (
+	// PriorityLow is for background work such as backfills and exports.
+	PriorityLow Priority = -1
+
+	// PriorityNormal is the default priority.
+	PriorityNormal Priority = 0
+
+	// PriorityHigh is for interactive calls, such as those from web handlers.
+	PriorityHigh Priority = 1
+)
+
+// priorityKey is the context key for request priorities.
+type priorityKey struct{}
+
+// ContextWithPriority returns a context whose requests are scheduled with the
+// given priority. Every page of a paginated call uses the same priority.
+//
+// Example:
+//
+//	// In a web handler
+//	ctx := quickbase.ContextWithPriority(r.Context(), quickbase.PriorityHigh)
+//	app, err := qb.GetApp(appID).Run(ctx)
+//
+//	// In a backfill job
+//	ctx := quickbase.ContextWithPriority(context.Background(), quickbase.PriorityLow)
+//	records, err := qb.RunQueryAll(ctx, body)
+func ContextWithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+// PriorityFromContext returns the priority set by ContextWithPriority, or
+// PriorityNormal.
+func PriorityFromContext(ctx context.Context) Priority {
+	if p, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return p
+	}
+	return PriorityNormal
+}
+
+// WithMaxConcurrency limits the number of requests in flight at once. Each
+// attempt holds a slot from when it is sent until its response headers
+// arrive. Waiting requests are served by priority (see ContextWithPriority).
+// The default, 0, is unlimited.
+func WithMaxConcurrency(n int) Option {
+	return func(c *Client) {
+		c.maxConcurrency = n
+	}
+}
+
+// scheduler admits request attempts in priority order: first into a
+// concurrency slot, then through the throttle.
+type scheduler struct {
+	throttle Throttle
+	gate     *prioritySemaphore // Orders throttle attempts (nil for NoOpThrottle)
+	slots    *prioritySemaphore // Limits in-flight requests (nil if unlimited)
+}
+
+// throttlePollInterval is how often the scheduler rechecks a custom throttle
+// that has no remaining requests.
+const throttlePollInterval = 50 * time.Millisecond
+
+func newScheduler(throttle Throttle, maxConcurrency int) *scheduler {
+	s := &scheduler{throttle: throttle}
+	if _, ok := throttle.(*NoOpThrottle); !ok {
+		s.gate = newPrioritySemaphore(1)
+	}
+	if maxConcurrency > 0 {
+		s.slots = newPrioritySemaphore(maxConcurrency)
+	}
+	return s
+}
+
+// acquire waits for a concurrency slot and then the throttle, so a request
+// does not spend rate limit budget while it queues for a slot. The caller
+// must call release once the attempt's response headers arrive or it fails.
+func (s *scheduler) acquire(ctx context.Context) (release func(), err error) {
+	p := PriorityFromContext(ctx)
+
+	release = func() {}
+	if s.slots != nil {
+		if err := s.slots.acquire(ctx, p); err != nil {
+			return nil, err
+		}
+		var once sync.Once
+		release = func() { once.Do(s.slots.release) }
+	}
+
+	if err := s.waitThrottle(ctx, p); err != nil {
+		release()
+		return nil, err
+	}
+	return release, nil
+}
+
+// waitThrottle takes a throttle slot. Requests try the throttle one at a time
+// in priority order, but none holds the queue while it sleeps: after each
+// wait it queues again, so a higher-priority request that arrives meanwhile
+// goes first.
+func (s *scheduler) waitThrottle(ctx context.Context, p Priority) error {
+	if s.gate == nil {
+		return s.throttle.Acquire(ctx)
+	}
+	for {
+		if err := s.gate.acquire(ctx, p); err != nil {
+			return err
+		}
+		wait, err := s.tryThrottle(ctx)
+		s.gate.release()
+		if err != nil || wait <= 0 {
+			return err
+		}
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// tryThrottle takes a throttle slot if one is free, or returns how long to
+// wait. Custom throttles are polled through GetRemaining.
+func (s *scheduler) tryThrottle(ctx context.Context) (time.Duration, error) {
+	if r, ok := s.throttle.(slotReserver); ok {
+		return r.tryAcquire()
+	}
+	if s.throttle.GetRemaining() > 0 {
+		return 0, s.throttle.Acquire(ctx)
+	}
+	return throttlePollInterval, nil
+}
+
+// prioritySemaphore is a counting semaphore whose waiters are served by
+// priority, then arrival order.
+type prioritySemaphore struct {
+	mu      sync.Mutex
+	limit   int
+	active  int
+	seq     uint64
+	waiters waiterHeap
+}
+
+type semWaiter struct {
+	priority Priority
+	seq      uint64
+	ready    chan struct{}
+	index    int // Position in the heap, -1 once granted or removed
+}
+
+func newPrioritySemaphore(limit int) *prioritySemaphore {
+	return &prioritySemaphore{limit: limit}
+}
+
+// acquire takes a slot, waiting behind higher-priority and earlier waiters.
+func (s *prioritySemaphore) acquire(ctx context.Context, p Priority) error {
+	s.mu.Lock()
+	if s.active < s.limit && len(s.waiters) == 0 {
+		s.active++
+		s.mu.Unlock()
+		return nil
+	}
+	w := &semWaiter{priority: p, seq: s.seq, ready: make(chan struct{})}
+	s.seq++
+	heap.Push(&s.waiters, w)
+	s.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		if w.index < 0 {
+			// Granted while cancelling: pass the slot on
+			s.mu.Unlock()
+			s.release()
+			return ctx.Err()
+		}
+		heap.Remove(&s.waiters, w.index)
+		s.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// release frees a slot, handing it to the highest-priority waiter.
+func (s *prioritySemaphore) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.waiters) > 0 {
+		w := heap.Pop(&s.waiters).(*semWaiter)
+		close(w.ready)
+		return
+	}
+	s.active--
+}
+
+// waiterHeap orders waiters by priority (highest first), then arrival.
+type waiterHeap []*semWaiter
+
+func (h waiterHeap) Len() int { return len(h) }
+
+func (h waiterHeap) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority > h[j].priority
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h waiterHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *waiterHeap) Push(x any) {
+	w := x.(*semWaiter)
+	w.index = len(*h)
+	*h = append(*h, w)
+}
+
+func (h *waiterHeap) Pop() any {
+	old := *h
+	w := old[len(old)-1]
+	old[len(old)-1] = nil
+	w.index = -1
+	*h = old[:len(old)-1]
+	return w
+}
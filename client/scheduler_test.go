@@ -0,0 +1,320 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/auth"
+)
+
+func TestPriorityFromContext(t *testing.T) {
+	ctx := context.Background()
+	if got := PriorityFromContext(ctx); got != PriorityNormal {
+		t.Errorf("default = %d, want PriorityNormal", got)
+	}
+	if got := PriorityFromContext(ContextWithPriority(ctx, PriorityHigh)); got != PriorityHigh {
+		t.Errorf("got %d, want PriorityHigh", got)
+	}
+}
+
+func TestPrioritySemaphore_Order(t *testing.T) {
+	sem := newPrioritySemaphore(1)
+	ctx := context.Background()
+	if err := sem.acquire(ctx, PriorityNormal); err != nil {
+		t.Fatal(err)
+	}
+
+	// Queue waiters one at a time so arrival order is deterministic
+	var mu sync.Mutex
+	var order []string
+	var wg sync.WaitGroup
+	waiters := []struct {
+		name     string
+		priority Priority
+	}{
+		{"low1", PriorityLow},
+		{"normal", PriorityNormal},
+		{"low2", PriorityLow},
+		{"high", PriorityHigh},
+	}
+	for i, w := range waiters {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem.acquire(ctx, w.priority)
+			mu.Lock()
+			order = append(order, w.name)
+			mu.Unlock()
+			sem.release()
+		}()
+		waitForWaiters(t, sem, i+1)
+	}
+
+	sem.release()
+	wg.Wait()
+
+	want := []string{"high", "normal", "low1", "low2"}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("order = %v, want %v", order, want)
+		}
+	}
+}
+
+func TestPrioritySemaphore_Cancel(t *testing.T) {
+	sem := newPrioritySemaphore(1)
+	sem.acquire(context.Background(), PriorityNormal)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() { errCh <- sem.acquire(ctx, PriorityHigh) }()
+	waitForWaiters(t, sem, 1)
+	cancel()
+
+	if err := <-errCh; !errors.Is(err, context.Canceled) {
+		t.Errorf("acquire() error = %v, want context.Canceled", err)
+	}
+	sem.release()
+
+	// The cancelled waiter must not hold the slot
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := sem.acquire(ctx, PriorityNormal); err != nil {
+		t.Errorf("acquire() after cancel error = %v", err)
+	}
+}
+
+func TestWithMaxConcurrency(t *testing.T) {
+	var tracker concurrencyTracker
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		leave := tracker.enter()
+		time.Sleep(20 * time.Millisecond)
+		leave()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"bqapp123","name":"App"}`))
+	}))
+	defer srv.Close()
+
+	c, err := New("myrealm", auth.NewUserTokenStrategy("token"),
+		WithBaseURL(srv.URL),
+		WithMaxConcurrency(2),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.GetApp("bqapp123").Run(context.Background()); err != nil {
+				t.Errorf("GetApp() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := tracker.peak(); got != 2 {
+		t.Errorf("peak in-flight = %d, want 2", got)
+	}
+}
+
+func TestWithMaxConcurrency_Priority(t *testing.T) {
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var order []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		caller := r.Header.Get("X-Caller")
+		if caller == "blocker" {
+			<-release
+		}
+		mu.Lock()
+		order = append(order, caller)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"bqapp123","name":"App"}`))
+	}))
+	defer srv.Close()
+
+	type callerKey struct{}
+	c, err := New("myrealm", auth.NewUserTokenStrategy("token"),
+		WithBaseURL(srv.URL),
+		WithMaxConcurrency(1),
+		WithMiddleware(func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				req.Header.Set("X-Caller", req.Context().Value(callerKey{}).(string))
+				return next.Do(req)
+			})
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	var wg sync.WaitGroup
+	run := func(name string, p Priority) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := context.WithValue(ContextWithPriority(context.Background(), p), callerKey{}, name)
+			if _, err := c.GetApp("bqapp123").Run(ctx); err != nil {
+				t.Errorf("%s: GetApp() error = %v", name, err)
+			}
+		}()
+	}
+
+	// The blocker holds the only slot while the others queue
+	run("blocker", PriorityNormal)
+	waitForActive(t, c.scheduler.slots, 1)
+	run("backfill1", PriorityLow)
+	waitForWaiters(t, c.scheduler.slots, 1)
+	run("backfill2", PriorityLow)
+	waitForWaiters(t, c.scheduler.slots, 2)
+	run("interactive", PriorityHigh)
+	waitForWaiters(t, c.scheduler.slots, 3)
+
+	close(release)
+	wg.Wait()
+
+	want := []string{"blocker", "interactive", "backfill1", "backfill2"}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("order = %v, want %v", order, want)
+		}
+	}
+}
+
+// stepThrottle is a throttle whose slots are handed out by the test. When none
+// are free it asks callers to wait an hour.
+type stepThrottle struct {
+	NoOpThrottle
+	mu    sync.Mutex
+	free  int
+	taken int
+	tries int
+}
+
+func (t *stepThrottle) Acquire(ctx context.Context) error {
+	return acquireSlot(ctx, t.tryAcquire)
+}
+
+func (t *stepThrottle) tryAcquire() (time.Duration, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tries++
+	if t.free == 0 {
+		return time.Hour, nil
+	}
+	t.free--
+	t.taken++
+	return 0, nil
+}
+
+func (t *stepThrottle) add(n int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.free += n
+}
+
+func (t *stepThrottle) counts() (taken, tries int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.taken, t.tries
+}
+
+func TestScheduler_ThrottleWaitDoesNotBlockHigherPriority(t *testing.T) {
+	throttle := &stepThrottle{}
+	s := newScheduler(throttle, 0)
+
+	// A low-priority request finds the throttle full and sleeps
+	lowCtx, cancelLow := context.WithCancel(ContextWithPriority(context.Background(), PriorityLow))
+	lowErr := make(chan error, 1)
+	go func() {
+		_, err := s.acquire(lowCtx)
+		lowErr <- err
+	}()
+	waitFor(t, func() bool { _, tries := throttle.counts(); return tries == 1 })
+
+	// A high-priority request arriving later is not stuck behind it
+	throttle.add(1)
+	ctx, cancel := context.WithTimeout(ContextWithPriority(context.Background(), PriorityHigh), time.Second)
+	defer cancel()
+	release, err := s.acquire(ctx)
+	if err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+	release()
+
+	cancelLow()
+	if err := <-lowErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("low acquire() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestScheduler_SlotBeforeThrottle(t *testing.T) {
+	throttle := &stepThrottle{free: 10}
+	s := newScheduler(throttle, 1)
+	ctx := context.Background()
+
+	release, err := s.acquire(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A request queued for the slot has not spent throttle budget
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if r, err := s.acquire(ctx); err == nil {
+			r()
+		}
+	}()
+	waitForWaiters(t, s.slots, 1)
+	if taken, _ := throttle.counts(); taken != 1 {
+		t.Errorf("throttle slots taken = %d, want 1", taken)
+	}
+
+	release()
+	<-done
+	if taken, _ := throttle.counts(); taken != 2 {
+		t.Errorf("throttle slots taken = %d, want 2", taken)
+	}
+}
+
+// waitForWaiters waits until sem has n queued waiters.
+func waitForWaiters(t *testing.T, sem *prioritySemaphore, n int) {
+	t.Helper()
+	waitFor(t, func() bool {
+		sem.mu.Lock()
+		defer sem.mu.Unlock()
+		return len(sem.waiters) == n
+	})
+}
+
+// waitForActive waits until sem has n slots taken.
+func waitForActive(t *testing.T, sem *prioritySemaphore, n int) {
+	t.Helper()
+	waitFor(t, func() bool {
+		sem.mu.Lock()
+		defer sem.mu.Unlock()
+		return sem.active == n
+	})
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+		if cond() {
+			return
+		}
+	}
+	t.Fatal("timed out waiting for condition")
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"sync"
 	"time"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/core"
 )
 
 // Throttle is the interface for rate limiting strategies.
@@ -11,9 +13,18 @@ import (
 // QuickBase enforces a rate limit of 100 requests per 10 seconds per user token.
 // Implementing this interface allows custom throttling behavior.
 //
-// The SDK provides two built-in implementations:
+// The SDK provides three built-in implementations:
 //   - [SlidingWindowThrottle]: Proactive throttling to avoid hitting rate limits
+//   - [AIMDThrottle]: Adaptive throttling that learns the rate from 429 responses
 //   - [NoOpThrottle]: No throttling (default, relies on server-side 429 handling)
+//
+// Throttles that also implement [ThrottleFeedback] are told about every
+// response, so they can adapt their rate.
+//
+// Requests wait for the throttle in priority order (see ContextWithPriority).
+// While a custom throttle reports no remaining requests, the client rechecks
+// GetRemaining periodically instead of blocking in Acquire, so later
+// higher-priority requests are not held up.
 type Throttle interface {
 	// Acquire blocks until a request slot is available.
 	// Returns an error if the context is cancelled while waiting.
@@ -29,6 +40,17 @@ type Throttle interface {
 	Reset()
 }
 
+// ThrottleFeedback is an optional interface for throttles that adapt to
+// server responses. The client calls OnRateLimit for every 429 response, and
+// OnSuccess for every other response below 500.
+type ThrottleFeedback interface {
+	// OnRateLimit is called when a request is rate limited.
+	OnRateLimit(info core.RateLimitInfo)
+
+	// OnSuccess is called when a request is accepted.
+	OnSuccess()
+}
+
 // SlidingWindowThrottle implements proactive rate limiting using a sliding window algorithm.
 //
 // This throttle tracks request timestamps and blocks new requests when the limit
@@ -67,43 +89,34 @@ func NewSlidingWindowThrottle(requestsPer10Seconds int) *SlidingWindowThrottle {
 
 // Acquire waits until a request slot is available.
 func (t *SlidingWindowThrottle) Acquire(ctx context.Context) error {
-	for {
-		t.mu.Lock()
-		now := time.Now()
-		windowStart := now.Add(-10 * time.Second)
-
-		// Remove timestamps outside the window
-		newTimestamps := t.timestamps[:0]
-		for _, ts := range t.timestamps {
-			if ts.After(windowStart) {
-				newTimestamps = append(newTimestamps, ts)
-			}
-		}
-		t.timestamps = newTimestamps
-
-		// If under the limit, record this request and return
-		if len(t.timestamps) < t.requestsPer10Seconds {
-			t.timestamps = append(t.timestamps, now)
-			t.mu.Unlock()
-			return nil
-		}
+	return acquireSlot(ctx, t.tryAcquire)
+}
 
-		// Calculate wait time until oldest request exits the window
-		oldestTimestamp := t.timestamps[0]
-		waitTime := oldestTimestamp.Add(10 * time.Second).Sub(now)
-		t.mu.Unlock()
+// tryAcquire takes a slot if one is free, or returns how long until the
+// oldest request leaves the window.
+func (t *SlidingWindowThrottle) tryAcquire() (time.Duration, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now()
+	windowStart := now.Add(-10 * time.Second)
 
-		if waitTime <= 0 {
-			continue // Recheck immediately
+	// Remove timestamps outside the window
+	newTimestamps := t.timestamps[:0]
+	for _, ts := range t.timestamps {
+		if ts.After(windowStart) {
+			newTimestamps = append(newTimestamps, ts)
 		}
+	}
+	t.timestamps = newTimestamps
 
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-time.After(waitTime):
-			// Continue to recheck
-		}
+	// If under the limit, record this request
+	if len(t.timestamps) < t.requestsPer10Seconds {
+		t.timestamps = append(t.timestamps, now)
+		return 0, nil
 	}
+
+	// Wait until the oldest request exits the window
+	return t.timestamps[0].Add(10 * time.Second).Sub(now), nil
 }
 
 // GetWindowCount returns the number of requests in the current 10-second window.
@@ -133,6 +146,29 @@ func (t *SlidingWindowThrottle) Reset() {
 	t.timestamps = t.timestamps[:0]
 }
 
+// slotReserver is implemented by throttles that can take a slot without
+// blocking. The client's scheduler uses it to wait for the throttle without
+// holding up requests of higher priority.
+type slotReserver interface {
+	// tryAcquire takes a slot and returns 0 if one is free, or returns how
+	// long to wait before trying again.
+	tryAcquire() (time.Duration, error)
+}
+
+// acquireSlot calls try until it takes a slot, sleeping for the wait it
+// reports in between.
+func acquireSlot(ctx context.Context, try func() (time.Duration, error)) error {
+	for {
+		wait, err := try()
+		if err != nil || wait <= 0 {
+			return err
+		}
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
 // NoOpThrottle is a throttle that does nothing.
 //
 // This is the default throttle used when proactive throttling is not enabled.
@@ -161,3 +197,167 @@ func (t *NoOpThrottle) GetRemaining() int {
 
 // Reset does nothing.
 func (t *NoOpThrottle) Reset() {}
+
+// AIMDThrottle adapts its rate to 429 feedback using additive increase,
+// multiplicative decrease (AIMD), like TCP congestion control.
+//
+// It limits requests with a 10-second sliding window, like
+// [SlidingWindowThrottle], but the limit moves: each 429 multiplies it by
+// DecreaseFactor and pauses all requests for the Retry-After delay, and each
+// successful request raises it so that it grows by Increase per window of
+// successes. This suits jobs that share a user token without knowing each
+// other's share of the budget.
+//
+// Example:
+//
+//	client, _ := quickbase.New(realm,
+//	    quickbase.WithUserToken(token),
+//	    quickbase.WithThrottle(quickbase.NewAIMDThrottle(quickbase.AIMDThrottleOptions{})),
+//	)
+type AIMDThrottle struct {
+	mu           sync.Mutex
+	opts         AIMDThrottleOptions
+	rate         float64
+	timestamps   []time.Time
+	pausedUntil  time.Time
+	lastDecrease time.Time
+}
+
+// AIMDThrottleOptions configures an AIMDThrottle. Rates are in requests per
+// 10 seconds, matching QuickBase's rate limit.
+type AIMDThrottleOptions struct {
+	InitialRate    float64 // Starting rate (default MaxRate)
+	MinRate        float64 // Lowest rate after decreases (default 5)
+	MaxRate        float64 // Highest rate after increases (default 100)
+	Increase       float64 // Rate added per window of successful requests (default 5)
+	DecreaseFactor float64 // Rate multiplier on a 429, between 0 and 1 (default 0.5)
+}
+
+// aimdDecreaseCooldown stops a burst of 429s from in-flight requests from
+// cutting the rate more than once.
+const aimdDecreaseCooldown = time.Second
+
+// NewAIMDThrottle creates an adaptive throttle. Zero options take their defaults.
+func NewAIMDThrottle(opts AIMDThrottleOptions) *AIMDThrottle {
+	if opts.MaxRate <= 0 {
+		opts.MaxRate = 100
+	}
+	if opts.MinRate <= 0 {
+		opts.MinRate = min(5, opts.MaxRate)
+	}
+	if opts.InitialRate <= 0 {
+		opts.InitialRate = opts.MaxRate
+	}
+	opts.InitialRate = min(max(opts.InitialRate, opts.MinRate), opts.MaxRate)
+	if opts.Increase <= 0 {
+		opts.Increase = 5
+	}
+	if opts.DecreaseFactor <= 0 || opts.DecreaseFactor >= 1 {
+		opts.DecreaseFactor = 0.5
+	}
+	return &AIMDThrottle{
+		opts: opts,
+		rate: opts.InitialRate,
+	}
+}
+
+// Acquire waits until a request slot is available under the current rate and
+// any Retry-After pause has passed.
+func (t *AIMDThrottle) Acquire(ctx context.Context) error {
+	return acquireSlot(ctx, t.tryAcquire)
+}
+
+// tryAcquire takes a slot if one is free, or returns how long to wait.
+func (t *AIMDThrottle) tryAcquire() (time.Duration, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now()
+	t.prune(now)
+
+	switch {
+	case now.Before(t.pausedUntil):
+		return t.pausedUntil.Sub(now), nil
+	case len(t.timestamps) < t.limit():
+		t.timestamps = append(t.timestamps, now)
+		return 0, nil
+	}
+	// Wait until enough requests exit the window to get under the limit
+	return t.timestamps[len(t.timestamps)-t.limit()].Add(10 * time.Second).Sub(now), nil
+}
+
+// OnRateLimit cuts the rate by DecreaseFactor and pauses requests for the
+// Retry-After delay.
+func (t *AIMDThrottle) OnRateLimit(info core.RateLimitInfo) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	if info.RetryAfter > 0 {
+		if until := now.Add(time.Duration(info.RetryAfter) * time.Second); until.After(t.pausedUntil) {
+			t.pausedUntil = until
+		}
+	}
+	if now.Sub(t.lastDecrease) < aimdDecreaseCooldown {
+		return
+	}
+	t.lastDecrease = now
+	t.rate = max(t.rate*t.opts.DecreaseFactor, t.opts.MinRate)
+}
+
+// OnSuccess raises the rate by Increase spread over one window of requests.
+func (t *AIMDThrottle) OnSuccess() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rate = min(t.rate+t.opts.Increase/t.rate, t.opts.MaxRate)
+}
+
+// Rate returns the current rate in requests per 10 seconds.
+func (t *AIMDThrottle) Rate() float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.rate
+}
+
+// GetWindowCount returns the number of requests in the current 10-second window.
+func (t *AIMDThrottle) GetWindowCount() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.prune(time.Now())
+	return len(t.timestamps)
+}
+
+// GetRemaining returns remaining requests available in the current window at
+// the current rate.
+func (t *AIMDThrottle) GetRemaining() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.prune(time.Now())
+	return max(0, t.limit()-len(t.timestamps))
+}
+
+// Reset clears the window and restores the initial rate.
+func (t *AIMDThrottle) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.timestamps = t.timestamps[:0]
+	t.rate = t.opts.InitialRate
+	t.pausedUntil = time.Time{}
+	t.lastDecrease = time.Time{}
+}
+
+// limit returns the current rate as a whole number of requests, at least 1.
+func (t *AIMDThrottle) limit() int {
+	return max(1, int(t.rate))
+}
+
+// prune removes timestamps outside the 10-second window.
+func (t *AIMDThrottle) prune(now time.Time) {
+	windowStart := now.Add(-10 * time.Second)
+	kept := t.timestamps[:0]
+	for _, ts := range t.timestamps {
+		if ts.After(windowStart) {
+			kept = append(kept, ts)
+		}
+	}
+	t.timestamps = kept
+}
@@ -0,0 +1,133 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileThrottle is a sliding window throttle whose window is stored in a file
+// and guarded by an exclusive file lock, so processes on one host that share
+// credentials also share the rate limit. Use it through
+// [NewFileThrottleRegistry], or directly with WithThrottle.
+//
+// File locking is supported on Unix systems. On other platforms Acquire
+// returns an error.
+type FileThrottle struct {
+	mu                   sync.Mutex
+	path                 string
+	requestsPer10Seconds int
+}
+
+// NewFileThrottle creates a file-backed throttle. The file is created on first
+// use. If requestsPer10Seconds is <= 0, it defaults to 100.
+func NewFileThrottle(path string, requestsPer10Seconds int) *FileThrottle {
+	if requestsPer10Seconds <= 0 {
+		requestsPer10Seconds = 100
+	}
+	return &FileThrottle{
+		path:                 path,
+		requestsPer10Seconds: requestsPer10Seconds,
+	}
+}
+
+// Acquire waits until a request slot is available in the shared window.
+func (t *FileThrottle) Acquire(ctx context.Context) error {
+	return acquireSlot(ctx, t.tryAcquire)
+}
+
+// tryAcquire takes a slot in the shared window if one is free, or returns how
+// long to wait.
+func (t *FileThrottle) tryAcquire() (time.Duration, error) {
+	var waitTime time.Duration
+	err := t.update(func(timestamps []time.Time, now time.Time) []time.Time {
+		if len(timestamps) < t.requestsPer10Seconds {
+			return append(timestamps, now)
+		}
+		waitTime = timestamps[len(timestamps)-t.requestsPer10Seconds].Add(10 * time.Second).Sub(now)
+		return timestamps
+	})
+	return waitTime, err
+}
+
+// GetWindowCount returns the number of requests in the shared window, or 0 if
+// the file cannot be read.
+func (t *FileThrottle) GetWindowCount() int {
+	count := 0
+	t.update(func(timestamps []time.Time, now time.Time) []time.Time {
+		count = len(timestamps)
+		return timestamps
+	})
+	return count
+}
+
+// GetRemaining returns remaining requests available in the shared window.
+func (t *FileThrottle) GetRemaining() int {
+	return max(0, t.requestsPer10Seconds-t.GetWindowCount())
+}
+
+// Reset clears the shared window.
+func (t *FileThrottle) Reset() {
+	t.update(func(timestamps []time.Time, now time.Time) []time.Time {
+		return nil
+	})
+}
+
+// update locks the file, passes fn the timestamps in the current window, and
+// writes back the ones it returns.
+func (t *FileThrottle) update(fn func(timestamps []time.Time, now time.Time) []time.Time) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	f, err := os.OpenFile(t.path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("opening throttle file: %w", err)
+	}
+	defer f.Close()
+
+	if err := lockFile(f); err != nil {
+		return fmt.Errorf("locking throttle file: %w", err)
+	}
+	defer unlockFile(f)
+
+	now := time.Now()
+	windowStart := now.Add(-10 * time.Second)
+	var timestamps []time.Time
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		ns, err := strconv.ParseInt(strings.TrimSpace(scanner.Text()), 10, 64)
+		if err != nil {
+			continue // Skip corrupt lines
+		}
+		if ts := time.Unix(0, ns); ts.After(windowStart) {
+			timestamps = append(timestamps, ts)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading throttle file: %w", err)
+	}
+
+	timestamps = fn(timestamps, now)
+
+	var b strings.Builder
+	for _, ts := range timestamps {
+		b.WriteString(strconv.FormatInt(ts.UnixNano(), 10))
+		b.WriteByte('\n')
+	}
+	if err := f.Truncate(0); err != nil {
+		return fmt.Errorf("writing throttle file: %w", err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("writing throttle file: %w", err)
+	}
+	if _, err := f.WriteString(b.String()); err != nil {
+		return fmt.Errorf("writing throttle file: %w", err)
+	}
+	return nil
+}
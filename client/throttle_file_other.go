@@ -0,0 +1,19 @@
+//go:build !unix
+
+package client
+
+import (
+	"errors"
+	"os"
+)
+
+// errFileLockUnsupported is returned by FileThrottle on platforms without flock.
+var errFileLockUnsupported = errors.New("file locking is not supported on this platform")
+
+func lockFile(f *os.File) error {
+	return errFileLockUnsupported
+}
+
+func unlockFile(f *os.File) error {
+	return errFileLockUnsupported
+}
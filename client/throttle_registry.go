@@ -0,0 +1,168 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/auth"
+)
+
+// ThrottleRegistry shares throttles between clients that use the same
+// credentials. QuickBase rate-limits per user token, so clients in one process
+// that share a token must also share one window to stay within the budget.
+//
+// WithProactiveThrottle looks up its throttle in [DefaultThrottleRegistry],
+// keyed by realm and the identity reported by the auth strategy (see
+// auth.Identifier). Clients with the same credentials and rate get the same
+// throttle; a client that asks for a different rate than the registered one
+// gets a throttle of its own. Entries are reference-counted: Client.Close, or
+// garbage collection of a client that was never closed, releases the client's
+// entry, and the throttle is dropped once no client uses it.
+type ThrottleRegistry struct {
+	mu          sync.Mutex
+	throttles   map[string]*registryEntry
+	newThrottle func(key string, requestsPer10Seconds int) Throttle
+}
+
+// registryEntry is a registered throttle and the number of clients using it.
+type registryEntry struct {
+	throttle Throttle
+	rate     int
+	refs     int
+}
+
+// DefaultThrottleRegistry is the process-wide registry used by
+// WithProactiveThrottle unless WithThrottleRegistry overrides it.
+var DefaultThrottleRegistry = NewThrottleRegistry()
+
+// NewThrottleRegistry creates a registry of in-process sliding window throttles.
+func NewThrottleRegistry() *ThrottleRegistry {
+	return NewThrottleRegistryWithFactory(func(key string, requestsPer10Seconds int) Throttle {
+		return NewSlidingWindowThrottle(requestsPer10Seconds)
+	})
+}
+
+// NewThrottleRegistryWithFactory creates a registry that builds throttles with
+// newThrottle. The key is a hex string, safe to use in file names.
+func NewThrottleRegistryWithFactory(newThrottle func(key string, requestsPer10Seconds int) Throttle) *ThrottleRegistry {
+	return &ThrottleRegistry{
+		throttles:   make(map[string]*registryEntry),
+		newThrottle: newThrottle,
+	}
+}
+
+// NewFileThrottleRegistry creates a registry of [FileThrottle]s stored in dir,
+// so that processes on one host share a window per set of credentials.
+//
+// Example:
+//
+//	registry := quickbase.NewFileThrottleRegistry(os.TempDir())
+//	client, _ := quickbase.New(realm,
+//	    quickbase.WithUserToken(token),
+//	    quickbase.WithProactiveThrottle(100),
+//	    quickbase.WithThrottleRegistry(registry),
+//	)
+func NewFileThrottleRegistry(dir string) *ThrottleRegistry {
+	return NewThrottleRegistryWithFactory(func(key string, requestsPer10Seconds int) Throttle {
+		return NewFileThrottle(filepath.Join(dir, "quickbase-"+key+".throttle"), requestsPer10Seconds)
+	})
+}
+
+// Throttle returns the throttle registered under key, creating it with the
+// given rate if needed, and takes a reference to it. Call Release when done
+// with it. It returns false, and takes no reference, if key is registered
+// with a different rate.
+func (r *ThrottleRegistry) Throttle(key string, requestsPer10Seconds int) (Throttle, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if e, ok := r.throttles[key]; ok {
+		if e.rate != requestsPer10Seconds {
+			return nil, false
+		}
+		e.refs++
+		return e.throttle, true
+	}
+	e := &registryEntry{throttle: r.newThrottle(key, requestsPer10Seconds), rate: requestsPer10Seconds, refs: 1}
+	r.throttles[key] = e
+	return e.throttle, true
+}
+
+// Release drops a reference taken by Throttle. The throttle is removed once
+// no references remain.
+func (r *ThrottleRegistry) Release(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, ok := r.throttles[key]
+	if !ok {
+		return
+	}
+	if e.refs--; e.refs <= 0 {
+		delete(r.throttles, key)
+	}
+}
+
+// Len returns the number of registered throttles.
+func (r *ThrottleRegistry) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.throttles)
+}
+
+// WithThrottleRegistry sets the registry WithProactiveThrottle shares its
+// throttle through (default DefaultThrottleRegistry). Pass nil to give the
+// client a throttle of its own.
+func WithThrottleRegistry(r *ThrottleRegistry) Option {
+	return func(c *Client) {
+		c.throttleRegistry = r
+	}
+}
+
+// throttleKey returns the registry key for a realm and auth strategy. It
+// returns false if the strategy does not implement auth.Identifier.
+func throttleKey(realm string, strategy auth.Strategy) (string, bool) {
+	identifier, ok := strategy.(auth.Identifier)
+	if !ok {
+		return "", false
+	}
+	sum := sha256.Sum256([]byte(realm + "\x00" + identifier.Identity()))
+	return hex.EncodeToString(sum[:16]), true
+}
+
+// proactiveThrottle returns the throttle for WithProactiveThrottle, shared
+// through the registry when the credentials have an identity and the rate
+// matches. A shared entry is released by Close, or when the client is garbage
+// collected without being closed.
+func (c *Client) proactiveThrottle() Throttle {
+	if c.throttleRegistry != nil {
+		if key, ok := throttleKey(c.realm, c.auth); ok {
+			if t, ok := c.throttleRegistry.Throttle(key, c.throttleRate); ok {
+				c.throttleKey = key
+				c.throttleCleanup = runtime.AddCleanup(c, func(ref registryRef) {
+					ref.registry.Release(ref.key)
+				}, registryRef{c.throttleRegistry, key})
+				return t
+			}
+		}
+	}
+	return NewSlidingWindowThrottle(c.throttleRate)
+}
+
+// registryRef identifies a registry entry for the cleanup that releases it.
+type registryRef struct {
+	registry *ThrottleRegistry
+	key      string
+}
+
+// releaseThrottle releases the client's registry entry, once.
+func (c *Client) releaseThrottle() {
+	if c.throttleKey != "" {
+		c.throttleCleanup.Stop()
+		c.throttleRegistry.Release(c.throttleKey)
+		c.throttleKey = ""
+	}
+}
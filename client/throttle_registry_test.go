@@ -0,0 +1,187 @@
+package client
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/auth"
+)
+
+func TestThrottleRegistry_SharesByCredentials(t *testing.T) {
+	registry := NewThrottleRegistry()
+	newClient := func(strategy auth.Strategy, realm string, opts ...Option) *Client {
+		t.Helper()
+		opts = append([]Option{WithProactiveThrottle(50), WithThrottleRegistry(registry)}, opts...)
+		c, err := New(realm, strategy, opts...)
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		return c
+	}
+
+	a := newClient(auth.NewUserTokenStrategy("token-a"), "myrealm")
+	a2 := newClient(auth.NewUserTokenStrategy("token-a"), "myrealm")
+	b := newClient(auth.NewUserTokenStrategy("token-b"), "myrealm")
+	other := newClient(auth.NewUserTokenStrategy("token-a"), "otherrealm")
+	temp1 := newClient(auth.NewTempTokenStrategy("myrealm"), "myrealm")
+	temp2 := newClient(auth.NewTempTokenStrategy("myrealm"), "myrealm")
+	unshared := newClient(auth.NewUserTokenStrategy("token-a"), "myrealm", WithThrottleRegistry(nil))
+
+	if a.throttle != a2.throttle {
+		t.Error("clients with the same token should share a throttle")
+	}
+	for name, c := range map[string]*Client{"other token": b, "other realm": other, "no identity": temp1, "nil registry": unshared} {
+		if c.throttle == a.throttle {
+			t.Errorf("%s: should not share a's throttle", name)
+		}
+	}
+	if temp1.throttle == temp2.throttle {
+		t.Error("strategies without an identity should not share throttles")
+	}
+	if registry.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", registry.Len())
+	}
+
+	// The shared window is consumed by both clients
+	ctx := context.Background()
+	a.throttle.Acquire(ctx)
+	a2.throttle.Acquire(ctx)
+	if a.throttle.GetRemaining() != 48 {
+		t.Errorf("GetRemaining() = %d, want 48", a.throttle.GetRemaining())
+	}
+}
+
+func TestThrottleRegistry_ReleasedOnClose(t *testing.T) {
+	registry := NewThrottleRegistry()
+	newClient := func() *Client {
+		t.Helper()
+		c, err := New("myrealm", auth.NewUserTokenStrategy("token-a"), WithProactiveThrottle(50), WithThrottleRegistry(registry))
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		return c
+	}
+
+	a, b := newClient(), newClient()
+	a.Close()
+	a.Close() // Releases once
+	if registry.Len() != 1 {
+		t.Fatalf("Len() after closing one of two clients = %d, want 1", registry.Len())
+	}
+	b.Close()
+	if registry.Len() != 0 {
+		t.Errorf("Len() after closing both clients = %d, want 0", registry.Len())
+	}
+}
+
+func TestThrottleRegistry_RateConflict(t *testing.T) {
+	registry := NewThrottleRegistry()
+	strategy := auth.NewUserTokenStrategy("token-a")
+	c, err := New("myrealm", strategy, WithProactiveThrottle(50), WithThrottleRegistry(registry))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer c.Close()
+
+	other, err := New("myrealm", strategy, WithProactiveThrottle(100), WithThrottleRegistry(registry))
+	if err != nil {
+		t.Fatalf("New() with a different rate error = %v", err)
+	}
+	if other.throttle == c.throttle || other.throttle.GetRemaining() != 100 {
+		t.Error("a client with a different rate should get a throttle of its own")
+	}
+	other.Close()
+	if registry.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", registry.Len())
+	}
+}
+
+func TestThrottleRegistry_ReleasedOnGC(t *testing.T) {
+	registry := NewThrottleRegistry()
+	func() {
+		_, err := New("myrealm", auth.NewUserTokenStrategy("token-a"), WithProactiveThrottle(50), WithThrottleRegistry(registry))
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+	}()
+
+	for i := 0; i < 50 && registry.Len() > 0; i++ {
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+	}
+	if registry.Len() != 0 {
+		t.Errorf("Len() after the unclosed client was collected = %d, want 0", registry.Len())
+	}
+}
+
+func TestThrottleRegistry_OptionOrder(t *testing.T) {
+	custom := NewNoOpThrottle()
+	strategy := auth.NewUserTokenStrategy("order-token")
+
+	c, _ := New("myrealm", strategy, WithProactiveThrottle(10), WithThrottle(custom))
+	if c.throttle != custom {
+		t.Error("WithThrottle after WithProactiveThrottle should win")
+	}
+
+	c, _ = New("myrealm", strategy, WithThrottle(custom), WithProactiveThrottle(10))
+	if _, ok := c.throttle.(*SlidingWindowThrottle); !ok {
+		t.Errorf("throttle = %T, want *SlidingWindowThrottle", c.throttle)
+	}
+
+	c2, _ := New("myrealm", strategy, WithProactiveThrottle(10))
+	if c.throttle != c2.throttle {
+		t.Error("DefaultThrottleRegistry should share throttles by default")
+	}
+}
+
+func TestFileThrottle(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shared.throttle")
+	a := NewFileThrottle(path, 3)
+	b := NewFileThrottle(path, 3) // A second process, in effect
+	ctx := context.Background()
+
+	for _, throttle := range []*FileThrottle{a, a, b} {
+		if err := throttle.Acquire(ctx); err != nil {
+			t.Fatalf("Acquire() error = %v", err)
+		}
+	}
+	if a.GetWindowCount() != 3 || b.GetRemaining() != 0 {
+		t.Errorf("count = %d, remaining = %d, want 3 and 0", a.GetWindowCount(), b.GetRemaining())
+	}
+
+	timeout, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if err := b.Acquire(timeout); err != context.DeadlineExceeded {
+		t.Errorf("Acquire() error = %v, want DeadlineExceeded", err)
+	}
+
+	a.Reset()
+	if err := b.Acquire(ctx); err != nil {
+		t.Errorf("Acquire() after Reset error = %v", err)
+	}
+	if b.GetWindowCount() != 1 {
+		t.Errorf("GetWindowCount() = %d, want 1", b.GetWindowCount())
+	}
+}
+
+func TestNewFileThrottleRegistry(t *testing.T) {
+	dir := t.TempDir()
+	registry := NewFileThrottleRegistry(dir)
+	c, err := New("myrealm", auth.NewUserTokenStrategy("file-token"),
+		WithProactiveThrottle(10), WithThrottleRegistry(registry))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := c.throttle.Acquire(context.Background()); err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 || filepath.Ext(entries[0].Name()) != ".throttle" {
+		t.Errorf("throttle files = %v", entries)
+	}
+}
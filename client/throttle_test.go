@@ -2,9 +2,15 @@ package client
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/auth"
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/core"
 )
 
 func TestSlidingWindowThrottle(t *testing.T) {
@@ -187,4 +193,124 @@ func TestThrottleInterface(t *testing.T) {
 	// Verify both types implement Throttle interface
 	var _ Throttle = (*SlidingWindowThrottle)(nil)
 	var _ Throttle = (*NoOpThrottle)(nil)
+	var _ Throttle = (*AIMDThrottle)(nil)
+	var _ ThrottleFeedback = (*AIMDThrottle)(nil)
+}
+
+func TestAIMDThrottle(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		throttle := NewAIMDThrottle(AIMDThrottleOptions{})
+		if throttle.Rate() != 100 {
+			t.Errorf("Rate() = %v, want 100", throttle.Rate())
+		}
+		if throttle.GetRemaining() != 100 {
+			t.Errorf("GetRemaining() = %d, want 100", throttle.GetRemaining())
+		}
+	})
+
+	t.Run("decreases multiplicatively once per burst", func(t *testing.T) {
+		throttle := NewAIMDThrottle(AIMDThrottleOptions{MinRate: 20})
+		throttle.OnRateLimit(core.RateLimitInfo{})
+		throttle.OnRateLimit(core.RateLimitInfo{}) // Same burst, ignored
+		if throttle.Rate() != 50 {
+			t.Errorf("Rate() = %v, want 50", throttle.Rate())
+		}
+
+		throttle.lastDecrease = time.Now().Add(-2 * time.Second)
+		throttle.OnRateLimit(core.RateLimitInfo{})
+		if throttle.Rate() != 25 {
+			t.Errorf("Rate() = %v, want 25", throttle.Rate())
+		}
+
+		throttle.lastDecrease = time.Time{}
+		throttle.OnRateLimit(core.RateLimitInfo{})
+		if throttle.Rate() != 20 {
+			t.Errorf("Rate() = %v, want MinRate 20", throttle.Rate())
+		}
+	})
+
+	t.Run("increases additively per window of successes", func(t *testing.T) {
+		throttle := NewAIMDThrottle(AIMDThrottleOptions{InitialRate: 10, MaxRate: 20, Increase: 2})
+		for i := 0; i < 10; i++ {
+			throttle.OnSuccess()
+		}
+		if rate := throttle.Rate(); rate < 11.5 || rate > 12 {
+			t.Errorf("Rate() = %v, want about 12 after one window", rate)
+		}
+		for i := 0; i < 1000; i++ {
+			throttle.OnSuccess()
+		}
+		if throttle.Rate() != 20 {
+			t.Errorf("Rate() = %v, want MaxRate 20", throttle.Rate())
+		}
+	})
+
+	t.Run("limits requests to the current rate", func(t *testing.T) {
+		throttle := NewAIMDThrottle(AIMDThrottleOptions{InitialRate: 4, MinRate: 1})
+		ctx := context.Background()
+		for i := 0; i < 4; i++ {
+			if err := throttle.Acquire(ctx); err != nil {
+				t.Fatalf("Acquire() error = %v", err)
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		if err := throttle.Acquire(ctx); err != context.DeadlineExceeded {
+			t.Errorf("Acquire() error = %v, want DeadlineExceeded", err)
+		}
+		if throttle.GetWindowCount() != 4 || throttle.GetRemaining() != 0 {
+			t.Errorf("count = %d, remaining = %d", throttle.GetWindowCount(), throttle.GetRemaining())
+		}
+	})
+
+	t.Run("pauses for Retry-After", func(t *testing.T) {
+		throttle := NewAIMDThrottle(AIMDThrottleOptions{})
+		throttle.OnRateLimit(core.RateLimitInfo{RetryAfter: 5})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		if err := throttle.Acquire(ctx); err != context.DeadlineExceeded {
+			t.Errorf("Acquire() error = %v, want DeadlineExceeded during pause", err)
+		}
+
+		throttle.Reset()
+		if err := throttle.Acquire(context.Background()); err != nil {
+			t.Errorf("Acquire() after Reset error = %v", err)
+		}
+		if throttle.Rate() != 100 {
+			t.Errorf("Rate() after Reset = %v, want 100", throttle.Rate())
+		}
+	})
+}
+
+func TestAIMDThrottle_ClientFeedback(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"bqapp123","name":"App"}`))
+	}))
+	defer srv.Close()
+
+	throttle := NewAIMDThrottle(AIMDThrottleOptions{InitialRate: 40})
+	c, err := New("myrealm", auth.NewUserTokenStrategy("token"),
+		WithBaseURL(srv.URL),
+		WithThrottle(throttle),
+		WithRetryPolicy(ExponentialBackoff{MaxAttempts: 3, InitialDelay: time.Millisecond}))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if _, err := c.GetApp("bqapp123").Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	// Halved by the 429, then raised by 5/20 for the success
+	if rate := throttle.Rate(); rate != 20.25 {
+		t.Errorf("Rate() = %v, want 20.25", rate)
+	}
 }
@@ -1,8 +1,10 @@
 package client
 
 import (
+	"bytes"
 	"encoding/json"
 
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/core"
 	"github.com/DrewBradfordXYZ/quickbase-go/v2/generated"
 )
 
@@ -177,21 +179,49 @@ func ExtractSortFields(sortBy *generated.SortByUnion) ([]generated.SortField, er
 // Values are unwrapped from FieldValue wrappers to their actual Go types.
 type Record = map[string]any
 
+// NumberMode selects the Go type numbers are decoded to in unwrapped records.
+// Raw generated records keep the exact JSON, whatever the mode.
+type NumberMode int
+
+// Number modes.
+const (
+	// NumberFloat64 decodes numbers as float64, which is exact up to 15
+	// significant digits.
+	NumberFloat64 NumberMode = iota
+
+	// NumberJSON decodes numbers as json.Number, the exact digits QuickBase
+	// sent.
+	NumberJSON
+
+	// NumberDecimal decodes numbers as core.Decimal, for exact arithmetic.
+	NumberDecimal
+)
+
 // unwrapRecord converts a generated QuickbaseRecord to a friendly map[string]any.
 // It unwraps each FieldValue to expose the actual value directly.
 func unwrapRecord(record generated.QuickbaseRecord) Record {
+	return unwrapRecordAs(record, NumberFloat64)
+}
+
+// unwrapRecords converts a slice of generated QuickbaseRecords to friendly Records.
+func unwrapRecords(records []generated.QuickbaseRecord) []Record {
+	return unwrapRecordsAs(records, NumberFloat64)
+}
+
+// unwrapRecordAs unwraps a record, decoding numbers according to mode.
+func unwrapRecordAs(record generated.QuickbaseRecord, mode NumberMode) Record {
 	result := make(Record)
 	for k, fv := range record {
-		result[k] = extractValue(fv.Value)
+		result[k] = extractValueAs(fv.Value, mode)
 	}
 	return result
 }
 
-// unwrapRecords converts a slice of generated QuickbaseRecords to friendly Records.
-func unwrapRecords(records []generated.QuickbaseRecord) []Record {
+// unwrapRecordsAs unwraps records, decoding numbers according to mode.
+func unwrapRecordsAs(records []generated.QuickbaseRecord, mode NumberMode) []Record {
 	result := make([]Record, len(records))
 	for i, record := range records {
-		result[i] = unwrapRecord(record)
+		result[i] = unwrapRecordAs(record, mode)
 	}
 	return result
 }
@@ -219,6 +249,16 @@ func UnwrapRecord(record generated.QuickbaseRecord) Record {
 	return unwrapRecord(record)
 }
 
+// UnwrapRecordsAs is UnwrapRecords with numbers decoded according to mode.
+//
+// Example:
+//
+//	records := quickbase.UnwrapRecordsAs(raw, quickbase.NumberJSON)
+//	amount := records[0]["7"].(json.Number) // "12345678901234.56"
+func UnwrapRecordsAs(records []generated.QuickbaseRecord, mode NumberMode) []Record {
+	return unwrapRecordsAs(records, mode)
+}
+
 // Deref returns the value of a pointer, or zero value if nil.
 // This is an opt-in helper for working with the many optional (pointer) fields
 // in generated response types.
@@ -257,19 +297,59 @@ func DerefOr[T any](ptr *T, defaultVal T) T {
 // - []any (multi-select, address, etc.)
 // - map[string]any (user, file attachment, etc.)
 func extractValue(v generated.FieldValue_Value) any {
+	return extractValueAs(v, NumberFloat64)
+}
+
+// extractValueAs unwraps a FieldValue_Value union, decoding numbers, including
+// those nested in lists and objects, according to mode.
+func extractValueAs(v generated.FieldValue_Value, mode NumberMode) any {
 	// The union type stores data as json.RawMessage internally.
 	// We marshal it to JSON and then unmarshal to any to get the actual value.
 	data, err := v.MarshalJSON()
 	if err != nil {
 		return nil
 	}
+	if mode == NumberFloat64 {
+		var result any
+		if err := json.Unmarshal(data, &result); err != nil {
+			return nil
+		}
+		return result
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
 	var result any
-	if err := json.Unmarshal(data, &result); err != nil {
+	if err := dec.Decode(&result); err != nil {
 		return nil
 	}
+	if mode == NumberDecimal {
+		return toDecimals(result)
+	}
 	return result
 }
 
+// toDecimals replaces the json.Numbers in a decoded value with core.Decimals.
+func toDecimals(v any) any {
+	switch val := v.(type) {
+	case json.Number:
+		d, err := core.ParseDecimal(val.String())
+		if err != nil {
+			return val
+		}
+		return d
+	case []any:
+		for i, item := range val {
+			val[i] = toDecimals(item)
+		}
+	case map[string]any:
+		for k, item := range val {
+			val[k] = toDecimals(item)
+		}
+	}
+	return v
+}
+
 // --- Value Wrapping (for creating records) ---
 
 // wrapValue converts a Go value to a FieldValue suitable for record creation.
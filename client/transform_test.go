@@ -0,0 +1,153 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/auth"
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/core"
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/generated"
+)
+
+// bigNumbersResponse holds values float64 cannot represent exactly.
+const bigNumbersResponse = `{
+	"data": [{"3": {"value": 12345678901234567}, "6": {"value": 1234567890.123456789}, "7": {"value": [1.10, 2]}}],
+	"fields": [{"id": 3, "label": "Record ID#", "type": "recordid"}, {"id": 6, "label": "Amount", "type": "numeric"}],
+	"metadata": {"numFields": 2, "numRecords": 1, "skip": 0, "totalRecords": 1}
+}`
+
+// newNumbersServer serves body for every request and records the raw
+// request bodies.
+func newNumbersServer(t *testing.T, body string, opts ...Option) (*Client, *[]string) {
+	t.Helper()
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		requests = append(requests, string(data))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	opts = append([]Option{WithBaseURL(srv.URL), WithRetryPolicy(NeverRetry)}, opts...)
+	c, err := New("myrealm", auth.NewUserTokenStrategy("token"), opts...)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return c, &requests
+}
+
+func TestRunQuery_NumberModes(t *testing.T) {
+	ctx := context.Background()
+	body := generated.RunQueryJSONRequestBody{From: "bqxyz"}
+
+	// Default: float64, as before
+	c, _ := newNumbersServer(t, bigNumbersResponse)
+	result, err := c.RunQuery(ctx, body)
+	if err != nil {
+		t.Fatalf("RunQuery() error = %v", err)
+	}
+	if _, ok := result.Records()[0]["3"].(float64); !ok {
+		t.Errorf("default mode decoded %T, want float64", result.Records()[0]["3"])
+	}
+
+	c, _ = newNumbersServer(t, bigNumbersResponse, WithNumberMode(NumberJSON))
+	result, err = c.RunQuery(ctx, body)
+	if err != nil {
+		t.Fatalf("RunQuery() error = %v", err)
+	}
+	rec := result.Records()[0]
+	if n, ok := rec["3"].(json.Number); !ok || n != "12345678901234567" {
+		t.Errorf(`rec["3"] = %#v, want json.Number("12345678901234567")`, rec["3"])
+	}
+	if n, ok := rec["6"].(json.Number); !ok || n != "1234567890.123456789" {
+		t.Errorf(`rec["6"] = %#v, want json.Number("1234567890.123456789")`, rec["6"])
+	}
+	if list, ok := rec["7"].([]any); !ok || list[0] != json.Number("1.10") {
+		t.Errorf(`rec["7"] = %#v, want nested json.Numbers`, rec["7"])
+	}
+
+	c, _ = newNumbersServer(t, bigNumbersResponse, WithNumberMode(NumberDecimal))
+	result, err = c.RunQuery(ctx, body)
+	if err != nil {
+		t.Fatalf("RunQuery() error = %v", err)
+	}
+	rec = result.Records()[0]
+	if d, ok := rec["6"].(core.Decimal); !ok || d.String() != "1234567890.123456789" {
+		t.Errorf(`rec["6"] = %#v, want Decimal 1234567890.123456789`, rec["6"])
+	}
+	if list, ok := rec["7"].([]any); !ok || list[0].(core.Decimal).String() != "1.10" {
+		t.Errorf(`rec["7"] = %#v, want nested Decimals`, rec["7"])
+	}
+}
+
+func TestQueryBuilder_NumberMode(t *testing.T) {
+	c, _ := newNumbersServer(t, bigNumbersResponse, WithNumberMode(NumberJSON))
+	records, err := c.Query("bqxyz").Select(3).Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if n, ok := records[0]["3"].(json.Number); !ok || n != "12345678901234567" {
+		t.Errorf(`records[0]["3"] = %#v, want json.Number`, records[0]["3"])
+	}
+}
+
+func TestRunReport_NumberMode(t *testing.T) {
+	c, _ := newNumbersServer(t, bigNumbersResponse, WithNumberMode(NumberDecimal))
+	result, err := c.RunReport("1", "bqxyz").Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if d, ok := result.Records()[0]["3"].(core.Decimal); !ok || d.String() != "12345678901234567" {
+		t.Errorf(`Records()[0]["3"] = %#v, want Decimal`, result.Records()[0]["3"])
+	}
+}
+
+func TestUpsert_NumbersRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	c, requests := newNumbersServer(t, bigNumbersResponse, WithNumberMode(NumberJSON))
+
+	queried, err := c.RunQuery(ctx, generated.RunQueryJSONRequestBody{From: "bqxyz"})
+	if err != nil {
+		t.Fatalf("RunQuery() error = %v", err)
+	}
+	data := make([]any, 0)
+	for _, record := range *queried.Raw().JSON200.Data {
+		data = append(data, record)
+	}
+
+	result, err := c.Upsert("bqxyz").Data(data...).Run(ctx)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	// The queried digits are sent back unchanged
+	sent := (*requests)[1]
+	for _, want := range []string{"12345678901234567", "1234567890.123456789", "1.10"} {
+		if !strings.Contains(sent, want) {
+			t.Errorf("upsert body %s does not contain %s", sent, want)
+		}
+	}
+	if n, ok := result.Records()[0]["3"].(json.Number); !ok || n != "12345678901234567" {
+		t.Errorf(`Records()[0]["3"] = %#v, want json.Number`, result.Records()[0]["3"])
+	}
+}
+
+func TestUnwrapRecordsAs(t *testing.T) {
+	var records []generated.QuickbaseRecord
+	if err := json.Unmarshal([]byte(`[{"6": {"value": 0.1}, "7": {"value": "text"}, "8": {"value": null}}]`), &records); err != nil {
+		t.Fatal(err)
+	}
+	rec := UnwrapRecordsAs(records, NumberJSON)[0]
+	if rec["6"] != json.Number("0.1") || rec["7"] != "text" || rec["8"] != nil {
+		t.Errorf("UnwrapRecordsAs() = %#v", rec)
+	}
+	if rec := UnwrapRecords(records)[0]; rec["6"] != 0.1 {
+		t.Errorf("UnwrapRecords() = %#v", rec)
+	}
+}
@@ -0,0 +1,290 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/core"
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/generated"
+)
+
+// --- Typed Field Values ---
+// TypedValue reads a field value as the Go type of its QuickBase field type,
+// instead of the string, float64 or map[string]any that unwrapped records hold.
+//
+//	result, _ := client.RunQuery(ctx, body)
+//	for _, rec := range result.TypedRecords() {
+//	    due, _ := rec.Field(8).Date()
+//	    owner, _ := rec.Field(9).User()
+//	    file, _ := rec.Field(10).FileAttachment()
+//	}
+//
+// When the field type is known, from query metadata or NewTypedValue, an
+// accessor that does not fit it returns an error: calling Date on a text field
+// fails even if the text looks like a date. Without a field type, accessors
+// decode whatever JSON the value holds. Null values decode to the zero value.
+
+// FileAttachment is a file attachment field value.
+type FileAttachment struct {
+	URL      string        `json:"url"`      // Path of the latest version's download
+	Versions []FileVersion `json:"versions"` // Oldest first
+}
+
+// Latest returns the most recent version, or false if no file is attached.
+func (f FileAttachment) Latest() (FileVersion, bool) {
+	if len(f.Versions) == 0 {
+		return FileVersion{}, false
+	}
+	return f.Versions[len(f.Versions)-1], true
+}
+
+// FileVersion is one uploaded version of a file attachment.
+type FileVersion struct {
+	VersionNumber int       `json:"versionNumber"`
+	FileName      string    `json:"fileName"`
+	Uploaded      time.Time `json:"uploaded"`
+	Creator       User      `json:"creator"`
+}
+
+// Address is an address field value.
+type Address struct {
+	Street1    string `json:"street1,omitempty"`
+	Street2    string `json:"street2,omitempty"`
+	City       string `json:"city,omitempty"`
+	Region     string `json:"region,omitempty"` // State or province
+	PostalCode string `json:"postalCode,omitempty"`
+	Country    string `json:"country,omitempty"`
+}
+
+// Field types each accessor accepts when the field type is known.
+var (
+	numericFieldTypes = []string{"numeric", "currency", "percent", "rating", "duration", "recordid"}
+	dateFieldTypes    = []string{"date", "timestamp"}
+)
+
+// TypedValue is a field value with typed accessors. The zero TypedValue is
+// null.
+type TypedValue struct {
+	raw       json.RawMessage
+	fieldType string
+}
+
+// NewTypedValue wraps a field value for typed access. v may be a FieldValue,
+// a FieldValue_Value union, raw JSON, or an unwrapped value from a Record.
+// fieldType is the QuickBase field type ("date", "user", ...), or "" if
+// unknown.
+func NewTypedValue(v any, fieldType string) TypedValue {
+	var raw []byte
+	switch val := v.(type) {
+	case generated.FieldValue:
+		raw, _ = val.Value.MarshalJSON()
+	case *generated.FieldValue:
+		if val != nil {
+			raw, _ = val.Value.MarshalJSON()
+		}
+	case generated.FieldValue_Value:
+		raw, _ = val.MarshalJSON()
+	case json.RawMessage:
+		raw = val
+	default:
+		raw, _ = json.Marshal(val)
+	}
+	return TypedValue{raw: raw, fieldType: fieldType}
+}
+
+// FieldType returns the QuickBase field type, or "" if unknown.
+func (v TypedValue) FieldType() string {
+	return v.fieldType
+}
+
+// Raw returns the value's JSON.
+func (v TypedValue) Raw() json.RawMessage {
+	return v.raw
+}
+
+// IsNull reports whether the value is null or missing.
+func (v TypedValue) IsNull() bool {
+	return len(v.raw) == 0 || string(v.raw) == "null"
+}
+
+// String returns text values as-is and other values as JSON. Null is "".
+func (v TypedValue) String() string {
+	if v.IsNull() {
+		return ""
+	}
+	var s string
+	if json.Unmarshal(v.raw, &s) == nil {
+		return s
+	}
+	return string(v.raw)
+}
+
+// Bool returns a checkbox value.
+func (v TypedValue) Bool() (bool, error) {
+	var b bool
+	err := v.decode("bool", &b, "checkbox")
+	return b, err
+}
+
+// Numeric returns the value of a numeric, currency, percent, rating,
+// duration or record ID field.
+func (v TypedValue) Numeric() (float64, error) {
+	var n float64
+	err := v.decode("number", &n, numericFieldTypes...)
+	return n, err
+}
+
+// Number returns the value of a numeric field as the exact digits QuickBase
+// sent. Null is "".
+func (v TypedValue) Number() (json.Number, error) {
+	var n json.Number
+	err := v.decode("number", &n, numericFieldTypes...)
+	return n, err
+}
+
+// Decimal returns the value of a numeric field as an exact decimal.
+func (v TypedValue) Decimal() (core.Decimal, error) {
+	var d core.Decimal
+	err := v.decode("decimal", &d, numericFieldTypes...)
+	return d, err
+}
+
+// Duration returns a duration field value, which QuickBase sends in
+// milliseconds.
+func (v TypedValue) Duration() (time.Duration, error) {
+	var ms float64
+	if err := v.decode("time.Duration", &ms, "duration"); err != nil {
+		return 0, err
+	}
+	return time.Duration(ms * float64(time.Millisecond)), nil
+}
+
+// Date returns a date field value at midnight UTC. For a timestamp field it
+// returns the UTC date of the timestamp.
+func (v TypedValue) Date() (time.Time, error) {
+	t, err := v.parseTime("date")
+	if err != nil || t.IsZero() {
+		return t, err
+	}
+	return t.UTC().Truncate(24 * time.Hour), nil
+}
+
+// DateTime returns a timestamp field value in UTC. For a date field it
+// returns midnight UTC of that date.
+func (v TypedValue) DateTime() (time.Time, error) {
+	t, err := v.parseTime("date-time")
+	return t.UTC(), err
+}
+
+// User returns a user field value.
+func (v TypedValue) User() (User, error) {
+	var u User
+	err := v.decode("user", &u, "user")
+	return u, err
+}
+
+// Users returns a list-user field value.
+func (v TypedValue) Users() ([]User, error) {
+	var users []User
+	err := v.decode("user list", &users, "multiuser")
+	return users, err
+}
+
+// MultiChoice returns the selected choices of a multi-select text field.
+func (v TypedValue) MultiChoice() ([]string, error) {
+	var choices []string
+	err := v.decode("multi-choice", &choices, "multitext")
+	return choices, err
+}
+
+// FileAttachment returns a file attachment field value.
+func (v TypedValue) FileAttachment() (FileAttachment, error) {
+	var f FileAttachment
+	err := v.decode("file attachment", &f, "file")
+	return f, err
+}
+
+// Address returns an address field value.
+func (v TypedValue) Address() (Address, error) {
+	var a Address
+	err := v.decode("address", &a, "address")
+	return a, err
+}
+
+// checkType fails if the field type is known and not one of types.
+func (v TypedValue) checkType(want string, types ...string) error {
+	if v.fieldType != "" && !slices.Contains(types, v.fieldType) {
+		return fmt.Errorf("cannot read %s field as %s", v.fieldType, want)
+	}
+	return nil
+}
+
+// decode unmarshals the value into dst after checking the field type. Null,
+// and the empty string QuickBase sends for some empty fields, leave dst zero.
+func (v TypedValue) decode(want string, dst any, types ...string) error {
+	if err := v.checkType(want, types...); err != nil {
+		return err
+	}
+	if v.IsNull() || string(v.raw) == `""` {
+		return nil
+	}
+	if err := json.Unmarshal(v.raw, dst); err != nil {
+		return fmt.Errorf("cannot decode %s as %s", jsonKind(v.raw), want)
+	}
+	return nil
+}
+
+// parseTime parses a date or timestamp value.
+func (v TypedValue) parseTime(want string) (time.Time, error) {
+	var s string
+	if err := v.decode(want, &s, dateFieldTypes...); err != nil || s == "" {
+		return time.Time{}, err
+	}
+	t, err := core.ParseISODate(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("cannot parse %q as a %s", s, want)
+	}
+	return t, nil
+}
+
+// TypedRecord is a record whose fields are read as TypedValues.
+type TypedRecord struct {
+	record generated.QuickbaseRecord
+	types  map[int]string
+}
+
+// NewTypedRecord wraps a record for typed access. fieldTypes maps field IDs
+// to QuickBase field types; it may be nil.
+func NewTypedRecord(record generated.QuickbaseRecord, fieldTypes map[int]string) TypedRecord {
+	return TypedRecord{record: record, types: fieldTypes}
+}
+
+// Field returns the value of a field. Fields missing from the record are
+// null.
+func (r TypedRecord) Field(id int) TypedValue {
+	fv, ok := r.record[strconv.Itoa(id)]
+	if !ok {
+		return TypedValue{fieldType: r.types[id]}
+	}
+	return NewTypedValue(fv, r.types[id])
+}
+
+// TypedRecords returns the records for typed access, with field types from
+// the response's field metadata.
+func (r *RunQueryResult) TypedRecords() []TypedRecord {
+	if r == nil || r.resp == nil || r.resp.JSON200 == nil || r.resp.JSON200.Data == nil {
+		return nil
+	}
+	types := make(map[int]string)
+	for _, f := range r.Fields() {
+		types[f.Id()] = f.Type()
+	}
+	records := make([]TypedRecord, len(*r.resp.JSON200.Data))
+	for i, record := range *r.resp.JSON200.Data {
+		records[i] = NewTypedRecord(record, types)
+	}
+	return records
+}
@@ -0,0 +1,167 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/auth"
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/generated"
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/qbtest"
+)
+
+func typedValue(t *testing.T, data, fieldType string) TypedValue {
+	t.Helper()
+	var fv generated.FieldValue
+	if err := json.Unmarshal([]byte(`{"value":`+data+`}`), &fv); err != nil {
+		t.Fatalf("unmarshal %s: %v", data, err)
+	}
+	return NewTypedValue(fv, fieldType)
+}
+
+func TestTypedValue_Accessors(t *testing.T) {
+	date, err := typedValue(t, `"2026-03-14"`, "date").Date()
+	if err != nil || !date.Equal(time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Date() = %v, %v", date, err)
+	}
+	ts, err := typedValue(t, `"2026-03-14T09:30:00.000Z"`, "timestamp").DateTime()
+	if err != nil || !ts.Equal(time.Date(2026, 3, 14, 9, 30, 0, 0, time.UTC)) {
+		t.Errorf("DateTime() = %v, %v", ts, err)
+	}
+	day, err := typedValue(t, `"2026-03-14T23:30:00.000Z"`, "timestamp").Date()
+	if err != nil || !day.Equal(time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Date() of timestamp = %v, %v", day, err)
+	}
+	d, err := typedValue(t, `5400000`, "duration").Duration()
+	if err != nil || d != 90*time.Minute {
+		t.Errorf("Duration() = %v, %v", d, err)
+	}
+	n, err := typedValue(t, `1234.5`, "currency").Numeric()
+	if err != nil || n != 1234.5 {
+		t.Errorf("Numeric() = %v, %v", n, err)
+	}
+	if num, err := typedValue(t, `12345678901234567`, "recordid").Number(); err != nil || num != "12345678901234567" {
+		t.Errorf("Number() = %v, %v", num, err)
+	}
+	if dec, err := typedValue(t, `1234567890.125`, "currency").Decimal(); err != nil || dec.String() != "1234567890.125" {
+		t.Errorf("Decimal() = %v, %v", dec, err)
+	}
+	b, err := typedValue(t, `true`, "checkbox").Bool()
+	if err != nil || !b {
+		t.Errorf("Bool() = %v, %v", b, err)
+	}
+
+	u, err := typedValue(t, `{"id":"58.ab","email":"ann@example.com","name":"Ann Lee","userName":"ann"}`, "user").User()
+	if want := (User{ID: "58.ab", Email: "ann@example.com", Name: "Ann Lee", UserName: "ann"}); err != nil || u != want {
+		t.Errorf("User() = %+v, %v", u, err)
+	}
+	users, err := typedValue(t, `[{"id":"1","email":"a@example.com"},{"id":"2","email":"b@example.com"}]`, "multiuser").Users()
+	if err != nil || len(users) != 2 || users[1].Email != "b@example.com" {
+		t.Errorf("Users() = %+v, %v", users, err)
+	}
+	choices, err := typedValue(t, `["red","blue"]`, "multitext").MultiChoice()
+	if err != nil || !reflect.DeepEqual(choices, []string{"red", "blue"}) {
+		t.Errorf("MultiChoice() = %v, %v", choices, err)
+	}
+
+	file, err := typedValue(t, `{"url":"/files/bqxyz/1/10/2","versions":[
+		{"versionNumber":1,"fileName":"a.pdf","uploaded":"2026-03-01T10:00:00.000Z","creator":{"id":"1","email":"a@example.com"}},
+		{"versionNumber":2,"fileName":"b.pdf","uploaded":"2026-03-02T10:00:00.000Z","creator":{"id":"1","email":"a@example.com"}}]}`, "file").FileAttachment()
+	if err != nil || file.URL != "/files/bqxyz/1/10/2" {
+		t.Fatalf("FileAttachment() = %+v, %v", file, err)
+	}
+	if latest, ok := file.Latest(); !ok || latest.FileName != "b.pdf" || latest.Uploaded.Day() != 2 {
+		t.Errorf("Latest() = %+v, %v", latest, ok)
+	}
+
+	addr, err := typedValue(t, `{"street1":"1 Main St","city":"Boston","region":"MA","postalCode":"02110","country":"USA"}`, "address").Address()
+	if err != nil || addr.City != "Boston" || addr.PostalCode != "02110" {
+		t.Errorf("Address() = %+v, %v", addr, err)
+	}
+}
+
+func TestTypedValue_TypeMismatch(t *testing.T) {
+	if _, err := typedValue(t, `"2026-03-14"`, "text").Date(); err == nil {
+		t.Error("Date() on a text field succeeded, want error")
+	}
+	if _, err := typedValue(t, `"abc"`, "numeric").Numeric(); err == nil {
+		t.Error("Numeric() on a string succeeded, want error")
+	}
+	// Without a field type, the JSON decides
+	if date, err := typedValue(t, `"2026-03-14"`, "").Date(); err != nil || date.Day() != 14 {
+		t.Errorf("Date() without field type = %v, %v", date, err)
+	}
+}
+
+func TestTypedValue_Empty(t *testing.T) {
+	if date, err := typedValue(t, `""`, "date").Date(); err != nil || !date.IsZero() {
+		t.Errorf("Date() of empty = %v, %v", date, err)
+	}
+	if n, err := typedValue(t, `null`, "numeric").Numeric(); err != nil || n != 0 {
+		t.Errorf("Numeric() of null = %v, %v", n, err)
+	}
+	if u, err := (TypedValue{}).User(); err != nil || u != (User{}) {
+		t.Errorf("User() of zero TypedValue = %+v, %v", u, err)
+	}
+	if !typedValue(t, `null`, "user").IsNull() {
+		t.Error("IsNull() = false for null")
+	}
+}
+
+func TestNewTypedValue_RecordValue(t *testing.T) {
+	// Values from unwrapped Records work too
+	record := Record{"7": 3600000.0, "8": []any{"red"}}
+	if d, err := NewTypedValue(record["7"], "duration").Duration(); err != nil || d != time.Hour {
+		t.Errorf("Duration() = %v, %v", d, err)
+	}
+	if c, err := NewTypedValue(record["8"], "").MultiChoice(); err != nil || len(c) != 1 {
+		t.Errorf("MultiChoice() = %v, %v", c, err)
+	}
+	if s := NewTypedValue(record["8"], "").String(); s != `["red"]` {
+		t.Errorf("String() = %q", s)
+	}
+}
+
+func TestRunQueryResult_TypedRecords(t *testing.T) {
+	srv := qbtest.NewServer(qbtest.WithUserToken("token"))
+	t.Cleanup(srv.Close)
+	appID := srv.CreateApp("Test App")
+	tableID := srv.CreateTable(appID, "Projects",
+		qbtest.Field{Label: "Due", Type: "date"},       // 6
+		qbtest.Field{Label: "Owner", Type: "user"},     // 7
+		qbtest.Field{Label: "Tags", Type: "multitext"}, // 8
+	)
+	srv.AddRecords(tableID, map[int]any{6: "2026-03-14", 7: map[string]any{"id": "1", "email": "ann@example.com"}, 8: []string{"red"}})
+
+	c, err := New("myrealm", auth.NewUserTokenStrategy("token"), WithBaseURL(srv.BaseURL()), WithRetryPolicy(NeverRetry))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	result, err := c.RunQuery(context.Background(), generated.RunQueryJSONRequestBody{From: tableID, Select: &[]int{6, 7, 8}})
+	if err != nil {
+		t.Fatalf("RunQuery() error = %v", err)
+	}
+
+	records := result.TypedRecords()
+	if len(records) != 1 {
+		t.Fatalf("got %d records, want 1", len(records))
+	}
+	rec := records[0]
+	if rec.Field(6).FieldType() != "date" {
+		t.Errorf("FieldType() = %q, want date", rec.Field(6).FieldType())
+	}
+	if due, err := rec.Field(6).Date(); err != nil || due.Month() != time.March {
+		t.Errorf("Date() = %v, %v", due, err)
+	}
+	if owner, err := rec.Field(7).User(); err != nil || owner.Email != "ann@example.com" {
+		t.Errorf("User() = %+v, %v", owner, err)
+	}
+	if _, err := rec.Field(8).User(); err == nil {
+		t.Error("User() on a multitext field succeeded, want error")
+	}
+	if !rec.Field(99).IsNull() {
+		t.Error("missing field is not null")
+	}
+}
@@ -0,0 +1,358 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"iter"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/core"
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/generated"
+)
+
+// ErrDeletesTruncated is yielded by Watch when QuickBase reports more deletes
+// in one poll than it returns details for. Some deletions were not emitted;
+// resynchronize the table to catch them.
+var ErrDeletesTruncated = errors.New("recordsModifiedSince truncated the deleted records")
+
+// Watch defaults, used when the matching WatchOptions field is 0.
+const (
+	defaultWatchInterval = 30 * time.Second
+	defaultWatchOverlap  = 5 * time.Second
+)
+
+// ChangeType is the kind of change in a ChangeEvent.
+type ChangeType string
+
+// Change types, matching the changeType values of recordsModifiedSince.
+const (
+	ChangeCreate ChangeType = "CREATE"
+	ChangeModify ChangeType = "MODIFY"
+	ChangeDelete ChangeType = "DELETE"
+)
+
+// ChangeEvent is a record change emitted by Watch.
+type ChangeEvent struct {
+	Type      ChangeType
+	RecordId  int
+	Timestamp time.Time // When QuickBase recorded the change
+	Record    Record    // Current field values, keyed by field ID (nil for deletes)
+}
+
+// WatchOptions configures Watch.
+type WatchOptions struct {
+	Interval time.Duration // Time between polls (default 30s)
+	Select   []int         // Fields in event records (default: the table's default fields)
+
+	// FieldList is passed to recordsModifiedSince, so changes to the records
+	// these fields look up through relationships also count as changes.
+	FieldList []int
+
+	// Checkpoints stores the watch position. Without a store, a new watch
+	// starts from Since.
+	Checkpoints CheckpointStore
+
+	// CheckpointKey names this watch in the store (default: the table ID).
+	CheckpointKey string
+
+	// Since is where a watch with no saved checkpoint starts (default: now).
+	Since time.Time
+
+	// Overlap is how far before the checkpoint each poll looks, so changes
+	// committed late are not missed (default 5s). Changes already emitted in
+	// the overlap are not emitted again.
+	Overlap time.Duration
+}
+
+// Watch polls a table for created, modified and deleted records and yields a
+// ChangeEvent for each change, oldest first, until ctx is done or the loop
+// breaks.
+//
+// Each poll asks recordsModifiedSince for the changes since the checkpoint,
+// then fetches the current values of changed records with a query on Date
+// Modified (field 2). The checkpoint only advances to timestamps QuickBase
+// reported, never the local clock, so clock skew between hosts doesn't drop
+// changes.
+//
+// Delivery is at least once. An event counts as processed when the loop asks
+// for the next one; the checkpoint is saved after each poll, and when the loop
+// breaks, up to the last processed event. Events after the checkpoint are
+// emitted again on restart. A record that changed several times between polls
+// produces one event. Errors, including ErrDeletesTruncated, are yielded with a zero event
+// and polling continues.
+//
+// Example:
+//
+//	store := quickbase.NewFileCheckpointStore("checkpoints.json")
+//	for event, err := range client.Watch(ctx, "orders", quickbase.WatchOptions{Checkpoints: store}) {
+//	    if err != nil {
+//	        log.Printf("watch: %v", err)
+//	        continue
+//	    }
+//	    switch event.Type {
+//	    case quickbase.ChangeCreate, quickbase.ChangeModify:
+//	        upsertLocal(event.RecordId, event.Record)
+//	    case quickbase.ChangeDelete:
+//	        deleteLocal(event.RecordId)
+//	    }
+//	}
+func (c *Client) Watch(ctx context.Context, table string, opts WatchOptions) iter.Seq2[ChangeEvent, error] {
+	return func(yield func(ChangeEvent, error) bool) {
+		tableID := table
+		if c.schema != nil {
+			resolved, err := core.ResolveTableAlias(c.schema, table)
+			if err != nil {
+				yield(ChangeEvent{}, err)
+				return
+			}
+			tableID = resolved
+		}
+		w := &watcher{
+			client:  c,
+			tableID: tableID,
+			opts:    opts,
+			key:     opts.CheckpointKey,
+			overlap: orDefault(opts.Overlap, defaultWatchOverlap),
+			seen:    make(map[int]time.Time),
+		}
+		if w.key == "" {
+			w.key = tableID
+		}
+
+		checkpoint, err := w.start(ctx)
+		if err != nil {
+			yield(ChangeEvent{}, err)
+			return
+		}
+
+		interval := orDefault(opts.Interval, defaultWatchInterval)
+		for {
+			events, next, err := w.poll(ctx, checkpoint)
+			if ctx.Err() != nil {
+				return
+			}
+			// An event is processed once the loop asks for the next one
+			processed := checkpoint
+			for _, event := range events {
+				if !yield(event, nil) {
+					if processed.After(checkpoint) {
+						w.save(ctx, processed)
+					}
+					return
+				}
+				if event.Timestamp.After(processed) {
+					processed = event.Timestamp
+				}
+			}
+			if err != nil && !yield(ChangeEvent{}, err) {
+				return
+			}
+
+			if next.After(checkpoint) {
+				checkpoint = next
+				if err := w.save(ctx, checkpoint); err != nil && !yield(ChangeEvent{}, err) {
+					return
+				}
+			}
+
+			if sleepContext(ctx, interval) != nil {
+				return
+			}
+		}
+	}
+}
+
+// watcher holds the state of one Watch.
+type watcher struct {
+	client  *Client
+	tableID string
+	opts    WatchOptions
+	key     string
+	overlap time.Duration
+	seen    map[int]time.Time // Latest emitted change per record, while inside the overlap
+}
+
+// start returns the saved checkpoint, or the starting point of a new watch.
+func (w *watcher) start(ctx context.Context) (time.Time, error) {
+	if w.opts.Checkpoints != nil {
+		checkpoint, ok, err := w.opts.Checkpoints.Load(ctx, w.key)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if ok {
+			return checkpoint, nil
+		}
+	}
+	if !w.opts.Since.IsZero() {
+		return w.opts.Since, nil
+	}
+	return time.Now(), nil
+}
+
+// save stores the checkpoint if a store is configured.
+func (w *watcher) save(ctx context.Context, checkpoint time.Time) error {
+	if w.opts.Checkpoints == nil {
+		return nil
+	}
+	return w.opts.Checkpoints.Save(ctx, w.key, checkpoint)
+}
+
+// poll returns the changes since checkpoint that have not been emitted yet,
+// and the new checkpoint. Events may be returned along with
+// ErrDeletesTruncated.
+func (w *watcher) poll(ctx context.Context, checkpoint time.Time) ([]ChangeEvent, time.Time, error) {
+	after := checkpoint.Add(-w.overlap)
+
+	includeDetails := true
+	body := generated.RecordsModifiedSinceJSONRequestBody{
+		From:           w.tableID,
+		After:          after.UTC(),
+		IncludeDetails: &includeDetails,
+	}
+	if len(w.opts.FieldList) > 0 {
+		body.FieldList = &w.opts.FieldList
+	}
+	resp, err := w.client.API().RecordsModifiedSinceWithResponse(ctx, body)
+	if err != nil {
+		return nil, checkpoint, err
+	}
+	if resp.JSON200 == nil {
+		return nil, checkpoint, parseAPIError(resp.StatusCode(), resp.Body, resp.HTTPResponse)
+	}
+
+	// Forget emitted changes that have left the overlap
+	maps.DeleteFunc(w.seen, func(_ int, ts time.Time) bool { return ts.Before(after) })
+	seen := maps.Clone(w.seen)
+
+	next := checkpoint
+	var events []ChangeEvent
+	fetch := false
+	for _, change := range Deref(resp.JSON200.Changes) {
+		if change.RecordId == nil || change.Timestamp == nil || change.ChangeType == nil {
+			continue
+		}
+		if change.Timestamp.After(next) {
+			next = *change.Timestamp
+		}
+		event := ChangeEvent{
+			Type:      ChangeType(*change.ChangeType),
+			RecordId:  *change.RecordId,
+			Timestamp: *change.Timestamp,
+		}
+		last, emitted := w.seen[event.RecordId]
+		if emitted && !event.Timestamp.After(last) {
+			continue
+		}
+		// A create and later modify in one window is reported as a create
+		if emitted && event.Type == ChangeCreate {
+			event.Type = ChangeModify
+		}
+		w.seen[event.RecordId] = event.Timestamp
+		fetch = fetch || event.Type != ChangeDelete
+		events = append(events, event)
+	}
+	slices.SortStableFunc(events, func(a, b ChangeEvent) int { return a.Timestamp.Compare(b.Timestamp) })
+
+	if fetch {
+		records, err := w.changedRecords(ctx, after, events)
+		if err != nil {
+			// Emit these changes on the next poll
+			w.seen = seen
+			return nil, checkpoint, err
+		}
+		for i := range events {
+			if events[i].Type != ChangeDelete {
+				events[i].Record = records[events[i].RecordId]
+			}
+		}
+		// Records deleted since the change are dropped; their delete comes next poll
+		events = slices.DeleteFunc(events, func(e ChangeEvent) bool {
+			return e.Type != ChangeDelete && e.Record == nil
+		})
+	}
+
+	if resp.JSON200.DeletesTruncated != nil && *resp.JSON200.DeletesTruncated {
+		return events, next, ErrDeletesTruncated
+	}
+	return events, next, nil
+}
+
+// changedRecords fetches the current values of the created and modified
+// records in events. Records whose Date Modified is in the poll window come
+// from one query; the rest (changed through FieldList dependencies) are
+// fetched by record ID.
+func (w *watcher) changedRecords(ctx context.Context, after time.Time, events []ChangeEvent) (map[int]Record, error) {
+	byID := make(map[int]Record)
+	if err := w.queryRecords(ctx, core.F(2).OAF(after).String(), byID); err != nil {
+		return nil, err
+	}
+
+	var missing []int
+	for _, e := range events {
+		if e.Type != ChangeDelete && byID[e.RecordId] == nil {
+			missing = append(missing, e.RecordId)
+		}
+	}
+	for chunk := range slices.Chunk(missing, watchIDChunkSize) {
+		clauses := make([]core.Condition, len(chunk))
+		for i, id := range chunk {
+			clauses[i] = core.F(3).EX(id)
+		}
+		if err := w.queryRecords(ctx, core.Or(clauses...).String(), byID); err != nil {
+			return nil, err
+		}
+	}
+	return byID, nil
+}
+
+// watchIDChunkSize is the number of record IDs per query when fetching
+// records by ID.
+const watchIDChunkSize = 100
+
+// queryRecords runs a query with the watch's selected fields, adding the
+// results to byID.
+func (w *watcher) queryRecords(ctx context.Context, where string, byID map[int]Record) error {
+	body := generated.RunQueryJSONRequestBody{From: w.tableID}
+	if len(w.opts.Select) > 0 {
+		fields := slices.Clone(w.opts.Select)
+		if !slices.Contains(fields, 3) {
+			fields = append(fields, 3)
+		}
+		body.Select = &fields
+	}
+	whereUnion, err := StringToWhereUnion(where)
+	if err != nil {
+		return err
+	}
+	body.Where = whereUnion
+
+	records, err := w.client.RunQueryAll(ctx, body)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		r := unwrapRecordAs(record, w.client.numbers)
+		if id, ok := recordIDValue(r["3"]); ok {
+			byID[id] = r
+		}
+	}
+	return nil
+}
+
+// recordIDValue reads a record ID from an unwrapped record value, whatever the
+// client's number mode.
+func recordIDValue(v any) (int, bool) {
+	switch id := v.(type) {
+	case float64:
+		return int(id), true
+	case json.Number:
+		n, err := id.Int64()
+		return int(n), err == nil
+	case core.Decimal:
+		return int(id.Float64()), true
+	}
+	return 0, false
+}
@@ -0,0 +1,174 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/qbtest"
+)
+
+// testClock is a settable clock for the fake server.
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newWatchTestServer(t *testing.T) (*qbtest.Server, *Client, string, *testClock) {
+	t.Helper()
+	clock := &testClock{now: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)}
+	srv, tableID := newFakeTable(t, "Orders",
+		[]qbtest.Field{{Label: "Name", Type: "text"}}, // 6
+		qbtest.WithClock(clock.Now))
+	c := newFakeClient(t, srv)
+	return srv, c, tableID, clock
+}
+
+func TestWatch(t *testing.T) {
+	srv, c, tableID, clock := newWatchTestServer(t)
+	start := clock.Now()
+	clock.Advance(time.Second)
+	srv.AddRecords(tableID, map[int]any{6: "Alpha"}, map[int]any{6: "Beta"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	store := NewMemoryCheckpointStore()
+
+	var got []string
+	for event, err := range c.Watch(ctx, tableID, WatchOptions{Interval: time.Millisecond, Since: start, Checkpoints: store}) {
+		if err != nil {
+			t.Fatalf("Watch() error = %v", err)
+		}
+		got = append(got, fmt.Sprintf("%s %d %v", event.Type, event.RecordId, event.Record["6"]))
+
+		if len(got) == 2 {
+			clock.Advance(time.Second)
+			data := map[string]any{"3": map[string]any{"value": 1}, "6": map[string]any{"value": "Alpha 2"}}
+			if _, err := c.Upsert(tableID).Data(data).MergeFieldId(3).Run(ctx); err != nil {
+				t.Fatalf("Upsert() error = %v", err)
+			}
+			clock.Advance(time.Second)
+			if _, err := c.DeleteRecords(tableID).Where("{3.EX.2}").Run(ctx); err != nil {
+				t.Fatalf("DeleteRecords() error = %v", err)
+			}
+		}
+		if len(got) == 4 {
+			break
+		}
+	}
+
+	want := []string{"CREATE 1 Alpha", "CREATE 2 Beta", "MODIFY 1 Alpha 2", "DELETE 2 <nil>"}
+	if !slices.Equal(got, want) {
+		t.Errorf("events = %q, want %q", got, want)
+	}
+
+	// The first poll's checkpoint was saved; the break stops before the second's
+	checkpoint, ok, _ := store.Load(ctx, tableID)
+	if !ok || !checkpoint.Equal(start.Add(2*time.Second)) {
+		t.Errorf("checkpoint = %v (saved %v), want %v", checkpoint, ok, start.Add(2*time.Second))
+	}
+}
+
+func TestWatch_ResumesFromCheckpoint(t *testing.T) {
+	srv, c, tableID, clock := newWatchTestServer(t)
+	start := clock.Now()
+	store := NewFileCheckpointStore(filepath.Join(t.TempDir(), "checkpoints.json"))
+	opts := WatchOptions{Interval: time.Millisecond, Since: start, Checkpoints: store, Overlap: time.Millisecond}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for _, name := range []string{"Alpha", "Beta", "Gamma"} {
+		clock.Advance(time.Second)
+		srv.AddRecords(tableID, map[int]any{6: name})
+	}
+
+	// Process records 1 and 2, then stop on record 3 as if the process exited
+	var first []int
+	for event, err := range c.Watch(ctx, tableID, opts) {
+		if err != nil {
+			t.Fatalf("Watch() error = %v", err)
+		}
+		first = append(first, event.RecordId)
+		if len(first) == 3 {
+			break
+		}
+	}
+	if !slices.Equal(first, []int{1, 2, 3}) {
+		t.Fatalf("first events = %v, want [1 2 3]", first)
+	}
+
+	clock.Advance(time.Second)
+	srv.AddRecords(tableID, map[int]any{6: "Delta"})
+
+	// A new watch with the same store resumes at record 2's checkpoint. Record
+	// 2 is inside the overlap, so it is delivered again.
+	var ids []int
+	for event, err := range c.Watch(ctx, tableID, opts) {
+		if err != nil {
+			t.Fatalf("Watch() error = %v", err)
+		}
+		ids = append(ids, event.RecordId)
+		if event.RecordId == 4 {
+			break
+		}
+	}
+	if !slices.Equal(ids, []int{2, 3, 4}) {
+		t.Errorf("resumed events = %v, want [2 3 4]", ids)
+	}
+}
+
+func TestWatch_Cancel(t *testing.T) {
+	_, c, tableID, _ := newWatchTestServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for _, err := range c.Watch(ctx, tableID, WatchOptions{Interval: time.Millisecond}) {
+			if err != nil {
+				t.Errorf("Watch() error = %v", err)
+			}
+		}
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Watch did not stop after cancel")
+	}
+}
+
+func TestFileCheckpointStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoints.json")
+	ctx := context.Background()
+	ts := time.Date(2026, 3, 1, 12, 0, 0, 123000000, time.UTC)
+
+	if _, ok, err := NewFileCheckpointStore(path).Load(ctx, "orders"); ok || err != nil {
+		t.Fatalf("Load() on missing file = %v, %v", ok, err)
+	}
+	if err := NewFileCheckpointStore(path).Save(ctx, "orders", ts); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	got, ok, err := NewFileCheckpointStore(path).Load(ctx, "orders")
+	if err != nil || !ok || !got.Equal(ts) {
+		t.Errorf("Load() = %v, %v, %v, want %v", got, ok, err, ts)
+	}
+}
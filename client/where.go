@@ -0,0 +1,60 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/core"
+)
+
+// --- Where Clause Builder Integration ---
+// Builders accept either a raw where string or a core.Condition built with
+// core.F. Both are validated when passed to the builder, so syntax, alias and
+// operator errors surface from Run like other builder errors.
+
+// resolveWhere renders a where value for a table. Conditions are built with the
+// client's schema; strings are validated and their aliases rewritten to IDs.
+// Other values (such as record ID slices for deleteRecords) are passed through.
+func resolveWhere(c *Client, tableID string, value any) (any, error) {
+	switch v := value.(type) {
+	case string:
+		return core.TransformWhere(v, c.schema, tableID)
+	case core.Condition:
+		return v.Build(c.schema, tableID)
+	case *core.Condition:
+		if v == nil {
+			return "", nil
+		}
+		return v.Build(c.schema, tableID)
+	}
+	return value, nil
+}
+
+// whereString renders a where value that must be a query string.
+func whereString(c *Client, tableID string, value any) (string, error) {
+	resolved, err := resolveWhere(c, tableID, value)
+	if err != nil {
+		return "", err
+	}
+	s, ok := resolved.(string)
+	if !ok {
+		return "", &core.ValidationError{
+			QuickbaseError: core.QuickbaseError{
+				Message: fmt.Sprintf("where must be a string or core.Condition, got %T", value),
+			},
+		}
+	}
+	return s, nil
+}
+
+// andWhere joins two where clauses, grouping the first so its ORs stay scoped.
+func andWhere(where, clause string) string {
+	return "(" + where + ")AND" + clause
+}
+
+// Since sets the after parameter from a time, formatted as ISO-8601 UTC.
+// recordsModifiedSince has no where parameter; to filter the changed records,
+// query them with a condition such as core.F(2).OAF(t).
+func (b *RecordsModifiedSinceBuilder) Since(t time.Time) *RecordsModifiedSinceBuilder {
+	return b.After(t.UTC().Format("2006-01-02T15:04:05.000Z"))
+}
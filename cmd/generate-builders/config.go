@@ -13,6 +13,9 @@ type AliasConfig struct {
 
 	// FieldStructParams are struct field paths that contain field IDs (e.g., "sortBy[].fieldId")
 	FieldStructParams []string
+
+	// WhereParams are parameter names that accept a where clause string or core.Condition
+	WhereParams []string
 }
 
 // aliasRules defines the alias resolution rules for the builder generator.
@@ -43,6 +46,11 @@ var aliasRules = AliasConfig{
 		"sortBy[].fieldId",  // runQuery
 		"groupBy[].fieldId", // runQuery
 	},
+
+	// Parameters that take a where clause (conditions resolved via schema)
+	WhereParams: []string{
+		"where", // deleteRecords
+	},
 }
 
 // ConstructorConfig defines which parameters go in the constructor vs chainable methods
@@ -92,11 +100,10 @@ var constructorRules = ConstructorConfig{
 	},
 }
 
-// paginatedOperations lists operations that support pagination
-var paginatedOperations = map[string]bool{
-	"runQuery":  true,
-	"runReport": true,
-}
+// paginationTokens are the request body properties that carry a page token.
+// Operations whose request and 200 response both contain one of these are
+// detected as token-paginated (see extractPagination).
+var paginationTokens = []string{"nextToken", "nextPageToken"}
 
 // manualImplementations lists operations that have fully manual implementations in api.go
 // These operations are completely skipped in code generation.
@@ -162,6 +169,16 @@ func isFieldArrayParam(name string) bool {
 	return false
 }
 
+// isWhereParam checks if a parameter name takes a where clause
+func isWhereParam(name string) bool {
+	for _, p := range aliasRules.WhereParams {
+		if p == name {
+			return true
+		}
+	}
+	return false
+}
+
 // isFieldStructParam checks if a struct field path should support field alias resolution
 func isFieldStructParam(path string) bool {
 	for _, p := range aliasRules.FieldStructParams {
@@ -203,3 +220,15 @@ var rawResponseOperations = map[string]bool{
 func shouldReturnRawResponse(opID string) bool {
 	return rawResponseOperations[opID]
 }
+
+// recordResultOperations lists operations whose result wrapper unwraps
+// records, and so needs the client's number mode.
+var recordResultOperations = map[string]bool{
+	"runReport": true,
+	"upsert":    true,
+}
+
+// returnsRecords returns true if the operation's result wrapper unwraps records.
+func returnsRecords(opID string) bool {
+	return recordResultOperations[opID]
+}
@@ -6,6 +6,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"regexp"
 	"sort"
@@ -86,10 +87,34 @@ type BuilderSpec struct {
 	ResultTypeName   string          // Name of the result type (e.g., "RunQueryResult")
 	ResponseIsArray  bool            // Whether the response is an array at root level
 	ResponseItemType string          // For array responses, the item type
-	HasPagination    bool            // Whether this operation supports pagination
+	Pagination       *PaginationSpec // Token pagination (nil if the operation is not token-paginated)
 	Transform        TransformSpec   // Response transformation config
 }
 
+// PaginationSpec describes how to follow page tokens for an operation.
+// It is derived from the spec: the request body has a token property and the
+// 200 response returns the next token.
+type PaginationSpec struct {
+	RequestToken string      // Request body property for the page token (e.g., "nextToken")
+	TokenPath    []FieldPath // Path to the next token in the response
+	ItemsPath    []FieldPath // Path to the top-level items array (nil to iterate pages)
+	ItemType     string      // Type yielded by Iter or Pages (e.g., "generated.AuditEventsItem")
+	Carry        []CarryField
+}
+
+// FieldPath is one step in a path through a generated response struct.
+type FieldPath struct {
+	GoName  string // Go field name (e.g., "NextToken")
+	Pointer bool   // Whether the field is optional (a pointer in the generated type)
+}
+
+// CarryField is a response property echoed back in the next page's request,
+// such as the audit queryId.
+type CarryField struct {
+	Param  string      // Request body property
+	Path   []FieldPath // Path to the value in the response
+}
+
 type ConstructorArg struct {
 	Name string
 	Type string
@@ -228,7 +253,6 @@ func extractBuilder(path string, op Operation) BuilderSpec {
 		Summary:        op.Summary,
 		ResultTypeName: opName + "Result",
 		HasBody:        op.RequestBody != nil,
-		HasPagination:  isPaginatedOperation(op.OperationID),
 	}
 
 	// Extract all parameters
@@ -324,6 +348,9 @@ func extractBuilder(path string, op Operation) BuilderSpec {
 		}
 	}
 
+	// Detect token pagination from the request and response schemas
+	builder.Pagination = extractPagination(op, opName)
+
 	// Skip result type generation for operations with manual result types
 	if shouldSkipResultType(op.OperationID) {
 		builder.ResponseFields = nil
@@ -584,9 +611,154 @@ func toCamelCase(s string) string {
 	return string(runes)
 }
 
-// isPaginatedOperation returns true if the operation supports pagination
-func isPaginatedOperation(opID string) bool {
-	return paginatedOperations[opID]
+// extractPagination detects token pagination: the request body has a
+// paginationTokens property and the 200 response returns one. Items are
+// yielded from the first array of objects at the top level of the response;
+// responses that nest their items yield whole pages instead, through Pages,
+// AllPages and NPages so that Iter, All and N always count items.
+func extractPagination(op Operation, opName string) *PaginationSpec {
+	if op.RequestBody == nil || shouldReturnRawResponse(op.OperationID) {
+		return nil
+	}
+	if _, ok := getResponseTransform(op.OperationID); ok {
+		return nil
+	}
+	if _, ok := getManualResultType(op.OperationID); ok {
+		return nil
+	}
+	reqContent, ok := op.RequestBody.Content["application/json"]
+	if !ok || reqContent.Schema == nil || reqContent.Schema.Properties == nil {
+		return nil
+	}
+	resp, ok := op.Responses["200"]
+	if !ok || resp == nil {
+		return nil
+	}
+	respContent, ok := resp.Content["application/json"]
+	if !ok || respContent.Schema == nil || respContent.Schema.Type == "array" {
+		return nil
+	}
+	reqProps := reqContent.Schema.Properties
+	respSchema := respContent.Schema
+
+	var p *PaginationSpec
+	for _, token := range paginationTokens {
+		if _, ok := reqProps[token]; !ok {
+			continue
+		}
+		for _, name := range paginationTokens {
+			if path := findResponseField(respSchema, name); path != nil {
+				p = &PaginationSpec{RequestToken: token, TokenPath: path}
+				break
+			}
+		}
+		if p != nil {
+			break
+		}
+	}
+	if p == nil {
+		return nil
+	}
+
+	for _, name := range sortedKeys(respSchema.Properties) {
+		prop := respSchema.Properties[name]
+		if _, echoed := reqProps[name]; echoed {
+			// A request property echoed back (e.g., audit queryId) is
+			// carried into the next page's request
+			if prop.Type == "string" && name != p.RequestToken {
+				p.Carry = append(p.Carry, CarryField{
+					Param: name,
+					Path:  []FieldPath{{GoName: snakeToPascal(name), Pointer: !contains(respSchema.Required, name)}},
+				})
+			}
+			continue
+		}
+		if p.ItemsPath == nil && prop.Type == "array" && prop.Items != nil && prop.Items.Type == "object" {
+			p.ItemsPath = []FieldPath{{GoName: snakeToPascal(name), Pointer: !contains(respSchema.Required, name)}}
+			p.ItemType = fmt.Sprintf("generated.%s%sItem", opName, snakeToPascal(name))
+		}
+	}
+	if p.ItemsPath == nil {
+		p.ItemType = "*" + getWrapperTypeName(BuilderSpec{OperationID: op.OperationID})
+	}
+	return p
+}
+
+// findResponseField returns the path to the first string property named name,
+// searching depth-first through nested objects in property name order.
+func findResponseField(schema *Schema, name string) []FieldPath {
+	if schema == nil || schema.Properties == nil {
+		return nil
+	}
+	for _, propName := range sortedKeys(schema.Properties) {
+		prop := schema.Properties[propName]
+		step := FieldPath{GoName: snakeToPascal(propName), Pointer: !contains(schema.Required, propName)}
+		if propName == name && prop.Type == "string" {
+			return []FieldPath{step}
+		}
+		if prop.Type == "object" {
+			if rest := findResponseField(prop, name); rest != nil {
+				return append([]FieldPath{step}, rest...)
+			}
+		}
+	}
+	return nil
+}
+
+// fieldAssignment returns code that assigns the response field at path to
+// target, guarding optional fields against nil. Empty strings are skipped when
+// skipEmpty is set.
+func fieldAssignment(target string, path []FieldPath, skipEmpty bool, indent string) string {
+	accessor := "resp.JSON200"
+	var conds []string
+	value := ""
+	for i, step := range path {
+		accessor += "." + step.GoName
+		if step.Pointer {
+			conds = append(conds, accessor+" != nil")
+		}
+		if i == len(path)-1 {
+			value = accessor
+			if step.Pointer {
+				value = "*" + accessor
+			}
+		}
+	}
+	if skipEmpty {
+		conds = append(conds, value+` != ""`)
+	}
+	if len(conds) == 0 {
+		return fmt.Sprintf("%s%s = %s", indent, target, value)
+	}
+	return fmt.Sprintf("%sif %s {\n%s\t%s = %s\n%s}", indent, strings.Join(conds, " && "), indent, target, value, indent)
+}
+
+// hasPagination checks if any builder is token-paginated
+func hasPagination(builders []BuilderSpec) bool {
+	for _, b := range builders {
+		if b.Pagination != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]*Schema) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func contains(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
 }
 
 // resultTypeGoName generates the Go type to use in result struct
@@ -620,6 +792,18 @@ func assignmentCode(rf ResponseField, opName string) string {
 }
 
 func generateCode(builders []BuilderSpec) error {
+	outputPath := findOutputPath()
+	f, err := os.Create(outputPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return renderBuilders(f, builders)
+}
+
+// renderBuilders executes the builders template for builders into w.
+func renderBuilders(w io.Writer, builders []BuilderSpec) error {
 	titleCaser := cases.Title(language.English)
 	_ = titleCaser
 
@@ -633,6 +817,7 @@ func generateCode(builders []BuilderSpec) error {
 		"hasManualResultType":     func(opID string) bool { _, ok := getManualResultType(opID); return ok },
 		"isFieldParam":            isFieldParam,
 		"isFieldArrayParam":       isFieldArrayParam,
+		"isWhereParam":            isWhereParam,
 		"needsResultType":         needsResultType,
 		"hasTransform":            hasTransform,
 		"transformResultType":     transformResultType,
@@ -645,18 +830,14 @@ func generateCode(builders []BuilderSpec) error {
 		"getDataTypeName":         getDataTypeName,
 		"getWrapperTypeName":      getWrapperTypeName,
 		"shouldReturnRawResponse": shouldReturnRawResponse,
+		"returnsRecords":          returnsRecords,
+		"hasPagination":           hasPagination,
+		"fieldAssignment":         fieldAssignment,
 	}
 
 	tmpl := template.Must(template.New("builders").Funcs(funcMap).Parse(buildersTemplate))
 
-	outputPath := findOutputPath()
-	f, err := os.Create(outputPath)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-
-	return tmpl.Execute(f, struct {
+	return tmpl.Execute(w, struct {
 		Builders []BuilderSpec
 	}{
 		Builders: builders,
@@ -822,6 +1003,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+{{- if hasPagination .Builders}}
+	"iter"
+	"maps"
+{{- end}}
 	"strconv"
 {{if hasImportTime .Builders}}
 	"time"
@@ -886,6 +1071,7 @@ type {{$b.BuilderName}} struct {
 {{- end}}
 {{- end}}
 	params  map[string]any
+	retry   RetryPolicy
 	err     error
 }
 
@@ -938,6 +1124,13 @@ func (b *{{$b.BuilderName}}) {{$f.MethodName}}(value {{$f.ParamType}}) *{{$b.Bui
 	b.params["{{$f.ParentField}}"] = nested
 {{- else if $f.IsArray}}
 	b.params["{{$f.ParamName}}"] = values
+{{- else if and (isWhereParam $f.ParamName) $b.HasTable}}
+	resolved, err := resolveWhere(b.client, b.tableID, value)
+	if err != nil {
+		b.err = err
+		return b
+	}
+	b.params["{{$f.ParamName}}"] = resolved
 {{- else}}
 	b.params["{{$f.ParamName}}"] = value
 {{- end}}
@@ -959,6 +1152,12 @@ func (b *{{$b.BuilderName}}) {{$qp.GoName}}(value {{$qp.GenType}}) *{{$b.Builder
 
 {{end}}
 {{- end}}
+// RetryPolicy overrides the client's retry policy for this request.
+func (b *{{$b.BuilderName}}) RetryPolicy(policy RetryPolicy) *{{$b.BuilderName}} {
+	b.retry = policy
+	return b
+}
+
 // Run executes the {{$b.OperationID}} request and returns the response data directly.
 {{- if hasTransform $b}}
 {{- if $b.Transform.IsArrayResponse}}
@@ -980,6 +1179,7 @@ func (b *{{$b.BuilderName}}) Run(ctx context.Context) (*{{getWrapperTypeName $b}
 	if b.err != nil {
 		return nil, b.err
 	}
+	ctx = withRetryPolicy(ctx, b.retry)
 
 {{- /* Validate required body fields */}}
 {{- range $b.RequiredFields}}
@@ -1142,10 +1342,93 @@ func (b *{{$b.BuilderName}}) Run(ctx context.Context) (*{{getWrapperTypeName $b}
 		items[i] = &{{getWrapperTypeName $b}}{&(*resp.JSON200)[i]}
 	}
 	return items, nil
+{{- else if returnsRecords $b.OperationID}}
+	return &{{getWrapperTypeName $b}}{resp: resp, numbers: b.client.numbers}, nil
 {{- else}}
 	return &{{getWrapperTypeName $b}}{resp: resp}, nil
 {{- end}}
 }
+{{- if $b.Pagination}}
+{{- $p := $b.Pagination}}
+{{- $page := printf "%sPage" (toCamel $b.MethodName)}}
+
+// {{$page}} adapts a {{$b.OperationID}} response to PaginatedResponse.
+type {{$page}} struct {
+	items []{{$p.ItemType}}
+	next  string
+}
+
+func (p *{{$page}}) GetData() []{{$p.ItemType}} {
+	return p.items
+}
+
+func (p *{{$page}}) GetMetadata() PaginationMetadata {
+	return PaginationMetadata{NextToken: &p.next}
+}
+
+// fetcher returns a PageFetcher that reruns the request with each page's {{$p.RequestToken}}.
+func (b *{{$b.BuilderName}}) fetcher() PageFetcher[{{$p.ItemType}}, *{{$page}}] {
+	params := maps.Clone(b.params)
+	return func(ctx context.Context, skip int, nextToken string) (*{{$page}}, error) {
+		req := *b
+		req.params = maps.Clone(params)
+		if nextToken != "" {
+			req.params["{{$p.RequestToken}}"] = nextToken
+		}
+		result, err := req.Run(ctx)
+		if err != nil {
+			return nil, err
+		}
+		resp := result.resp
+
+		page := &{{$page}}{}
+{{- if $p.ItemsPath}}
+{{fieldAssignment "page.items" $p.ItemsPath false "\t\t"}}
+{{- else}}
+		page.items = []{{$p.ItemType}}{result}
+{{- end}}
+{{fieldAssignment "page.next" $p.TokenPath false "\t\t"}}
+{{- range $p.Carry}}
+{{fieldAssignment (printf "params[%q]" .Param) .Path true "\t\t"}}
+{{- end}}
+		return page, nil
+	}
+}
+
+{{- if $p.ItemsPath}}
+
+// Iter returns an iterator over the items of every page, following {{$p.RequestToken}}.
+func (b *{{$b.BuilderName}}) Iter(ctx context.Context) iter.Seq2[{{$p.ItemType}}, error] {
+	return Paginate(ctx, b.fetcher())
+}
+
+// All fetches every page and returns all items.
+func (b *{{$b.BuilderName}}) All(ctx context.Context) ([]{{$p.ItemType}}, error) {
+	return CollectAll(ctx, b.fetcher())
+}
+
+// N fetches up to n items across pages.
+func (b *{{$b.BuilderName}}) N(ctx context.Context, n int) ([]{{$p.ItemType}}, error) {
+	return CollectN(ctx, b.fetcher(), n)
+}
+{{- else}}
+
+// Pages returns an iterator over every page, following {{$p.RequestToken}}.
+func (b *{{$b.BuilderName}}) Pages(ctx context.Context) iter.Seq2[{{$p.ItemType}}, error] {
+	return Paginate(ctx, b.fetcher())
+}
+
+// AllPages fetches and returns every page.
+func (b *{{$b.BuilderName}}) AllPages(ctx context.Context) ([]{{$p.ItemType}}, error) {
+	return CollectAll(ctx, b.fetcher())
+}
+
+// NPages fetches up to n pages.
+func (b *{{$b.BuilderName}}) NPages(ctx context.Context, n int) ([]{{$p.ItemType}}, error) {
+	return CollectN(ctx, b.fetcher(), n)
+}
+{{- end}}
+{{- end}}
 
 {{end}}
 // Ensure imports are used
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+// paginationBlock returns the pagination code generated for a builder, from
+// its page type through its last pagination method.
+func paginationBlock(t *testing.T, src, page string) string {
+	t.Helper()
+	start := strings.Index(src, "// "+page+" adapts")
+	if start < 0 {
+		t.Fatalf("%s not found", page)
+	}
+	end := strings.Index(src[start:], "\n}\n\n\n")
+	if end < 0 {
+		t.Fatalf("end of %s block not found", page)
+	}
+	return src[start : start+end+2]
+}
+
+// TestPagination_MatchesGenerated regenerates the token-paginated builders
+// from testdata/pagination.json, which holds the parts of the OpenAPI spec
+// that extractPagination reads, and checks the output against
+// client/builders_generated.go.
+func TestPagination_MatchesGenerated(t *testing.T) {
+	data, err := os.ReadFile("testdata/pagination.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var spec OpenAPI
+	if err := json.Unmarshal(data, &spec); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := renderBuilders(&buf, extractBuilders(&spec)); err != nil {
+		t.Fatalf("renderBuilders() error = %v", err)
+	}
+	checkedIn, err := os.ReadFile("../../client/builders_generated.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, page := range []string{"auditPage", "getUsersPage", "platformAnalyticEventSummariesPage"} {
+		got := paginationBlock(t, buf.String(), page)
+		want := paginationBlock(t, string(checkedIn), page)
+		if got != want {
+			t.Errorf("%s: generated code differs from client/builders_generated.go\ngot:\n%s\nwant:\n%s", page, got, want)
+		}
+	}
+
+	if strings.Contains(paginationBlock(t, buf.String(), "platformAnalyticEventSummariesPage"), ") N(") {
+		t.Error("page-level builder has N; want NPages")
+	}
+}
@@ -631,6 +631,9 @@ import (
 // {{$resp.WrapperName}} wraps {{$resp.Name}} with convenience methods.
 type {{$resp.WrapperName}} struct {
 	resp *generated.{{$resp.Name}}
+{{- if $resp.HasRecordData}}
+	numbers NumberMode // How Records decodes numbers
+{{- end}}
 }
 
 // Raw returns the underlying generated response.
@@ -706,7 +709,7 @@ func (r *{{$resp.WrapperName}}) Records() []Record {
 	if r == nil || r.resp == nil || r.resp.JSON200 == nil || r.resp.JSON200.Data == nil {
 		return nil
 	}
-	return unwrapRecords(*r.resp.JSON200.Data)
+	return unwrapRecordsAs(*r.resp.JSON200.Data, r.numbers)
 }
 {{end}}
 {{end}}
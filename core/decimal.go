@@ -0,0 +1,108 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Decimal is an exact decimal number, for numeric and currency values that
+// must not pass through float64. It keeps the number of fractional digits it
+// was parsed with, so 12.50 is written back as 12.50.
+//
+// The zero Decimal is 0.
+//
+// Example:
+//
+//	price, _ := core.ParseDecimal("19.99")
+//	total := new(big.Rat).Mul(price.Rat(), big.NewRat(3, 1))
+//	fmt.Println(core.NewDecimal(total, 2)) // 59.97
+type Decimal struct {
+	rat   *big.Rat
+	scale int // Digits after the decimal point
+}
+
+// ParseDecimal parses a decimal number such as "-1234.50" or "1.5e3".
+func ParseDecimal(s string) (Decimal, error) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok || strings.Contains(s, "/") {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	return Decimal{rat: r, scale: decimalScale(s)}, nil
+}
+
+// NewDecimal creates a Decimal from a rational number, rounded to scale
+// fractional digits.
+func NewDecimal(r *big.Rat, scale int) Decimal {
+	d, _ := ParseDecimal(r.FloatString(max(scale, 0)))
+	return d
+}
+
+// decimalScale returns the number of fractional digits in a decimal string,
+// accounting for an exponent.
+func decimalScale(s string) int {
+	mantissa, exp, _ := strings.Cut(strings.ToLower(s), "e")
+	scale := 0
+	if _, frac, ok := strings.Cut(mantissa, "."); ok {
+		scale = len(frac)
+	}
+	if exp != "" {
+		var e int
+		fmt.Sscan(exp, &e)
+		scale -= e
+	}
+	return max(scale, 0)
+}
+
+// Rat returns the value as a new big.Rat.
+func (d Decimal) Rat() *big.Rat {
+	if d.rat == nil {
+		return new(big.Rat)
+	}
+	return new(big.Rat).Set(d.rat)
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int {
+	return d.scale
+}
+
+// Float64 returns the nearest float64 value.
+func (d Decimal) Float64() float64 {
+	if d.rat == nil {
+		return 0
+	}
+	f, _ := d.rat.Float64()
+	return f
+}
+
+// String returns the value in decimal notation.
+func (d Decimal) String() string {
+	if d.rat == nil {
+		return "0"
+	}
+	return d.rat.FloatString(d.scale)
+}
+
+// MarshalJSON writes the value as a JSON number.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON reads a JSON number, or a string holding one.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("invalid decimal %s", data)
+	}
+	parsed, err := ParseDecimal(n.String())
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
@@ -0,0 +1,90 @@
+package core
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"19.99", "19.99"},
+		{"-1234.50", "-1234.50"},
+		{"12345678901234567", "12345678901234567"},
+		{"0.000000000000000001", "0.000000000000000001"},
+		{"1.5e3", "1500"},
+		{"1.25e-1", "0.125"},
+		{"0", "0"},
+	}
+	for _, tt := range tests {
+		d, err := ParseDecimal(tt.in)
+		if err != nil {
+			t.Errorf("ParseDecimal(%q) error = %v", tt.in, err)
+			continue
+		}
+		if got := d.String(); got != tt.want {
+			t.Errorf("ParseDecimal(%q).String() = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", "abc", "1/3", "1,000"} {
+		if _, err := ParseDecimal(in); err == nil {
+			t.Errorf("ParseDecimal(%q) succeeded, want error", in)
+		}
+	}
+}
+
+func TestDecimal_Arithmetic(t *testing.T) {
+	price, _ := ParseDecimal("0.10")
+	total := new(big.Rat).Mul(price.Rat(), big.NewRat(3, 1))
+	if got := NewDecimal(total, 2).String(); got != "0.30" {
+		t.Errorf("0.10 * 3 = %s, want 0.30", got)
+	}
+	// Rat returns a copy
+	price.Rat().SetInt64(5)
+	if price.String() != "0.10" {
+		t.Errorf("Rat() modified the Decimal: %s", price)
+	}
+	if (Decimal{}).String() != "0" || (Decimal{}).Float64() != 0 {
+		t.Error("zero Decimal is not 0")
+	}
+}
+
+func TestDecimal_JSON(t *testing.T) {
+	var v struct {
+		Amount Decimal  `json:"amount"`
+		Quoted Decimal  `json:"quoted"`
+		Null   *Decimal `json:"null"`
+	}
+	in := `{"amount":1234567890.123456789,"quoted":"12.50","null":null}`
+	if err := json.Unmarshal([]byte(in), &v); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if v.Amount.String() != "1234567890.123456789" || v.Quoted.String() != "12.50" || v.Null != nil {
+		t.Errorf("Unmarshal() = %+v", v)
+	}
+
+	out, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if want := `{"amount":1234567890.123456789,"quoted":12.50,"null":null}`; string(out) != want {
+		t.Errorf("Marshal() = %s, want %s", out, want)
+	}
+
+	var d Decimal
+	if err := json.Unmarshal([]byte(`true`), &d); err == nil {
+		t.Error("Unmarshal(true) succeeded, want error")
+	}
+}
+
+func TestEvaluateWhere_Decimal(t *testing.T) {
+	amount, _ := ParseDecimal("5000.25")
+	ok, err := EvaluateWhere(map[string]any{"7": amount}, "{7.GT.5000}")
+	if err != nil || !ok {
+		t.Errorf("EvaluateWhere() = %v, %v, want true", ok, err)
+	}
+}
@@ -333,3 +333,22 @@ func NewReadOnlyError(method, path, action string) *ReadOnlyError {
 		Action: action,
 	}
 }
+
+// MappingError is returned when a record value cannot be converted to or from
+// a tagged struct field (see QueryInto and UpsertFrom in the client package).
+//
+// Index is the position of the record in the query results or upsert input.
+type MappingError struct {
+	Type    string `json:"type"`
+	Field   string `json:"field"`
+	FieldID int    `json:"fieldId,omitempty"`
+	Index   int    `json:"index"`
+	Message string `json:"message"`
+}
+
+func (e *MappingError) Error() string {
+	if e.FieldID != 0 {
+		return fmt.Sprintf("record %d: %s.%s (field %d): %s", e.Index, e.Type, e.Field, e.FieldID, e.Message)
+	}
+	return fmt.Sprintf("record %d: %s.%s: %s", e.Index, e.Type, e.Field, e.Message)
+}
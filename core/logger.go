@@ -1,15 +1,45 @@
 package core
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"log/slog"
+	"strings"
 	"time"
 )
 
-// Logger provides debug logging for the QuickBase SDK.
+// Logger provides logging for the QuickBase SDK.
+//
+// A Logger created with NewLogger prints formatted lines through the standard
+// log package when debug is enabled. A Logger created with NewSlogLogger emits
+// structured records to a *slog.Logger, with attributes such as method, path,
+// dbid, attempt, status, ray IDs and duration.
 type Logger struct {
 	enabled bool
 	prefix  string
+	slog    *slog.Logger
+	levels  LogLevels
+}
+
+// LogLevels sets the level of each kind of structured record.
+type LogLevels struct {
+	Request      slog.Level // Completed requests, with timing (default Debug)
+	Retry        slog.Level // Retries after errors, 429s and 5xx responses (default Info)
+	RateLimit    slog.Level // 429 responses (default Warn)
+	TokenRefresh slog.Level // Token refreshes after auth errors (default Info)
+	XML          slog.Level // Legacy XML API calls (default Debug)
+}
+
+// DefaultLogLevels returns the default level of each kind of record.
+func DefaultLogLevels() LogLevels {
+	return LogLevels{
+		Request:      slog.LevelDebug,
+		Retry:        slog.LevelInfo,
+		RateLimit:    slog.LevelWarn,
+		TokenRefresh: slog.LevelInfo,
+		XML:          slog.LevelDebug,
+	}
 }
 
 // NewLogger creates a new logger.
@@ -17,7 +47,48 @@ func NewLogger(enabled bool) *Logger {
 	return &Logger{
 		enabled: enabled,
 		prefix:  "quickbase-go",
+		levels:  DefaultLogLevels(),
+	}
+}
+
+// NewSlogLogger creates a logger that writes structured records to l, at the
+// given levels. Records are filtered by l's handler.
+func NewSlogLogger(l *slog.Logger, levels LogLevels) *Logger {
+	return &Logger{
+		enabled: true,
+		prefix:  "quickbase-go",
+		slog:    l,
+		levels:  levels,
+	}
+}
+
+// Slog returns the underlying *slog.Logger, or nil if the logger prints
+// through the standard log package.
+func (l *Logger) Slog() *slog.Logger {
+	return l.slog
+}
+
+// Levels returns the level of each kind of structured record.
+func (l *Logger) Levels() LogLevels {
+	return l.levels
+}
+
+// LogAttrs emits a structured record. Without a *slog.Logger, the record is
+// printed as "message key=value ..." when debug is enabled.
+func (l *Logger) LogAttrs(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	if l.slog != nil {
+		l.slog.LogAttrs(ctx, level, msg, attrs...)
+		return
+	}
+	if !l.enabled {
+		return
 	}
+	var b strings.Builder
+	b.WriteString(msg)
+	for _, a := range attrs {
+		fmt.Fprintf(&b, " %s=%v", a.Key, a.Value)
+	}
+	log.Println(l.formatMessage(level.String(), b.String()))
 }
 
 func (l *Logger) formatMessage(level, message string) string {
@@ -29,8 +100,20 @@ func (l *Logger) formatMessage(level, message string) string {
 	)
 }
 
+// logf routes a printf-style message to slog, if configured.
+func (l *Logger) logf(level slog.Level, message string, args []any) {
+	if len(args) > 0 {
+		message = fmt.Sprintf(message, args...)
+	}
+	l.slog.Log(context.Background(), level, message)
+}
+
 // Debug logs a debug message (only if debug is enabled).
 func (l *Logger) Debug(message string, args ...any) {
+	if l.slog != nil {
+		l.logf(slog.LevelDebug, message, args)
+		return
+	}
 	if l.enabled {
 		if len(args) > 0 {
 			message = fmt.Sprintf(message, args...)
@@ -41,6 +124,10 @@ func (l *Logger) Debug(message string, args ...any) {
 
 // Info logs an info message (only if debug is enabled).
 func (l *Logger) Info(message string, args ...any) {
+	if l.slog != nil {
+		l.logf(slog.LevelInfo, message, args)
+		return
+	}
 	if l.enabled {
 		if len(args) > 0 {
 			message = fmt.Sprintf(message, args...)
@@ -51,6 +138,10 @@ func (l *Logger) Info(message string, args ...any) {
 
 // Warn logs a warning message (always logged).
 func (l *Logger) Warn(message string, args ...any) {
+	if l.slog != nil {
+		l.logf(slog.LevelWarn, message, args)
+		return
+	}
 	if len(args) > 0 {
 		message = fmt.Sprintf(message, args...)
 	}
@@ -59,6 +150,10 @@ func (l *Logger) Warn(message string, args ...any) {
 
 // Error logs an error message (always logged).
 func (l *Logger) Error(message string, args ...any) {
+	if l.slog != nil {
+		l.logf(slog.LevelError, message, args)
+		return
+	}
 	if len(args) > 0 {
 		message = fmt.Sprintf(message, args...)
 	}
@@ -75,12 +75,19 @@ type ResolvedSchema struct {
 }
 
 // SchemaError is returned when an unknown table or field alias is used.
+// For where clause errors, Query, Pos and Token locate the offending token.
 type SchemaError struct {
 	Message string
+	Query   string // where clause being validated, if any
+	Pos     int    // byte offset of Token in Query
+	Token   string // offending token
 }
 
 func (e *SchemaError) Error() string {
-	return e.Message
+	if e.Query == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s (at position %d: %q)", e.Message, e.Pos, e.Token)
 }
 
 // ResolveSchema builds lookup maps from a schema definition.
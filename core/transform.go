@@ -5,8 +5,6 @@
 package core
 
 import (
-	"fmt"
-	"regexp"
 	"strconv"
 )
 
@@ -105,9 +103,12 @@ func TransformRequest(body map[string]any, schema *ResolvedSchema) (map[string]a
 		result["groupBy"] = resolved
 	}
 
-	// Resolve where clause (string replacement)
+	// Resolve where clause (parsed, validated and rewritten)
 	if where, ok := result["where"].(string); ok {
-		result["where"] = transformWhereClause(where, schema, tableID)
+		result["where"], err = transformWhereClause(where, schema, tableID)
+		if err != nil {
+			return nil, "", err
+		}
 	}
 
 	// Resolve data array (for upsert)
@@ -128,27 +129,10 @@ func TransformRequest(body map[string]any, schema *ResolvedSchema) (map[string]a
 	return result, tableID, nil
 }
 
-// wherePattern matches field references in where clauses: {'fieldAlias'. or {fieldAlias.
-var wherePattern = regexp.MustCompile(`\{['"]?([^.'"}\]]+)['"]?\.`)
-
 // transformWhereClause transforms field aliases in a where clause to field IDs.
-func transformWhereClause(where string, schema *ResolvedSchema, tableID string) string {
-	return wherePattern.ReplaceAllStringFunc(where, func(match string) string {
-		// Extract the field reference from the match
-		submatch := wherePattern.FindStringSubmatch(match)
-		if len(submatch) < 2 {
-			return match
-		}
-		fieldRef := submatch[1]
-
-		// Try to resolve as alias
-		fieldID, err := ResolveFieldAlias(schema, tableID, fieldRef)
-		if err != nil {
-			// If not found, keep original (might be a raw ID)
-			return match
-		}
-		return fmt.Sprintf("{%d.", fieldID)
-	})
+// Unknown aliases and malformed clauses return a *SchemaError.
+func transformWhereClause(where string, schema *ResolvedSchema, tableID string) (string, error) {
+	return TransformWhere(where, schema, tableID)
 }
 
 // transformRecordForRequest transforms a record's field alias keys to IDs.
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 )
@@ -231,6 +232,7 @@ func TestTransformWhereClause(t *testing.T) {
 		name     string
 		where    string
 		expected string
+		wantErr  bool
 	}{
 		{
 			name:     "simple alias",
@@ -253,15 +255,35 @@ func TestTransformWhereClause(t *testing.T) {
 			expected: "{7.EX.'Active'}AND{6.CT.'Project'}",
 		},
 		{
-			name:     "unknown alias left unchanged",
-			where:    "{'unknown'.EX.'test'}",
-			expected: "{'unknown'.EX.'test'}",
+			name:     "values and spacing preserved",
+			where:    "{'status'.EX.'a {name.x'} or ( {\"name\" . SW . Pro} )",
+			expected: "{7.EX.'a {name.x'} or ( {6. SW . Pro} )",
+		},
+		{
+			name:    "unknown alias errors",
+			where:   "{'unknown'.EX.'test'}",
+			wantErr: true,
+		},
+		{
+			name:    "unknown operator errors",
+			where:   "{'status'.EQ.'test'}",
+			wantErr: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := transformWhereClause(tt.where, schema, "bqw3ryzab")
+			result, err := transformWhereClause(tt.where, schema, "bqw3ryzab")
+			if tt.wantErr {
+				var schemaErr *SchemaError
+				if !errors.As(err, &schemaErr) {
+					t.Errorf("expected SchemaError, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
 			if result != tt.expected {
 				t.Errorf("transformWhereClause = %q, want %q", result, tt.expected)
 			}
@@ -0,0 +1,380 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// --- Where Clause Builder ---
+// A type-safe alternative to hand-written where strings like {6.EX.'foo'}.
+//
+//	cond := core.F("status").EX("Open").And(core.F("due").OBF(time.Now()))
+//	where, err := cond.Build(schema, tableID) // {7.EX.'Open'}AND{12.OBF.'1718000000000'}
+//
+// Values are quoted and escaped, dates are formatted for QuickBase, and field
+// aliases are resolved through the schema when the condition is built.
+
+// Where operators supported by the QuickBase query language.
+const (
+	OpContains           = "CT"
+	OpNotContains        = "XCT"
+	OpHas                = "HAS"
+	OpNotHas             = "XHAS"
+	OpEquals             = "EX"
+	OpNotEquals          = "XEX"
+	OpTrueValue          = "TV"
+	OpStartsWith         = "SW"
+	OpNotStartsWith      = "XSW"
+	OpBefore             = "BF"
+	OpOnOrBefore         = "OBF"
+	OpAfter              = "AF"
+	OpOnOrAfter          = "OAF"
+	OpInRange            = "IR"
+	OpNotInRange         = "XIR"
+	OpLessThan           = "LT"
+	OpLessThanOrEqual    = "LTE"
+	OpGreaterThan        = "GT"
+	OpGreaterThanOrEqual = "GTE"
+)
+
+// whereOperators is the set of valid where operators.
+var whereOperators = map[string]bool{
+	OpContains: true, OpNotContains: true, OpHas: true, OpNotHas: true,
+	OpEquals: true, OpNotEquals: true, OpTrueValue: true,
+	OpStartsWith: true, OpNotStartsWith: true,
+	OpBefore: true, OpOnOrBefore: true, OpAfter: true, OpOnOrAfter: true,
+	OpInRange: true, OpNotInRange: true,
+	OpLessThan: true, OpLessThanOrEqual: true, OpGreaterThan: true, OpGreaterThanOrEqual: true,
+}
+
+// IsWhereOperator reports whether op is a valid where operator (case-sensitive).
+func IsWhereOperator(op string) bool {
+	return whereOperators[op]
+}
+
+// FieldRef references a field in a where condition by ID (int) or alias (string).
+// Create one with F.
+type FieldRef struct {
+	field any
+}
+
+// F starts a condition on a field. The field can be an ID (int) or an alias
+// (string, resolved through the schema when the condition is built).
+//
+// Example:
+//
+//	core.F(7).EX("Open")
+//	core.F("dueDate").OBF(time.Now())
+func F(field any) FieldRef {
+	return FieldRef{field: field}
+}
+
+// Condition is a where clause built with F, And and Or.
+// The zero value is an empty condition that renders as an empty string.
+type Condition struct {
+	conj     string // "AND" or "OR" for groups, empty for a single clause
+	children []Condition
+	field    any
+	op       string
+	value    string
+	err      error
+}
+
+// Op builds a condition with an arbitrary operator. Unknown operators produce
+// an error when the condition is built. Prefer the named methods (EX, CT, ...).
+func (f FieldRef) Op(op string, value any) Condition {
+	c := Condition{field: f.field, op: op}
+	if !whereOperators[op] {
+		c.err = &SchemaError{Message: fmt.Sprintf("unknown where operator '%s'", op)}
+		return c
+	}
+	switch f.field.(type) {
+	case int, string:
+	default:
+		c.err = &SchemaError{Message: fmt.Sprintf("field reference must be int or string, got %T", f.field)}
+		return c
+	}
+	c.value, c.err = FormatWhereValue(value)
+	return c
+}
+
+// CT matches text fields containing the value.
+func (f FieldRef) CT(value any) Condition { return f.Op(OpContains, value) }
+
+// XCT matches text fields not containing the value.
+func (f FieldRef) XCT(value any) Condition { return f.Op(OpNotContains, value) }
+
+// HAS matches multi-select and list-user fields containing the value.
+func (f FieldRef) HAS(value any) Condition { return f.Op(OpHas, value) }
+
+// XHAS matches multi-select and list-user fields not containing the value.
+func (f FieldRef) XHAS(value any) Condition { return f.Op(OpNotHas, value) }
+
+// EX matches fields equal to the value. EX("") matches empty fields.
+func (f FieldRef) EX(value any) Condition { return f.Op(OpEquals, value) }
+
+// XEX matches fields not equal to the value.
+func (f FieldRef) XEX(value any) Condition { return f.Op(OpNotEquals, value) }
+
+// TV matches fields whose true value equals the value (e.g. a user ID for user fields).
+func (f FieldRef) TV(value any) Condition { return f.Op(OpTrueValue, value) }
+
+// SW matches text fields starting with the value.
+func (f FieldRef) SW(value any) Condition { return f.Op(OpStartsWith, value) }
+
+// XSW matches text fields not starting with the value.
+func (f FieldRef) XSW(value any) Condition { return f.Op(OpNotStartsWith, value) }
+
+// BF matches dates before the value.
+func (f FieldRef) BF(value any) Condition { return f.Op(OpBefore, value) }
+
+// OBF matches dates on or before the value.
+func (f FieldRef) OBF(value any) Condition { return f.Op(OpOnOrBefore, value) }
+
+// AF matches dates after the value.
+func (f FieldRef) AF(value any) Condition { return f.Op(OpAfter, value) }
+
+// OAF matches dates on or after the value.
+func (f FieldRef) OAF(value any) Condition { return f.Op(OpOnOrAfter, value) }
+
+// IR matches dates in a relative range such as "today", "last 7 days" or "this month".
+func (f FieldRef) IR(value any) Condition { return f.Op(OpInRange, value) }
+
+// XIR matches dates outside a relative range.
+func (f FieldRef) XIR(value any) Condition { return f.Op(OpNotInRange, value) }
+
+// LT matches values less than the value.
+func (f FieldRef) LT(value any) Condition { return f.Op(OpLessThan, value) }
+
+// LTE matches values less than or equal to the value.
+func (f FieldRef) LTE(value any) Condition { return f.Op(OpLessThanOrEqual, value) }
+
+// GT matches values greater than the value.
+func (f FieldRef) GT(value any) Condition { return f.Op(OpGreaterThan, value) }
+
+// GTE matches values greater than or equal to the value.
+func (f FieldRef) GTE(value any) Condition { return f.Op(OpGreaterThanOrEqual, value) }
+
+// And combines conditions so that all must match. Empty conditions are ignored.
+//
+// Example:
+//
+//	core.And(core.F("status").EX("Open"), core.F("priority").GTE(3))
+func And(conds ...Condition) Condition {
+	return group("AND", conds)
+}
+
+// Or combines conditions so that any may match. Empty conditions are ignored.
+func Or(conds ...Condition) Condition {
+	return group("OR", conds)
+}
+
+// And returns a condition matching c and all of others.
+func (c Condition) And(others ...Condition) Condition {
+	return And(append([]Condition{c}, others...)...)
+}
+
+// Or returns a condition matching c or any of others.
+func (c Condition) Or(others ...Condition) Condition {
+	return Or(append([]Condition{c}, others...)...)
+}
+
+// group combines conditions, flattening nested groups with the same conjunction.
+func group(conj string, conds []Condition) Condition {
+	children := make([]Condition, 0, len(conds))
+	for _, c := range conds {
+		switch {
+		case c.IsZero():
+			continue
+		case c.conj == conj:
+			children = append(children, c.children...)
+		default:
+			children = append(children, c)
+		}
+	}
+	switch len(children) {
+	case 0:
+		return Condition{}
+	case 1:
+		return children[0]
+	}
+	return Condition{conj: conj, children: children}
+}
+
+// IsZero reports whether c is an empty condition.
+func (c Condition) IsZero() bool {
+	return c.conj == "" && c.op == "" && c.err == nil
+}
+
+// Err returns the first error recorded while constructing the condition,
+// such as an unknown operator or an unsupported value type.
+func (c Condition) Err() error {
+	if c.err != nil {
+		return c.err
+	}
+	for _, child := range c.children {
+		if err := child.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Build renders the condition as a where string, resolving field aliases
+// through the schema. Aliases require a schema; field IDs work without one.
+func (c Condition) Build(schema *ResolvedSchema, tableID string) (string, error) {
+	var sb strings.Builder
+	if err := c.render(&sb, func(field any) (string, error) {
+		if s, ok := field.(string); ok {
+			if _, err := strconv.Atoi(s); err == nil {
+				return s, nil // numeric string is already a field ID
+			}
+			if schema == nil {
+				return "", &SchemaError{Message: "schema required to resolve field alias: " + s}
+			}
+		}
+		id, err := ResolveFieldAlias(schema, tableID, field)
+		if err != nil {
+			return "", err
+		}
+		return strconv.Itoa(id), nil
+	}); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// String renders the condition without resolving aliases. Aliases are written
+// as quoted field references ({'status'.EX.'Open'}), which RunQuery resolves
+// when a schema is configured.
+//
+// A condition with a construction error renders as a clause on field 0, such
+// as {0.EX.'<invalid: unknown where operator>'}, which QuickBase rejects, so
+// an invalid condition never widens into an empty filter that matches (or
+// deletes) every record. Use Err or Build to detect the error before sending.
+func (c Condition) String() string {
+	var sb strings.Builder
+	if err := c.render(&sb, func(field any) (string, error) {
+		switch f := field.(type) {
+		case int:
+			return strconv.Itoa(f), nil
+		case string:
+			if _, err := strconv.Atoi(f); err == nil {
+				return f, nil
+			}
+			return "'" + escapeWhereValue(f) + "'", nil
+		}
+		return "", &SchemaError{Message: fmt.Sprintf("field reference must be int or string, got %T", field)}
+	}); err != nil {
+		return invalidWhere(err)
+	}
+	return sb.String()
+}
+
+// invalidWhere renders err as a where clause that no table can satisfy.
+func invalidWhere(err error) string {
+	return "{0.EX.'" + escapeWhereValue("<invalid: "+err.Error()+">") + "'}"
+}
+
+// render writes the condition using resolve to format field references.
+func (c Condition) render(sb *strings.Builder, resolve func(field any) (string, error)) error {
+	if c.err != nil {
+		return c.err
+	}
+	if c.conj == "" {
+		if c.op == "" {
+			return nil
+		}
+		ref, err := resolve(c.field)
+		if err != nil {
+			return err
+		}
+		sb.WriteString("{" + ref + "." + c.op + ".'" + escapeWhereValue(c.value) + "'}")
+		return nil
+	}
+	for i, child := range c.children {
+		if i > 0 {
+			sb.WriteString(c.conj)
+		}
+		nested := child.conj != ""
+		if nested {
+			sb.WriteString("(")
+		}
+		if err := child.render(sb, resolve); err != nil {
+			return err
+		}
+		if nested {
+			sb.WriteString(")")
+		}
+	}
+	return nil
+}
+
+// escapeWhereValue escapes backslashes and single quotes for a quoted where value.
+func escapeWhereValue(s string) string {
+	if !strings.ContainsAny(s, `\'`) {
+		return s
+	}
+	return strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s)
+}
+
+// FormatWhereValue formats a Go value for use in a where clause (unquoted, unescaped).
+//
+//   - time.Time at midnight formats as a date (2006-01-02); other times as
+//     milliseconds since the Unix epoch, which QuickBase accepts for timestamps
+//   - time.Duration formats as milliseconds
+//   - numbers and bools use their plain text form; nil is the empty string
+func FormatWhereValue(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case time.Time:
+		if v.IsZero() {
+			return "", nil
+		}
+		h, m, s := v.Clock()
+		if h == 0 && m == 0 && s == 0 && v.Nanosecond() == 0 {
+			return v.Format("2006-01-02"), nil
+		}
+		return strconv.FormatInt(v.UnixMilli(), 10), nil
+	case *time.Time:
+		if v == nil {
+			return "", nil
+		}
+		return FormatWhereValue(*v)
+	case time.Duration:
+		return strconv.FormatInt(v.Milliseconds(), 10), nil
+	case fmt.Stringer:
+		return v.String(), nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return "", nil
+		}
+		return FormatWhereValue(rv.Elem().Interface())
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return "", &SchemaError{Message: fmt.Sprintf("unsupported where value %v", f)}
+		}
+		return strconv.FormatFloat(f, 'f', -1, rv.Type().Bits()), nil
+	}
+	return "", &SchemaError{Message: fmt.Sprintf("unsupported where value type %T", value)}
+}
@@ -0,0 +1,538 @@
+package core
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/internal/fieldvalue"
+)
+
+// --- Where Clause Evaluation ---
+// EvaluateWhere decides locally whether an unwrapped record matches a query,
+// using the same comparison semantics as QuickBase:
+//
+//   - text: case-insensitive EX/CT/SW and lexical LT/GT
+//   - numeric: numeric comparison (text operators compare the formatted number)
+//   - date/timestamp: BF/AF/OBF/OAF, EX on a day, IR relative ranges; a
+//     date-only operand compares whole days, even against timestamps
+//   - checkbox: EX against true/false
+//   - multi-select and multi-user: EX/HAS match any item
+//   - user: EX/CT/SW match email, name or user name; TV matches the user ID
+//
+// X-prefixed operators negate their counterpart, and {fid.EX.''} matches
+// blank values. Without FieldTypes, semantics are inferred from each value's
+// Go type (ISO date strings are treated as dates).
+
+// EvaluateOptions configures EvaluateWhereWithOptions.
+type EvaluateOptions struct {
+	// Now is the reference time for 'today' and IR ranges. Defaults to time.Now().
+	Now time.Time
+
+	// Location determines calendar days for date comparisons. Defaults to UTC.
+	Location *time.Location
+
+	// FieldTypes maps record keys (field IDs or aliases) to QuickBase field
+	// types such as "numeric", "date" or "multitext". Optional.
+	FieldTypes map[string]string
+
+	// Schema and TableID resolve aliases in the query and let queries using
+	// field IDs match records keyed by alias (and vice versa). Optional.
+	Schema  *ResolvedSchema
+	TableID string
+}
+
+// EvaluateWhere reports whether an unwrapped record (keyed by field ID or
+// alias) matches a where clause. Fields missing from the record are blank.
+//
+// Example:
+//
+//	record := map[string]any{"6": "Apollo", "7": 5000.0, "8": "2024-01-15"}
+//	ok, err := core.EvaluateWhere(record, "{6.CT.'apo'} AND {8.OBF.'2024-06-01'}")
+func EvaluateWhere(record map[string]any, query string) (bool, error) {
+	return EvaluateWhereWithOptions(record, query, EvaluateOptions{})
+}
+
+// EvaluateWhereWithOptions is EvaluateWhere with field types, clock and schema.
+// Syntax errors and unknown aliases are returned as *SchemaError.
+func EvaluateWhereWithOptions(record map[string]any, query string, opts EvaluateOptions) (bool, error) {
+	node, err := ParseWhere(query)
+	if err != nil {
+		return false, err
+	}
+	if node == nil {
+		return true, nil
+	}
+	if opts.Schema != nil {
+		if err := ResolveWhere(query, node, opts.Schema, opts.TableID); err != nil {
+			return false, err
+		}
+	}
+	return MatchWhere(node, record, opts), nil
+}
+
+// MatchWhere evaluates a parsed where clause against a record. Parse once with
+// ParseWhere and call MatchWhere per record to filter many records.
+// A nil node matches every record.
+func MatchWhere(node WhereNode, record map[string]any, opts EvaluateOptions) bool {
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+	return matchNode(node, record, &opts)
+}
+
+func matchNode(node WhereNode, record map[string]any, opts *EvaluateOptions) bool {
+	switch n := node.(type) {
+	case *WhereClause:
+		value, fieldType := lookupField(n, record, opts)
+		return matchClause(n.Op, value, n.Value, valueCategory(value, fieldType), opts)
+	case *WhereGroup:
+		for _, term := range n.Terms {
+			matched := matchNode(term, record, opts)
+			if n.Conj == "OR" && matched {
+				return true
+			}
+			if n.Conj == "AND" && !matched {
+				return false
+			}
+		}
+		return n.Conj == "AND"
+	}
+	return true
+}
+
+// lookupField finds a clause's value in the record, trying the field ID, the
+// reference as written and, with a schema, the field's alias.
+func lookupField(c *WhereClause, record map[string]any, opts *EvaluateOptions) (any, string) {
+	keys := make([]string, 0, 3)
+	id := c.FieldID
+	if id == 0 && !c.IsAlias() {
+		id, _ = strconv.Atoi(c.Field)
+	}
+	if id != 0 {
+		keys = append(keys, strconv.Itoa(id))
+		if opts.Schema != nil {
+			if alias := GetFieldAlias(opts.Schema, opts.TableID, id); alias != "" {
+				keys = append(keys, alias)
+			}
+		}
+	}
+	keys = append(keys, c.Field)
+
+	for _, key := range keys {
+		if v, ok := record[key]; ok {
+			return v, fieldTypeFor(keys, opts)
+		}
+	}
+	return nil, fieldTypeFor(keys, opts)
+}
+
+func fieldTypeFor(keys []string, opts *EvaluateOptions) string {
+	for _, key := range keys {
+		if t, ok := opts.FieldTypes[key]; ok {
+			return t
+		}
+	}
+	return ""
+}
+
+type valueKind int
+
+const (
+	kindText valueKind = iota
+	kindNumeric
+	kindDate
+	kindCheckbox
+	kindList
+	kindUser
+)
+
+// fieldTypeKind groups QuickBase field types by comparison semantics.
+func fieldTypeKind(fieldType string) (valueKind, bool) {
+	switch fieldType {
+	case "":
+		return kindText, false
+	case "numeric", "currency", "percent", "rating", "duration", "recordid":
+		return kindNumeric, true
+	case "date", "timestamp", "datetime", "date / time":
+		return kindDate, true
+	case "checkbox":
+		return kindCheckbox, true
+	case "multitext", "multiuser", "text-multiple-choice-multi":
+		return kindList, true
+	case "user":
+		return kindUser, true
+	}
+	return kindText, true
+}
+
+// valueCategory determines comparison semantics from the field type, or from
+// the value itself when the type is unknown.
+func valueCategory(v any, fieldType string) valueKind {
+	if kind, ok := fieldTypeKind(fieldType); ok {
+		return kind
+	}
+	switch val := v.(type) {
+	case bool:
+		return kindCheckbox
+	case time.Time:
+		return kindDate
+	case []any, []string:
+		return kindList
+	case map[string]any:
+		return kindUser
+	case string:
+		if IsISODateString(val) {
+			return kindDate
+		}
+		return kindText
+	}
+	if _, ok := fieldvalue.Float(v); ok {
+		return kindNumeric
+	}
+	return kindText
+}
+
+func matchClause(op string, v any, want string, kind valueKind, opts *EvaluateOptions) bool {
+	switch op {
+	case OpNotEquals:
+		return !matchClause(OpEquals, v, want, kind, opts)
+	case OpNotContains:
+		return !matchClause(OpContains, v, want, kind, opts)
+	case OpNotHas:
+		return !matchClause(OpHas, v, want, kind, opts)
+	case OpNotStartsWith:
+		return !matchClause(OpStartsWith, v, want, kind, opts)
+	case OpNotInRange:
+		return !matchClause(OpInRange, v, want, kind, opts)
+	case OpTrueValue:
+		return strings.EqualFold(trueValue(v), want)
+	}
+
+	// Empty comparisons: {fid.EX.''} matches blank values.
+	if want == "" && op == OpEquals {
+		return isBlankValue(v)
+	}
+	if isBlankValue(v) {
+		return false
+	}
+
+	switch kind {
+	case kindNumeric:
+		return matchNumeric(op, v, want)
+	case kindDate:
+		return matchDate(op, v, want, opts)
+	case kindCheckbox:
+		return matchCheckbox(op, v, want)
+	case kindList:
+		return matchList(op, v, want)
+	case kindUser:
+		return matchUser(op, v, want)
+	}
+	return matchText(op, fieldvalue.Text(v), want)
+}
+
+func isBlankValue(v any) bool {
+	switch val := v.(type) {
+	case nil:
+		return true
+	case string:
+		return val == ""
+	case []string:
+		return len(val) == 0
+	case []any:
+		return len(val) == 0
+	case time.Time:
+		return val.IsZero()
+	}
+	return false
+}
+
+func matchText(op, have, want string) bool {
+	h, w := strings.ToLower(have), strings.ToLower(want)
+	switch op {
+	case OpEquals, OpHas:
+		return h == w
+	case OpContains:
+		return strings.Contains(h, w)
+	case OpStartsWith:
+		return strings.HasPrefix(h, w)
+	case OpLessThan, OpBefore:
+		return h < w
+	case OpLessThanOrEqual, OpOnOrBefore:
+		return h <= w
+	case OpGreaterThan, OpAfter:
+		return h > w
+	case OpGreaterThanOrEqual, OpOnOrAfter:
+		return h >= w
+	}
+	return false
+}
+
+func matchNumeric(op string, v any, want string) bool {
+	have, ok := fieldvalue.Float(v)
+	if !ok {
+		return matchText(op, fieldvalue.Text(v), want)
+	}
+	w, err := strconv.ParseFloat(strings.TrimSpace(want), 64)
+	if err != nil || op == OpContains || op == OpStartsWith {
+		return matchText(op, fieldvalue.Text(v), want)
+	}
+	switch op {
+	case OpEquals, OpHas:
+		return have == w
+	case OpLessThan, OpBefore:
+		return have < w
+	case OpLessThanOrEqual, OpOnOrBefore:
+		return have <= w
+	case OpGreaterThan, OpAfter:
+		return have > w
+	case OpGreaterThanOrEqual, OpOnOrAfter:
+		return have >= w
+	}
+	return false
+}
+
+func matchCheckbox(op string, v any, want string) bool {
+	have, ok := v.(bool)
+	if !ok {
+		have, ok = fieldvalue.ParseBool(fieldvalue.Text(v))
+	}
+	w, wok := fieldvalue.ParseBool(want)
+	if !ok || !wok {
+		return false
+	}
+	switch op {
+	case OpEquals, OpHas, OpContains, OpStartsWith:
+		return have == w
+	}
+	return false
+}
+
+func matchList(op string, v any, want string) bool {
+	items := listItems(v)
+	switch op {
+	case OpEquals, OpHas, OpContains, OpStartsWith:
+		for _, item := range items {
+			if m, ok := item.(map[string]any); ok {
+				if matchUser(op, m, want) {
+					return true
+				}
+				continue
+			}
+			if matchText(op, fieldvalue.Text(item), want) {
+				return true
+			}
+		}
+		return false
+	}
+	texts := make([]string, len(items))
+	for i, item := range items {
+		texts[i] = fieldvalue.Text(item)
+	}
+	return matchText(op, strings.Join(texts, ";"), want)
+}
+
+// matchUser compares a user value by email, name or user name.
+func matchUser(op string, v any, want string) bool {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return matchText(op, fieldvalue.Text(v), want)
+	}
+	for _, key := range []string{"email", "name", "userName"} {
+		if s, _ := m[key].(string); s != "" && matchText(op, s, want) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchDate(op string, v any, want string, opts *EvaluateOptions) bool {
+	have, ok := timeValue(v)
+	if !ok {
+		return matchText(op, fieldvalue.Text(v), want)
+	}
+
+	switch op {
+	case OpInRange:
+		start, end, ok := relativeRange(want, opts.Now, opts.Location)
+		if !ok {
+			return false
+		}
+		day := calendarDay(have, opts.Location)
+		return !day.Before(start) && day.Before(end)
+	case OpContains, OpStartsWith:
+		return matchText(op, fieldvalue.Text(v), want)
+	}
+
+	w, dateOnly, ok := parseQueryDate(want, opts.Now, opts.Location)
+	if !ok {
+		return false
+	}
+	// A date-only operand compares whole days, even against timestamps.
+	if dateOnly {
+		have = calendarDay(have, opts.Location)
+	}
+	switch op {
+	case OpEquals, OpHas:
+		return have.Equal(w)
+	case OpLessThan, OpBefore:
+		return have.Before(w)
+	case OpLessThanOrEqual, OpOnOrBefore:
+		return !have.After(w)
+	case OpGreaterThan, OpAfter:
+		return have.After(w)
+	case OpGreaterThanOrEqual, OpOnOrAfter:
+		return !have.Before(w)
+	}
+	return false
+}
+
+// parseQueryDate parses a date operand: YYYY-MM-DD, MM-DD-YYYY, MM/DD/YYYY,
+// an ISO timestamp, epoch milliseconds, or 'today'. dateOnly reports whether
+// the operand has no time of day; date-only results are UTC calendar days.
+func parseQueryDate(s string, now time.Time, loc *time.Location) (t time.Time, dateOnly bool, ok bool) {
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, "today") {
+		return calendarDay(now, loc), true, true
+	}
+	for _, layout := range []string{"2006-01-02", "01-02-2006", "01/02/2006"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true, true
+		}
+	}
+	if t, err := ParseISODate(s); err == nil {
+		return t.UTC(), false, true
+	}
+	if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.UnixMilli(ms).UTC(), false, true
+	}
+	return time.Time{}, false, false
+}
+
+var relativePattern = regexp.MustCompile(`^(last|next)[ +]+(\d+)[ +]+([a-z]+)$`)
+
+// relativeRange resolves an IR operand to a half-open [start, end) range of
+// calendar days. Supported forms: today, yesterday, tomorrow,
+// this week|month|year, last|next week|month|year and
+// last|next N days|weeks|months|years.
+func relativeRange(s string, now time.Time, loc *time.Location) (time.Time, time.Time, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	today := calendarDay(now, loc)
+
+	switch s {
+	case "today":
+		return today, today.AddDate(0, 0, 1), true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), today, true
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), today.AddDate(0, 0, 2), true
+	}
+
+	if unit, ok := strings.CutPrefix(s, "this "); ok {
+		start := periodStart(today, unit)
+		if start.IsZero() {
+			return time.Time{}, time.Time{}, false
+		}
+		return start, addPeriod(start, unit, 1), true
+	}
+
+	m := relativePattern.FindStringSubmatch(s)
+	if m == nil {
+		parts := strings.Fields(strings.ReplaceAll(s, "+", " "))
+		if len(parts) != 2 || (parts[0] != "last" && parts[0] != "next") {
+			return time.Time{}, time.Time{}, false
+		}
+		m = []string{s, parts[0], "1", parts[1]}
+	}
+	n, _ := strconv.Atoi(m[2])
+	unit := m[3]
+	if addPeriod(today, unit, 1).IsZero() {
+		return time.Time{}, time.Time{}, false
+	}
+	if m[1] == "last" {
+		return addPeriod(today, unit, -n).AddDate(0, 0, 1), today.AddDate(0, 0, 1), true
+	}
+	return today, addPeriod(today, unit, n), true
+}
+
+func addPeriod(t time.Time, unit string, n int) time.Time {
+	switch strings.TrimSuffix(unit, "s") {
+	case "d", "day":
+		return t.AddDate(0, 0, n)
+	case "w", "week":
+		return t.AddDate(0, 0, 7*n)
+	case "m", "month":
+		return t.AddDate(0, n, 0)
+	case "y", "year":
+		return t.AddDate(n, 0, 0)
+	}
+	return time.Time{}
+}
+
+func periodStart(today time.Time, unit string) time.Time {
+	switch unit {
+	case "week":
+		return today.AddDate(0, 0, -int(today.Weekday()))
+	case "month":
+		return time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case "year":
+		return time.Date(today.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return time.Time{}
+}
+
+// calendarDay returns the calendar day of t in loc, as midnight UTC.
+func calendarDay(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// timeValue converts a date or timestamp value. Date-only strings are UTC days;
+// timestamps without a zone are UTC.
+func timeValue(v any) (time.Time, bool) {
+	switch val := v.(type) {
+	case time.Time:
+		return val, !val.IsZero()
+	case string:
+		if val == "" {
+			return time.Time{}, false
+		}
+		t, err := ParseISODate(val)
+		return t, err == nil
+	}
+	if ms, ok := fieldvalue.Float(v); ok {
+		return time.UnixMilli(int64(ms)).UTC(), true
+	}
+	return time.Time{}, false
+}
+
+func listItems(v any) []any {
+	switch val := v.(type) {
+	case []any:
+		return val
+	case []string:
+		out := make([]any, len(val))
+		for i, s := range val {
+			out[i] = s
+		}
+		return out
+	case nil:
+		return nil
+	}
+	return []any{v}
+}
+
+// trueValue returns the underlying value used by the TV operator: the user ID
+// for user values, otherwise the text value.
+func trueValue(v any) string {
+	if m, ok := v.(map[string]any); ok {
+		id, _ := m["id"].(string)
+		return id
+	}
+	return fieldvalue.Text(v)
+}
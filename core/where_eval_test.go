@@ -0,0 +1,205 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEvaluateWhere(t *testing.T) {
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	record := map[string]any{
+		"6":      "Apollo",
+		"7":      5000.0,
+		"8":      "2024-03-10",
+		"9":      true,
+		"10":     []any{"Red", "Blue"},
+		"11":     map[string]any{"id": "123.abcd", "email": "Ann@Example.com", "name": "Ann Lee"},
+		"12":     "2024-03-15T09:30:00Z",
+		"13":     "",
+		"14":     json.Number("42"),
+		"15":     time.Date(2024, 3, 14, 23, 0, 0, 0, time.UTC),
+		"status": "Open",
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  bool
+	}{
+		// Text
+		{"text equals ignores case", "{6.EX.'apollo'}", true},
+		{"text not equals", "{6.XEX.'Apollo'}", false},
+		{"text contains", "{6.CT.'POL'}", true},
+		{"text not contains", "{6.XCT.'x'}", true},
+		{"text starts with", "{6.SW.'ap'}", true},
+		{"text not starts with", "{6.XSW.'ap'}", false},
+		{"text less than", "{6.LT.'b'}", true},
+		{"text has", "{6.HAS.'APOLLO'}", true},
+
+		// Numeric
+		{"numeric equals", "{7.EX.5000}", true},
+		{"numeric equals decimal", "{7.EX.'5000.00'}", true},
+		{"numeric greater than", "{7.GT.4999.5}", true},
+		{"numeric less than or equal", "{7.LTE.5000}", true},
+		{"numeric less than", "{7.LT.5000}", false},
+		{"numeric contains", "{7.CT.'00'}", true},
+		{"json number", "{14.GTE.42}", true},
+
+		// Dates
+		{"date equals", "{8.EX.'2024-03-10'}", true},
+		{"date US format", "{8.EX.'03-10-2024'}", true},
+		{"date before", "{8.BF.'2024-03-11'}", true},
+		{"date on or before", "{8.OBF.'2024-03-10'}", true},
+		{"date after", "{8.AF.'2024-03-10'}", false},
+		{"date on or after today", "{8.OAF.'today'}", false},
+		{"date in range", "{8.IR.'last 7 days'}", true},
+		{"date not in range", "{8.XIR.'this week'}", false},
+		{"date in range this month", "{8.IR.'this month'}", true},
+		{"date not in next week", "{8.IR.'next week'}", false},
+		{"timestamp on day", "{12.EX.'2024-03-15'}", true},
+		{"timestamp in range today", "{12.IR.'today'}", true},
+		{"timestamp after instant", "{12.AF.'2024-03-15T09:00:00Z'}", true},
+		{"timestamp epoch ms", "{12.BF.'1710495000001'}", true},
+		{"time value yesterday", "{15.IR.'yesterday'}", true},
+
+		// Checkbox
+		{"checkbox true", "{9.EX.true}", true},
+		{"checkbox 1", "{9.EX.1}", true},
+		{"checkbox false", "{9.EX.'false'}", false},
+		{"checkbox not false", "{9.XEX.'no'}", true},
+
+		// Multi-select
+		{"list has item", "{10.HAS.'red'}", true},
+		{"list equals any item", "{10.EX.'Blue'}", true},
+		{"list missing item", "{10.HAS.'Green'}", false},
+		{"list not has", "{10.XHAS.'Green'}", true},
+		{"list contains", "{10.CT.'lu'}", true},
+
+		// Users
+		{"user by email", "{11.EX.'ann@example.com'}", true},
+		{"user by name", "{11.EX.'ann lee'}", true},
+		{"user contains", "{11.CT.'example'}", true},
+		{"user true value", "{11.TV.'123.abcd'}", true},
+		{"user other", "{11.EX.'bob@example.com'}", false},
+
+		// Blank values
+		{"empty string is blank", "{13.EX.''}", true},
+		{"missing field is blank", "{99.EX.''}", true},
+		{"value is not blank", "{6.XEX.''}", true},
+		{"blank does not match", "{13.CT.'a'}", false},
+		{"blank negated", "{99.XEX.'x'}", true},
+
+		// Aliases as record keys
+		{"alias key", "{status.EX.'open'}", true},
+		{"quoted alias key", "{'status'.XEX.'Closed'}", true},
+
+		// Groups
+		{"and", "{6.EX.'Apollo'}AND{7.GT.10000}", false},
+		{"or", "{6.EX.'Gemini'}OR{7.GT.1000}", true},
+		{"and binds tighter", "{6.EX.'Gemini'} AND {7.GT.0} OR {9.EX.true}", true},
+		{"parentheses", "{6.EX.'Gemini'} AND ({7.GT.0} OR {9.EX.true})", false},
+		{"empty query matches", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EvaluateWhereWithOptions(record, tt.query, EvaluateOptions{Now: now})
+			if err != nil {
+				t.Fatalf("EvaluateWhere() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("EvaluateWhere(%s) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateWhereFieldTypes(t *testing.T) {
+	// Without field types, numeric strings compare as text and dates stored
+	// as epoch milliseconds compare as numbers
+	record := map[string]any{"6": "10", "7": 1710460800000.0}
+
+	ok, _ := EvaluateWhere(record, "{6.GT.9}")
+	if ok {
+		t.Error("text comparison: '10' > '9' should be false")
+	}
+
+	opts := EvaluateOptions{FieldTypes: map[string]string{"6": "numeric", "7": "date"}}
+	if ok, _ := EvaluateWhereWithOptions(record, "{6.GT.9}", opts); !ok {
+		t.Error("numeric comparison: 10 > 9 should be true")
+	}
+	if ok, _ := EvaluateWhereWithOptions(record, "{7.EX.'2024-03-15'}", opts); !ok {
+		t.Error("date comparison: epoch ms should match its day")
+	}
+}
+
+func TestEvaluateWhereLocation(t *testing.T) {
+	// 2024-03-15T02:00Z is still March 14 in New York
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone data unavailable")
+	}
+	record := map[string]any{"12": "2024-03-15T02:00:00Z"}
+
+	if ok, _ := EvaluateWhere(record, "{12.EX.'2024-03-15'}"); !ok {
+		t.Error("UTC: expected match on 2024-03-15")
+	}
+	ok, _ := EvaluateWhereWithOptions(record, "{12.EX.'2024-03-14'}", EvaluateOptions{Location: loc})
+	if !ok {
+		t.Error("New York: expected match on 2024-03-14")
+	}
+}
+
+func TestEvaluateWhereSchema(t *testing.T) {
+	schema := whereTestSchema()
+	opts := EvaluateOptions{Schema: schema, TableID: "bqxyz123"}
+
+	// Queries by alias match records keyed by ID, and vice versa
+	byID := map[string]any{"7": "Open"}
+	if ok, err := EvaluateWhereWithOptions(byID, "{status.EX.'Open'}", opts); err != nil || !ok {
+		t.Errorf("alias query on ID record = %v, %v", ok, err)
+	}
+	byAlias := map[string]any{"status": "Open"}
+	if ok, err := EvaluateWhereWithOptions(byAlias, "{7.EX.'Open'}", opts); err != nil || !ok {
+		t.Errorf("ID query on alias record = %v, %v", ok, err)
+	}
+
+	_, err := EvaluateWhereWithOptions(byID, "{stauts.EX.'Open'}", opts)
+	var schemaErr *SchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Errorf("expected SchemaError for unknown alias, got %v", err)
+	}
+}
+
+func TestEvaluateWhereErrors(t *testing.T) {
+	_, err := EvaluateWhere(map[string]any{}, "{6.EQ.'a'}")
+	var schemaErr *SchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("expected SchemaError, got %v", err)
+	}
+	if schemaErr.Pos != 3 {
+		t.Errorf("Pos = %d, want 3", schemaErr.Pos)
+	}
+}
+
+func TestMatchWhere(t *testing.T) {
+	node, err := ParseWhere("{7.GTE.100}")
+	if err != nil {
+		t.Fatalf("ParseWhere() error = %v", err)
+	}
+	records := []map[string]any{{"7": 50.0}, {"7": 100.0}, {"7": 250}}
+	var matched int
+	for _, rec := range records {
+		if MatchWhere(node, rec, EvaluateOptions{}) {
+			matched++
+		}
+	}
+	if matched != 2 {
+		t.Errorf("matched %d records, want 2", matched)
+	}
+	if !MatchWhere(nil, records[0], EvaluateOptions{}) {
+		t.Error("nil node should match every record")
+	}
+}
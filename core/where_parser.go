@@ -0,0 +1,415 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// --- Where Clause Parser ---
+// ParseWhere turns a QuickBase query string into an AST:
+//
+//	{'status'.EX.'Open'} AND ({7.GT.100} OR {dueDate.IR.'today'})
+//
+// Clauses are {field.OP.value}; the field and value may be quoted with ' or "
+// and quoted text may contain backslash escapes. Clauses are joined with AND/OR
+// (case-insensitive) and grouped with parentheses. AND binds tighter than OR.
+// Syntax errors are returned as *SchemaError with the offending position.
+
+// WhereNode is a node in a parsed where clause: *WhereClause or *WhereGroup.
+type WhereNode interface {
+	// Pos returns the byte offset of the node in the query.
+	Pos() int
+	whereNode()
+}
+
+// WhereClause is a single {field.OP.value} comparison.
+type WhereClause struct {
+	Field   string // field reference as written, without quotes (ID or alias)
+	FieldID int    // field ID; 0 until an alias is resolved
+	Op      string // operator, upper-case
+	Value   string // value without quotes, escapes applied
+
+	Start      int // offset of '{'
+	End        int // offset after '}'
+	FieldStart int // offset of the field token, including any quote
+	FieldEnd   int // offset after the field token
+	OpStart    int // offset of the operator
+	ValueStart int // offset of the value token
+}
+
+// WhereGroup is two or more nodes joined by AND or OR.
+type WhereGroup struct {
+	Conj  string // "AND" or "OR"
+	Terms []WhereNode
+	Start int // offset of the first term, or of '(' when parenthesized
+}
+
+func (c *WhereClause) Pos() int { return c.Start }
+func (g *WhereGroup) Pos() int  { return g.Start }
+func (*WhereClause) whereNode() {}
+func (*WhereGroup) whereNode()  {}
+
+// IsAlias reports whether the clause references a field by alias rather than ID.
+func (c *WhereClause) IsAlias() bool {
+	_, err := strconv.Atoi(c.Field)
+	return err != nil
+}
+
+// ParseWhere parses a where clause. An empty or blank query returns a nil node.
+// Operators are validated; field references are not (see ResolveWhere).
+func ParseWhere(query string) (WhereNode, error) {
+	p := &whereParser{input: query}
+	p.skipSpace()
+	if p.done() {
+		return nil, nil
+	}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if !p.done() {
+		if p.peek() == ')' {
+			return nil, p.errorAt(p.pos, "unbalanced ')'")
+		}
+		return nil, p.errorAt(p.pos, "expected AND or OR")
+	}
+	return node, nil
+}
+
+// WalkWhere calls fn for each clause in order, stopping at the first error.
+func WalkWhere(node WhereNode, fn func(*WhereClause) error) error {
+	switch n := node.(type) {
+	case *WhereClause:
+		return fn(n)
+	case *WhereGroup:
+		for _, term := range n.Terms {
+			if err := WalkWhere(term, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ResolveWhere resolves field references in a parsed query, setting FieldID on
+// each clause. Aliases require a schema. The query is used for error positions.
+func ResolveWhere(query string, node WhereNode, schema *ResolvedSchema, tableID string) error {
+	return WalkWhere(node, func(c *WhereClause) error {
+		if !c.IsAlias() {
+			c.FieldID, _ = strconv.Atoi(c.Field)
+			return nil
+		}
+		if schema == nil {
+			return whereError(query, c.FieldStart, c.FieldEnd, "schema required to resolve field alias '"+c.Field+"'")
+		}
+		id, err := ResolveFieldAlias(schema, tableID, c.Field)
+		if err != nil {
+			return whereError(query, c.FieldStart, c.FieldEnd, err.Error())
+		}
+		c.FieldID = id
+		return nil
+	})
+}
+
+// RewriteWhere returns query with each resolved alias replaced by its field ID.
+// The rest of the query is left exactly as written.
+func RewriteWhere(query string, node WhereNode) string {
+	var sb strings.Builder
+	last := 0
+	_ = WalkWhere(node, func(c *WhereClause) error {
+		if c.IsAlias() && c.FieldID != 0 {
+			sb.WriteString(query[last:c.FieldStart])
+			sb.WriteString(strconv.Itoa(c.FieldID))
+			last = c.FieldEnd
+		}
+		return nil
+	})
+	sb.WriteString(query[last:])
+	return sb.String()
+}
+
+// TransformWhere validates a where clause and rewrites field aliases to IDs.
+// Syntax errors, unknown operators and unknown aliases are returned as
+// *SchemaError pointing at the offending token.
+func TransformWhere(query string, schema *ResolvedSchema, tableID string) (string, error) {
+	node, err := ParseWhere(query)
+	if err != nil || node == nil {
+		return query, err
+	}
+	if err := ResolveWhere(query, node, schema, tableID); err != nil {
+		return query, err
+	}
+	return RewriteWhere(query, node), nil
+}
+
+// FormatWhere renders a node in canonical compact form, e.g.
+// {7.EX.'Open'}AND({8.GT.'1'}OR{8.LT.'0'}). Unresolved aliases are quoted.
+func FormatWhere(node WhereNode) string {
+	var sb strings.Builder
+	formatWhere(&sb, node, "", "")
+	return sb.String()
+}
+
+// PrettyWhere renders a node with one clause per line, indenting nested groups:
+//
+//	{7.EX.'Open'}
+//	AND (
+//	  {8.GT.'1'}
+//	  OR {8.LT.'0'}
+//	)
+func PrettyWhere(node WhereNode) string {
+	var sb strings.Builder
+	formatWhere(&sb, node, "", "  ")
+	return sb.String()
+}
+
+// formatWhere writes node; indent is non-empty for pretty output.
+func formatWhere(sb *strings.Builder, node WhereNode, prefix, indent string) {
+	switch n := node.(type) {
+	case *WhereClause:
+		field := n.Field
+		if n.FieldID != 0 {
+			field = strconv.Itoa(n.FieldID)
+		} else if n.IsAlias() {
+			field = "'" + escapeWhereValue(field) + "'"
+		}
+		sb.WriteString("{" + field + "." + n.Op + ".'" + escapeWhereValue(n.Value) + "'}")
+	case *WhereGroup:
+		for i, term := range n.Terms {
+			if i > 0 {
+				if indent != "" {
+					sb.WriteString("\n" + prefix + n.Conj + " ")
+				} else {
+					sb.WriteString(n.Conj)
+				}
+			}
+			if _, nested := term.(*WhereGroup); !nested {
+				formatWhere(sb, term, prefix, indent)
+				continue
+			}
+			if indent == "" {
+				sb.WriteString("(")
+				formatWhere(sb, term, prefix, indent)
+				sb.WriteString(")")
+				continue
+			}
+			inner := prefix + indent
+			sb.WriteString("(\n" + inner)
+			formatWhere(sb, term, inner, indent)
+			sb.WriteString("\n" + prefix + ")")
+		}
+	}
+}
+
+// whereError builds a SchemaError for the query token in [start, end).
+func whereError(query string, start, end int, message string) *SchemaError {
+	if end > len(query) {
+		end = len(query)
+	}
+	if end <= start && start < len(query) {
+		end = start + 1
+	}
+	token := ""
+	if start < end {
+		token = query[start:end]
+	}
+	return &SchemaError{Message: "invalid where clause: " + message, Query: query, Pos: start, Token: token}
+}
+
+type whereParser struct {
+	input string
+	pos   int
+}
+
+func (p *whereParser) done() bool { return p.pos >= len(p.input) }
+
+func (p *whereParser) peek() byte { return p.input[p.pos] }
+
+func (p *whereParser) skipSpace() {
+	for !p.done() && unicode.IsSpace(rune(p.peek())) {
+		p.pos++
+	}
+}
+
+// errorAt reports an error at pos, using the rest of the current token as context.
+func (p *whereParser) errorAt(pos int, format string, args ...any) error {
+	end := pos
+	for end < len(p.input) && !strings.ContainsRune("{}() \t\n", rune(p.input[end])) {
+		end++
+	}
+	return whereError(p.input, pos, end, fmt.Sprintf(format, args...))
+}
+
+// keyword consumes a case-insensitive AND/OR keyword if present.
+func (p *whereParser) keyword(word string) bool {
+	p.skipSpace()
+	end := p.pos + len(word)
+	if end > len(p.input) || !strings.EqualFold(p.input[p.pos:end], word) {
+		return false
+	}
+	if end < len(p.input) {
+		if r := rune(p.input[end]); unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return false
+		}
+	}
+	p.pos = end
+	return true
+}
+
+func (p *whereParser) parseOr() (WhereNode, error) {
+	return p.parseGroup("OR", p.parseAnd)
+}
+
+func (p *whereParser) parseAnd() (WhereNode, error) {
+	return p.parseGroup("AND", p.parsePrimary)
+}
+
+// parseGroup parses terms joined by conj.
+func (p *whereParser) parseGroup(conj string, term func() (WhereNode, error)) (WhereNode, error) {
+	first, err := term()
+	if err != nil {
+		return nil, err
+	}
+	group := &WhereGroup{Conj: conj, Terms: []WhereNode{first}, Start: first.Pos()}
+	for p.keyword(conj) {
+		next, err := term()
+		if err != nil {
+			return nil, err
+		}
+		group.Terms = append(group.Terms, next)
+	}
+	if len(group.Terms) == 1 {
+		return first, nil
+	}
+	return group, nil
+}
+
+func (p *whereParser) parsePrimary() (WhereNode, error) {
+	p.skipSpace()
+	if p.done() {
+		return nil, whereError(p.input, len(p.input), len(p.input), "unexpected end of query")
+	}
+	switch p.peek() {
+	case '(':
+		open := p.pos
+		p.pos++
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.done() || p.peek() != ')' {
+			return nil, whereError(p.input, open, open+1, "unbalanced '('")
+		}
+		p.pos++
+		if g, ok := node.(*WhereGroup); ok {
+			g.Start = open
+		}
+		return node, nil
+	case '{':
+		return p.parseClause()
+	case ')':
+		return nil, p.errorAt(p.pos, "unbalanced ')'")
+	default:
+		return nil, p.errorAt(p.pos, "expected '{' or '('")
+	}
+}
+
+// parseClause parses {field.OP.value}.
+func (p *whereParser) parseClause() (WhereNode, error) {
+	c := &WhereClause{Start: p.pos}
+	p.pos++ // '{'
+
+	c.FieldStart = p.pos
+	field, err := p.parseToken('.', c.Start)
+	if err != nil {
+		return nil, err
+	}
+	c.Field = strings.TrimSpace(field)
+	c.FieldEnd = p.pos
+	if c.Field == "" {
+		return nil, whereError(p.input, c.Start, p.pos+1, "missing field reference")
+	}
+	p.pos++ // '.'
+
+	c.OpStart = p.pos
+	for !p.done() && p.peek() != '.' && p.peek() != '}' {
+		p.pos++
+	}
+	op := strings.TrimSpace(p.input[c.OpStart:p.pos])
+	if p.done() || p.peek() != '.' {
+		if p.done() {
+			return nil, whereError(p.input, c.Start, c.Start+1, "unbalanced '{'")
+		}
+		return nil, whereError(p.input, c.OpStart, p.pos, "expected operator followed by '.'")
+	}
+	c.Op = strings.ToUpper(op)
+	if !whereOperators[c.Op] {
+		return nil, whereError(p.input, c.OpStart, p.pos, fmt.Sprintf("unknown operator '%s'", op))
+	}
+	p.pos++ // '.'
+
+	c.ValueStart = p.pos
+	value, err := p.parseToken('}', c.Start)
+	if err != nil {
+		return nil, err
+	}
+	c.Value = value
+	p.pos++ // '}'
+	c.End = p.pos
+	return c, nil
+}
+
+// parseToken reads a quoted or bare token up to (not including) terminator.
+// A bare field token also stops at '}' so a missing operator is reported.
+func (p *whereParser) parseToken(terminator byte, clauseStart int) (string, error) {
+	p.skipSpace()
+	if !p.done() && (p.peek() == '\'' || p.peek() == '"') {
+		quote := p.peek()
+		open := p.pos
+		p.pos++
+		var sb strings.Builder
+		for {
+			if p.done() {
+				return "", whereError(p.input, open, open+1, "unterminated quoted text")
+			}
+			ch := p.peek()
+			if ch == '\\' && p.pos+1 < len(p.input) {
+				sb.WriteByte(p.input[p.pos+1])
+				p.pos += 2
+				continue
+			}
+			p.pos++
+			if ch == quote {
+				break
+			}
+			sb.WriteByte(ch)
+		}
+		p.skipSpace()
+		if p.done() {
+			return "", whereError(p.input, clauseStart, clauseStart+1, "unbalanced '{'")
+		}
+		if p.peek() != terminator {
+			return "", p.errorAt(p.pos, "expected '%c' after quoted text", terminator)
+		}
+		return sb.String(), nil
+	}
+
+	start := p.pos
+	for !p.done() && p.peek() != terminator && p.peek() != '}' {
+		if p.peek() == '{' {
+			return "", whereError(p.input, clauseStart, clauseStart+1, "unbalanced '{'")
+		}
+		p.pos++
+	}
+	if p.done() {
+		return "", whereError(p.input, clauseStart, clauseStart+1, "unbalanced '{'")
+	}
+	if p.peek() != terminator {
+		return "", whereError(p.input, clauseStart, p.pos+1, "expected operator")
+	}
+	return strings.TrimSpace(p.input[start:p.pos]), nil
+}
@@ -0,0 +1,152 @@
+package core
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseWhere(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string // FormatWhere output
+	}{
+		{"single clause", "{6.EX.'Apollo'}", "{6.EX.'Apollo'}"},
+		{"quoted alias", "{'status'.EX.'Open'}", "{'status'.EX.'Open'}"},
+		{"double-quoted alias", `{"status".CT."Op"}`, "{'status'.CT.'Op'}"},
+		{"bare alias and value", "{status.SW.Op}", "{'status'.SW.'Op'}"},
+		{"lowercase operator", "{6.ex.'a'}", "{6.EX.'a'}"},
+		{"escaped quote", `{6.EX.'O\'Brien'}`, `{6.EX.'O\'Brien'}`},
+		{"value with braces and dots", "{6.EX.'a}.{b'}", "{6.EX.'a}.{b'}"},
+		{"bare decimal value", "{7.GT.1.5}", "{7.GT.'1.5'}"},
+		{"and", "{6.EX.'a'}AND{7.GT.1}", "{6.EX.'a'}AND{7.GT.'1'}"},
+		{"lowercase keywords and spacing", "{6.EX.'a'} and {7.GT.1} or {8.LT.2}", "({6.EX.'a'}AND{7.GT.'1'})OR{8.LT.'2'}"},
+		{"parentheses", "{6.EX.'a'}AND({7.GT.1}OR{8.LT.2})", "{6.EX.'a'}AND({7.GT.'1'}OR{8.LT.'2'})"},
+		{"redundant parentheses", "(({6.EX.'a'}))", "{6.EX.'a'}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := ParseWhere(tt.query)
+			if err != nil {
+				t.Fatalf("ParseWhere() error = %v", err)
+			}
+			if got := FormatWhere(node); got != tt.want {
+				t.Errorf("FormatWhere() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	node, err := ParseWhere("   ")
+	if err != nil || node != nil {
+		t.Errorf("ParseWhere(blank) = %v, %v; want nil, nil", node, err)
+	}
+}
+
+func TestParseWhereErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		wantPos int
+		wantMsg string
+	}{
+		{"unknown operator", "{6.EQ.'a'}", 3, "unknown operator 'EQ'"},
+		{"missing operator", "{6.'a'}", 3, "expected operator"},
+		{"unbalanced brace", "{6.EX.'a'", 0, "unbalanced '{'"},
+		{"unbalanced bare brace", "{6.EX.a AND {7.EX.b}", 0, "unbalanced '{'"},
+		{"unterminated quote", "{6.EX.'a}", 6, "unterminated quoted text"},
+		{"unbalanced open paren", "({6.EX.'a'}", 0, "unbalanced '('"},
+		{"unbalanced close paren", "{6.EX.'a'})", 10, "unbalanced ')'"},
+		{"missing connector", "{6.EX.'a'}{7.EX.'b'}", 10, "expected AND or OR"},
+		{"dangling connector", "{6.EX.'a'} AND", 14, "unexpected end of query"},
+		{"plain text", "this is not valid", 0, "expected '{' or '('"},
+		{"empty field", "{.EX.'a'}", 0, "missing field reference"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseWhere(tt.query)
+			var schemaErr *SchemaError
+			if !errors.As(err, &schemaErr) {
+				t.Fatalf("ParseWhere() error = %v, want SchemaError", err)
+			}
+			if schemaErr.Pos != tt.wantPos {
+				t.Errorf("Pos = %d, want %d (%v)", schemaErr.Pos, tt.wantPos, err)
+			}
+			if !strings.Contains(schemaErr.Message, tt.wantMsg) {
+				t.Errorf("Message = %q, want it to contain %q", schemaErr.Message, tt.wantMsg)
+			}
+			if schemaErr.Query != tt.query {
+				t.Errorf("Query = %q, want %q", schemaErr.Query, tt.query)
+			}
+		})
+	}
+}
+
+func TestTransformWhere(t *testing.T) {
+	schema := whereTestSchema()
+
+	got, err := TransformWhere("{'status'.EX.'Open'} AND {dueDate.OBF.'today'}", schema, "bqxyz123")
+	if err != nil {
+		t.Fatalf("TransformWhere() error = %v", err)
+	}
+	if want := "{7.EX.'Open'} AND {8.OBF.'today'}"; got != want {
+		t.Errorf("TransformWhere() = %s, want %s", got, want)
+	}
+
+	_, err = TransformWhere("{6.EX.'a'}OR{'stauts'.EX.'Open'}", schema, "bqxyz123")
+	var schemaErr *SchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("expected SchemaError, got %v", err)
+	}
+	if schemaErr.Pos != 13 || schemaErr.Token != "'stauts'" {
+		t.Errorf("error at %d %q, want 13 'stauts'", schemaErr.Pos, schemaErr.Token)
+	}
+	if !strings.Contains(err.Error(), "Did you mean 'status'?") {
+		t.Errorf("expected suggestion in error, got %v", err)
+	}
+
+	// Aliases require a schema; field IDs do not
+	if _, err := TransformWhere("{status.EX.'Open'}", nil, "bqxyz123"); err == nil {
+		t.Error("expected error for alias without schema")
+	}
+	if got, err := TransformWhere("{7.EX.'Open'}", nil, "bqxyz123"); err != nil || got != "{7.EX.'Open'}" {
+		t.Errorf("TransformWhere(no schema) = %q, %v", got, err)
+	}
+}
+
+func TestPrettyWhere(t *testing.T) {
+	node, err := ParseWhere("{7.EX.'Open'}AND({8.GT.1}OR({8.LT.0}AND{6.CT.'x'}))")
+	if err != nil {
+		t.Fatalf("ParseWhere() error = %v", err)
+	}
+	want := strings.Join([]string{
+		"{7.EX.'Open'}",
+		"AND (",
+		"  {8.GT.'1'}",
+		"  OR (",
+		"    {8.LT.'0'}",
+		"    AND {6.CT.'x'}",
+		"  )",
+		")",
+	}, "\n")
+	if got := PrettyWhere(node); got != want {
+		t.Errorf("PrettyWhere() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWalkWhere(t *testing.T) {
+	node, err := ParseWhere("{6.EX.'a'}OR({7.GT.1}AND{status.EX.x})")
+	if err != nil {
+		t.Fatalf("ParseWhere() error = %v", err)
+	}
+	var fields []string
+	_ = WalkWhere(node, func(c *WhereClause) error {
+		fields = append(fields, c.Field)
+		return nil
+	})
+	if strings.Join(fields, ",") != "6,7,status" {
+		t.Errorf("fields = %v, want [6 7 status]", fields)
+	}
+}
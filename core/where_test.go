@@ -0,0 +1,129 @@
+package core
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func whereTestSchema() *ResolvedSchema {
+	return ResolveSchema(NewSchema().
+		Table("projects", "bqxyz123").
+		Field("name", 6).
+		Field("status", 7).
+		Field("dueDate", 8).
+		Build())
+}
+
+func TestConditionBuild(t *testing.T) {
+	due := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	stamp := time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		cond Condition
+		want string
+	}{
+		{"field ID", F(7).EX("Open"), "{7.EX.'Open'}"},
+		{"alias", F("status").EX("Open"), "{7.EX.'Open'}"},
+		{"numeric string field", F("7").CT("x"), "{7.CT.'x'}"},
+		{"escapes quotes", F("name").EX("O'Brien"), `{6.EX.'O\'Brien'}`},
+		{"escapes backslashes", F("name").CT(`a\b`), `{6.CT.'a\\b'}`},
+		{"braces stay quoted", F("name").EX("{x}.y"), "{6.EX.'{x}.y'}"},
+		{"date", F("dueDate").OBF(due), "{8.OBF.'2024-03-15'}"},
+		{"timestamp", F("dueDate").OAF(stamp), "{8.OAF.'1710498600000'}"},
+		{"relative range", F("dueDate").IR("last 7 days"), "{8.IR.'last 7 days'}"},
+		{"integer", F(9).GT(100), "{9.GT.'100'}"},
+		{"float", F(9).LTE(2.5), "{9.LTE.'2.5'}"},
+		{"bool", F(10).EX(true), "{10.EX.'true'}"},
+		{"duration", F(11).GTE(90 * time.Second), "{11.GTE.'90000'}"},
+		{"empty value", F("name").XEX(nil), "{6.XEX.''}"},
+		{
+			"and",
+			F("status").EX("Open").And(F("dueDate").BF(due)),
+			"{7.EX.'Open'}AND{8.BF.'2024-03-15'}",
+		},
+		{
+			"and flattens",
+			F(6).SW("A").And(F(6).XSW("Ab")).And(F(6).XCT("z")),
+			"{6.SW.'A'}AND{6.XSW.'Ab'}AND{6.XCT.'z'}",
+		},
+		{
+			"nested groups get parentheses",
+			F(7).EX("Open").Or(F(7).EX("Pending")).And(F(9).GT(1)),
+			"({7.EX.'Open'}OR{7.EX.'Pending'})AND{9.GT.'1'}",
+		},
+		{
+			"or of ands",
+			Or(And(F(6).HAS("a"), F(6).XHAS("b")), F(6).TV("c")),
+			"({6.HAS.'a'}AND{6.XHAS.'b'})OR{6.TV.'c'}",
+		},
+		{"empty conditions ignored", And(Condition{}, F(6).LT(1), Or()), "{6.LT.'1'}"},
+		{"zero condition", Condition{}, ""},
+	}
+
+	schema := whereTestSchema()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.cond.Build(schema, "bqxyz123")
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Build() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConditionBuildErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		cond   Condition
+		schema *ResolvedSchema
+	}{
+		{"unknown operator", F(6).Op("EQ", "x"), nil},
+		{"lowercase operator", F(6).Op("ex", "x"), nil},
+		{"unknown alias", F("stauts").EX("x"), whereTestSchema()},
+		{"alias without schema", F("status").EX("x"), nil},
+		{"bad field type", F(6.5).EX("x"), nil},
+		{"unsupported value", F(6).EX([]string{"a"}), nil},
+		{"error inside group", F(6).EX("a").And(F(7).Op("BAD", "b")), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.cond.Build(tt.schema, "bqxyz123")
+			var schemaErr *SchemaError
+			if !errors.As(err, &schemaErr) {
+				t.Errorf("Build() error = %v, want SchemaError", err)
+			}
+		})
+	}
+}
+
+func TestConditionString(t *testing.T) {
+	cond := F("status").EX("Open").And(F(8).OBF(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)))
+	want := "{'status'.EX.'Open'}AND{8.OBF.'2024-01-02'}"
+	if got := cond.String(); got != want {
+		t.Errorf("String() = %s, want %s", got, want)
+	}
+
+	// Aliases written by String are resolved by the request transformation
+	got, err := transformWhereClause(cond.String(), whereTestSchema(), "bqxyz123")
+	if err != nil {
+		t.Fatalf("transformWhereClause() error = %v", err)
+	}
+	if want := "{7.EX.'Open'}AND{8.OBF.'2024-01-02'}"; got != want {
+		t.Errorf("transformed = %s, want %s", got, want)
+	}
+
+	invalid := F(6).Op("NOPE", "x").Or(F(7).EX("y"))
+	if got := invalid.String(); !strings.HasPrefix(got, "{0.EX.'<invalid: ") {
+		t.Errorf("String() of invalid condition = %q, want a field 0 clause", got)
+	}
+	if _, err := ParseWhere(invalid.String()); err != nil {
+		t.Errorf("String() of invalid condition does not parse: %v", err)
+	}
+}
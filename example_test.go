@@ -332,6 +332,19 @@ func ExampleInts() {
 	_ = body
 }
 
+// Value writes numbers with every digit, so large IDs and amounts survive an
+// upsert unchanged.
+func ExampleValue() {
+	amount, _ := quickbase.ParseDecimal("1234567890.125")
+	for _, v := range []any{12345678901234567, amount} {
+		data, _ := quickbase.Value(v).Value.MarshalJSON()
+		fmt.Println(string(data))
+	}
+	// Output:
+	// 12345678901234567
+	// 1234567890.125
+}
+
 // Use schema aliases for readable table and field names.
 func ExampleWithSchema() {
 	// Define schema with readable names
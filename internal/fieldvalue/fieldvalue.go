@@ -0,0 +1,79 @@
+// Package fieldvalue converts record values to the numbers, booleans and text
+// that where clauses and sorting compare them as.
+package fieldvalue
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Float converts a numeric value or numeric string to float64.
+func Float(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	case interface{ Float64() float64 }: // core.Decimal
+		return n.Float64(), true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// ParseBool parses a checkbox value written as true/false, 1/0 or yes/no.
+func ParseBool(s string) (bool, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "true", "1", "yes":
+		return true, true
+	case "false", "0", "no":
+		return false, true
+	}
+	return false, false
+}
+
+// Text renders a value as text for text comparisons and sorting. User values
+// render as their email, or their name if there is no email.
+func Text(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case float64:
+		if val == math.Trunc(val) && math.Abs(val) < 1e15 {
+			return strconv.FormatInt(int64(val), 10)
+		}
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(val)
+	case time.Time:
+		return val.UTC().Format(time.RFC3339)
+	case map[string]any:
+		if email, _ := val["email"].(string); email != "" {
+			return email
+		}
+		name, _ := val["name"].(string)
+		return name
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,397 @@
+package qbtest
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrConnectionReset is the error a ConnectionReset fault fails requests
+// with. It stands in for ECONNRESET, which not every platform defines.
+var ErrConnectionReset = errors.New("connection reset by peer")
+
+// FaultKind identifies the failure a Fault injects.
+type FaultKind int
+
+const (
+	// FaultNone passes the request through unchanged.
+	FaultNone FaultKind = iota
+
+	// FaultRateLimit responds 429 Too Many Requests with a Retry-After header.
+	FaultRateLimit
+
+	// FaultServerError responds with a 5xx status.
+	FaultServerError
+
+	// FaultConnectionReset fails the request with a connection reset error.
+	FaultConnectionReset
+
+	// FaultSlow delays the request, then passes it through.
+	FaultSlow
+
+	// FaultUnauthorized rejects the credentials so the client refreshes its
+	// token: 401 for JSON requests, errcode 8 (invalid ticket) for XML requests.
+	FaultUnauthorized
+
+	// FaultXMLError responds to XML API requests with a non-zero errcode.
+	// JSON requests pass through.
+	FaultXMLError
+)
+
+// String returns the fault kind's name.
+func (k FaultKind) String() string {
+	switch k {
+	case FaultNone:
+		return "none"
+	case FaultRateLimit:
+		return "rate limit"
+	case FaultServerError:
+		return "server error"
+	case FaultConnectionReset:
+		return "connection reset"
+	case FaultSlow:
+		return "slow"
+	case FaultUnauthorized:
+		return "unauthorized"
+	case FaultXMLError:
+		return "xml error"
+	}
+	return "unknown"
+}
+
+// Fault describes one injected failure. Build faults with [RateLimit],
+// [ServerError], [ConnectionReset], [Slow], [Unauthorized] and [XMLError].
+type Fault struct {
+	Kind FaultKind
+
+	// Status is the HTTP status for FaultServerError (default 503).
+	Status int
+
+	// RetryAfter is the Retry-After delay for FaultRateLimit, sent in whole
+	// seconds (rounded up).
+	RetryAfter time.Duration
+
+	// Delay is how long FaultSlow waits before passing the request through.
+	Delay time.Duration
+
+	// ErrCode and ErrText are the XML error for FaultXMLError.
+	ErrCode int
+	ErrText string
+}
+
+// Pass returns a fault that lets the request through, for use in sequences.
+func Pass() Fault {
+	return Fault{Kind: FaultNone}
+}
+
+// RateLimit returns a 429 fault with the given Retry-After delay.
+func RateLimit(retryAfter time.Duration) Fault {
+	return Fault{Kind: FaultRateLimit, RetryAfter: retryAfter}
+}
+
+// ServerError returns a fault that responds with the given 5xx status.
+func ServerError(status int) Fault {
+	return Fault{Kind: FaultServerError, Status: status}
+}
+
+// ConnectionReset returns a fault that fails the request with a *net.OpError
+// wrapping ErrConnectionReset.
+func ConnectionReset() Fault {
+	return Fault{Kind: FaultConnectionReset}
+}
+
+// Slow returns a fault that delays the request by d.
+func Slow(d time.Duration) Fault {
+	return Fault{Kind: FaultSlow, Delay: d}
+}
+
+// Unauthorized returns a fault that forces a token refresh.
+func Unauthorized() Fault {
+	return Fault{Kind: FaultUnauthorized}
+}
+
+// XMLError returns a fault that fails XML API requests with the given errcode.
+func XMLError(code int, text string) Fault {
+	return Fault{Kind: FaultXMLError, ErrCode: code, ErrText: text}
+}
+
+// Repeat returns n copies of f, for scripting bursts such as five 503s in a row.
+func Repeat(n int, f Fault) []Fault {
+	out := make([]Fault, n)
+	for i := range out {
+		out[i] = f
+	}
+	return out
+}
+
+// InjectedFault records a fault the injector applied.
+type InjectedFault struct {
+	Method string // HTTP method
+	Path   string // URL path
+	Action string // XML action ("" for JSON requests)
+	Fault  Fault
+}
+
+// FaultOption configures a FaultInjector.
+type FaultOption func(*FaultInjector)
+
+// WithFaultSequence scripts the faults for successive matching requests:
+// the first request gets faults[0], the second faults[1], and so on. Once the
+// sequence is exhausted, requests fall back to the configured probabilities.
+func WithFaultSequence(faults ...Fault) FaultOption {
+	return func(fi *FaultInjector) {
+		fi.sequence = append(fi.sequence, faults...)
+	}
+}
+
+// WithFaultProbability injects f into each matching request with probability
+// p (0 to 1). Probabilities are checked in the order they are added, and at
+// most one fault is injected per request.
+func WithFaultProbability(f Fault, p float64) FaultOption {
+	return func(fi *FaultInjector) {
+		fi.random = append(fi.random, weightedFault{fault: f, p: p})
+	}
+}
+
+// WithFaultSeed seeds the random source used for probabilities, making
+// randomly injected faults reproducible. Defaults to the current time.
+func WithFaultSeed(seed int64) FaultOption {
+	return func(fi *FaultInjector) {
+		fi.rng = rand.New(rand.NewSource(seed))
+	}
+}
+
+// WithFaultMatch limits faults to requests for which match returns true.
+// Other requests pass through and do not advance the sequence.
+func WithFaultMatch(match func(*http.Request) bool) FaultOption {
+	return func(fi *FaultInjector) {
+		fi.match = match
+	}
+}
+
+// WithFaultTransport sets the transport that requests are passed to
+// (default http.DefaultTransport).
+func WithFaultTransport(next http.RoundTripper) FaultOption {
+	return func(fi *FaultInjector) {
+		fi.next = next
+	}
+}
+
+type weightedFault struct {
+	fault Fault
+	p     float64
+}
+
+// FaultInjector is an http.RoundTripper that injects failures into a client's
+// requests, so tests can assert how code behaves under rate limits, server
+// errors, network failures, latency and expired credentials. Install it with
+// quickbase.WithTransport:
+//
+//	faults := qbtest.NewFaultInjector(
+//	    qbtest.WithFaultSequence(qbtest.RateLimit(time.Second), qbtest.ServerError(503)),
+//	)
+//	client, _ := quickbase.New("myrealm",
+//	    quickbase.WithUserToken("test-token"),
+//	    quickbase.WithBaseURL(srv.BaseURL()),
+//	    quickbase.WithTransport(faults),
+//	)
+//
+// Each retry is a separate request, so a sequence of two faults followed by
+// a successful response exercises two retries.
+type FaultInjector struct {
+	mu       sync.Mutex
+	next     http.RoundTripper
+	sequence []Fault
+	random   []weightedFault
+	rng      *rand.Rand
+	match    func(*http.Request) bool
+	requests int
+	injected []InjectedFault
+}
+
+// NewFaultInjector creates a fault injector. Without options it passes every
+// request through.
+func NewFaultInjector(opts ...FaultOption) *FaultInjector {
+	fi := &FaultInjector{
+		next: http.DefaultTransport,
+		rng:  rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	for _, opt := range opts {
+		opt(fi)
+	}
+	return fi
+}
+
+// Requests returns the number of requests that matched, faulted or not.
+func (fi *FaultInjector) Requests() int {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	return fi.requests
+}
+
+// Injected returns the faults applied so far, in order.
+func (fi *FaultInjector) Injected() []InjectedFault {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	return append([]InjectedFault(nil), fi.injected...)
+}
+
+// Count returns how many faults of the given kind were applied.
+func (fi *FaultInjector) Count(kind FaultKind) int {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	n := 0
+	for _, in := range fi.injected {
+		if in.Fault.Kind == kind {
+			n++
+		}
+	}
+	return n
+}
+
+// RoundTrip implements http.RoundTripper.
+func (fi *FaultInjector) RoundTrip(req *http.Request) (*http.Response, error) {
+	action := req.Header.Get("QUICKBASE-ACTION")
+	fault, ok := fi.nextFault(req, action)
+	if !ok {
+		return fi.next.RoundTrip(req)
+	}
+
+	switch fault.Kind {
+	case FaultRateLimit:
+		resp := jsonResponse(req, http.StatusTooManyRequests, "Too Many Requests",
+			"Rate limit exceeded (injected by qbtest)")
+		resp.Header.Set("Retry-After", strconv.Itoa(int(math.Ceil(fault.RetryAfter.Seconds()))))
+		return resp, nil
+
+	case FaultServerError:
+		status := fault.Status
+		if status == 0 {
+			status = http.StatusServiceUnavailable
+		}
+		if action != "" {
+			return xmlResponse(req, status, action, 0, ""), nil
+		}
+		return jsonResponse(req, status, http.StatusText(status), "Injected by qbtest"), nil
+
+	case FaultConnectionReset:
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, &net.OpError{Op: "read", Net: "tcp", Err: ErrConnectionReset}
+
+	case FaultSlow:
+		timer := time.NewTimer(fault.Delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			if req.Body != nil {
+				req.Body.Close()
+			}
+			return nil, req.Context().Err()
+		}
+		return fi.next.RoundTrip(req)
+
+	case FaultUnauthorized:
+		if action != "" {
+			return xmlResponse(req, http.StatusOK, action, 8, "Invalid ticket (injected by qbtest)"), nil
+		}
+		return jsonResponse(req, http.StatusUnauthorized, "Unauthorized",
+			"Invalid or expired token (injected by qbtest)"), nil
+
+	case FaultXMLError:
+		return xmlResponse(req, http.StatusOK, action, fault.ErrCode, fault.ErrText), nil
+	}
+	return fi.next.RoundTrip(req)
+}
+
+// nextFault picks the fault for a request and records it. It returns false if
+// the request should pass through.
+func (fi *FaultInjector) nextFault(req *http.Request, action string) (Fault, bool) {
+	if fi.match != nil && !fi.match(req) {
+		return Fault{}, false
+	}
+
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+
+	fi.requests++
+	var fault Fault
+	if len(fi.sequence) > 0 {
+		fault = fi.sequence[0]
+		fi.sequence = fi.sequence[1:]
+	} else {
+		for _, w := range fi.random {
+			if fi.rng.Float64() < w.p {
+				fault = w.fault
+				break
+			}
+		}
+	}
+
+	if fault.Kind == FaultNone || (fault.Kind == FaultXMLError && action == "") {
+		return Fault{}, false
+	}
+	fi.injected = append(fi.injected, InjectedFault{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Action: action,
+		Fault:  fault,
+	})
+	return fault, true
+}
+
+// jsonResponse builds a JSON API error response.
+func jsonResponse(req *http.Request, status int, message, description string) *http.Response {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	body := fmt.Sprintf(`{"message":%q,"description":%q}`, message, description)
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// xmlResponse builds an XML API response with the given errcode.
+func xmlResponse(req *http.Request, status int, action string, errCode int, errText string) *http.Response {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "<?xml version=\"1.0\" ?>\n<qdbapi>\n<action>%s</action>\n<errcode>%d</errcode>\n<errtext>", action, errCode)
+	xml.EscapeText(&b, []byte(errText))
+	b.WriteString("</errtext>\n</qdbapi>")
+	body := b.String()
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"text/xml"}},
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// Ensure FaultInjector is an http.RoundTripper.
+var _ http.RoundTripper = (*FaultInjector)(nil)
@@ -0,0 +1,210 @@
+package qbtest_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2"
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/qbtest"
+)
+
+// newFaultClient starts a server with one app and returns a client whose
+// requests pass through the fault injector.
+func newFaultClient(t *testing.T, faults *qbtest.FaultInjector, opts ...quickbase.Option) (*quickbase.Client, string) {
+	t.Helper()
+
+	srv := qbtest.NewServer(qbtest.WithUserToken(testToken))
+	t.Cleanup(srv.Close)
+	appID := srv.CreateApp("Fault App")
+
+	opts = append([]quickbase.Option{
+		quickbase.WithUserToken(testToken),
+		quickbase.WithBaseURL(srv.BaseURL()),
+		quickbase.WithTransport(faults),
+		quickbase.WithRetryPolicy(quickbase.ExponentialBackoff{MaxAttempts: 5, InitialDelay: time.Millisecond}),
+	}, opts...)
+	client, err := quickbase.New("testrealm", opts...)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return client, appID
+}
+
+func TestFaultInjector_Sequence(t *testing.T) {
+	faults := qbtest.NewFaultInjector(qbtest.WithFaultSequence(
+		qbtest.Unauthorized(),
+		qbtest.RateLimit(0),
+		qbtest.ServerError(502),
+		qbtest.ConnectionReset(),
+	))
+	var statuses []int
+	client, appID := newFaultClient(t, faults,
+		quickbase.WithOnRequest(func(info quickbase.RequestInfo) { statuses = append(statuses, info.StatusCode) }))
+
+	app, err := client.GetApp(appID).Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if app.Name() != "Fault App" {
+		t.Errorf("Name() = %q", app.Name())
+	}
+	if faults.Requests() != 5 {
+		t.Errorf("Requests() = %d, want 5", faults.Requests())
+	}
+	injected := faults.Injected()
+	want := []qbtest.FaultKind{qbtest.FaultUnauthorized, qbtest.FaultRateLimit, qbtest.FaultServerError, qbtest.FaultConnectionReset}
+	if len(injected) != len(want) {
+		t.Fatalf("Injected() = %+v", injected)
+	}
+	for i, kind := range want {
+		if injected[i].Fault.Kind != kind || injected[i].Path != "/v1/apps/"+appID {
+			t.Errorf("Injected()[%d] = %+v, want %s", i, injected[i], kind)
+		}
+	}
+	// The refreshed 401 is not reported to onRequest
+	if len(statuses) != 4 || statuses[0] != 429 || statuses[1] != 502 || statuses[2] != 0 || statuses[3] != 200 {
+		t.Errorf("statuses = %v, want [429 502 0 200]", statuses)
+	}
+}
+
+func TestFaultInjector_RetriesExhausted(t *testing.T) {
+	faults := qbtest.NewFaultInjector(qbtest.WithFaultSequence(qbtest.Repeat(5, qbtest.ServerError(503))...))
+	client, appID := newFaultClient(t, faults)
+
+	_, err := client.GetApp(appID).Run(context.Background())
+	var serverErr *quickbase.ServerError
+	if !errors.As(err, &serverErr) {
+		t.Fatalf("error = %v, want ServerError", err)
+	}
+	if faults.Count(qbtest.FaultServerError) != 5 {
+		t.Errorf("Count(FaultServerError) = %d, want 5", faults.Count(qbtest.FaultServerError))
+	}
+}
+
+func TestFaultInjector_RateLimitHeaders(t *testing.T) {
+	faults := qbtest.NewFaultInjector(qbtest.WithFaultSequence(qbtest.RateLimit(1500 * time.Millisecond)))
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com/v1/apps/bqapp", nil)
+
+	resp, err := faults.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+	if resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") != "2" {
+		t.Errorf("status = %d, Retry-After = %q, want 429 and 2", resp.StatusCode, resp.Header.Get("Retry-After"))
+	}
+}
+
+func TestFaultInjector_ConnectionReset(t *testing.T) {
+	faults := qbtest.NewFaultInjector(qbtest.WithFaultSequence(qbtest.ConnectionReset()))
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com/v1/apps/bqapp", nil)
+
+	if _, err := faults.RoundTrip(req); !errors.Is(err, qbtest.ErrConnectionReset) {
+		t.Errorf("error = %v, want ErrConnectionReset", err)
+	}
+}
+
+func TestFaultInjector_Slow(t *testing.T) {
+	faults := qbtest.NewFaultInjector(qbtest.WithFaultSequence(qbtest.Slow(time.Second)))
+	client, appID := newFaultClient(t, faults, quickbase.WithRetryPolicy(quickbase.NeverRetry))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := client.GetApp(appID).Run(ctx); err == nil {
+		t.Fatal("expected timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("slow fault ignored cancellation: took %v", elapsed)
+	}
+}
+
+func TestFaultInjector_XML(t *testing.T) {
+	faults := qbtest.NewFaultInjector(qbtest.WithFaultSequence(
+		qbtest.XMLError(24, "Invalid Application Token & friends"),
+	))
+	client, _ := newFaultClient(t, faults)
+
+	body, err := client.DoXML(context.Background(), "bqapp", "API_GetRoleInfo", []byte("<qdbapi></qdbapi>"))
+	if err != nil {
+		t.Fatalf("DoXML() error = %v", err)
+	}
+	if !strings.Contains(string(body), "<errcode>24</errcode>") ||
+		!strings.Contains(string(body), "Invalid Application Token &amp; friends") {
+		t.Errorf("DoXML() = %s", body)
+	}
+	in := faults.Injected()
+	if len(in) != 1 || in[0].Action != "API_GetRoleInfo" {
+		t.Errorf("Injected() = %+v", in)
+	}
+}
+
+func TestFaultInjector_XMLErrorSkipsJSON(t *testing.T) {
+	faults := qbtest.NewFaultInjector(qbtest.WithFaultSequence(qbtest.XMLError(4, "User not authorized")))
+	client, appID := newFaultClient(t, faults)
+
+	if _, err := client.GetApp(appID).Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(faults.Injected()) != 0 {
+		t.Errorf("XML fault applied to a JSON request: %+v", faults.Injected())
+	}
+}
+
+func TestFaultInjector_ProbabilityAndMatch(t *testing.T) {
+	ok := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("{}")), Request: req}, nil
+	})
+	run := func(seed int64) []int {
+		faults := qbtest.NewFaultInjector(
+			qbtest.WithFaultTransport(ok),
+			qbtest.WithFaultSeed(seed),
+			qbtest.WithFaultProbability(qbtest.ServerError(500), 0.5),
+			qbtest.WithFaultMatch(func(req *http.Request) bool { return req.Method == http.MethodPost }),
+		)
+		var statuses []int
+		for i := 0; i < 20; i++ {
+			method := http.MethodPost
+			if i%4 == 0 {
+				method = http.MethodGet
+			}
+			req, _ := http.NewRequest(method, "http://example.com/v1/records", nil)
+			resp, err := faults.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("RoundTrip() error = %v", err)
+			}
+			if method == http.MethodGet && resp.StatusCode != http.StatusOK {
+				t.Errorf("unmatched GET was faulted")
+			}
+			statuses = append(statuses, resp.StatusCode)
+		}
+		if faults.Requests() != 15 {
+			t.Errorf("Requests() = %d, want 15 matching requests", faults.Requests())
+		}
+		return statuses
+	}
+
+	a, b := run(42), run(42)
+	faulted := 0
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("same seed gave different faults: %v vs %v", a, b)
+		}
+		if a[i] == 500 {
+			faulted++
+		}
+	}
+	if faulted == 0 || faulted == 15 {
+		t.Errorf("faulted %d of 15 requests, want some but not all", faulted)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,226 @@
+package qbtest
+
+import (
+	"cmp"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/core"
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/internal/fieldvalue"
+)
+
+// This file filters records with where clauses parsed by core.ParseWhere and
+// evaluated by core.MatchWhere, and sorts query results.
+
+// parseWhere parses a where clause with the core parser and checks that every
+// clause references an existing field by ID. An empty string parses to nil,
+// which matches every record.
+func parseWhere(query string, t *table) (core.WhereNode, error) {
+	node, err := core.ParseWhere(query)
+	if err != nil || node == nil {
+		return nil, err
+	}
+	err = core.WalkWhere(node, func(c *core.WhereClause) error {
+		fid, err := strconv.Atoi(c.Field)
+		if err != nil {
+			return fmt.Errorf("invalid query at position %d: field reference %q is not a field ID", c.FieldStart, c.Field)
+		}
+		if _, ok := t.fields[fid]; !ok {
+			return fmt.Errorf("invalid query at position %d: field %d does not exist", c.FieldStart, fid)
+		}
+		c.FieldID = fid
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// evalOptions returns the evaluation options for a table's field types.
+func (t *table) evalOptions(now time.Time) core.EvaluateOptions {
+	types := make(map[string]string, len(t.fields))
+	for fid, f := range t.fields {
+		types[strconv.Itoa(fid)] = f.typ
+	}
+	return core.EvaluateOptions{Now: now, FieldTypes: types}
+}
+
+// evalRecord keys a stored record by field ID for core.MatchWhere.
+func evalRecord(rec record) map[string]any {
+	out := make(map[string]any, len(rec))
+	for fid, v := range rec {
+		out[strconv.Itoa(fid)] = v
+	}
+	return out
+}
+
+type category int
+
+const (
+	categoryText category = iota
+	categoryNumeric
+	categoryDate
+	categoryTimestamp
+	categoryCheckbox
+	categoryList
+	categoryUser
+)
+
+// typeCategory groups QuickBase field types by comparison semantics.
+func typeCategory(fieldType string) category {
+	switch fieldType {
+	case "numeric", "currency", "percent", "rating", "duration", "recordid":
+		return categoryNumeric
+	case "date":
+		return categoryDate
+	case "timestamp", "datetime":
+		return categoryTimestamp
+	case "checkbox":
+		return categoryCheckbox
+	case "multitext", "multiuser":
+		return categoryList
+	case "user":
+		return categoryUser
+	default:
+		return categoryText
+	}
+}
+
+func isBlank(v any) bool {
+	switch val := v.(type) {
+	case nil:
+		return true
+	case string:
+		return val == ""
+	case []string:
+		return len(val) == 0
+	case []any:
+		return len(val) == 0
+	}
+	return false
+}
+
+// storedTime parses a stored date or timestamp value.
+func storedTime(v any) (time.Time, bool) {
+	s, ok := v.(string)
+	if !ok || s == "" {
+		return time.Time{}, false
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, true
+	}
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t.UTC(), true
+	}
+	return time.Time{}, false
+}
+
+// parseDateValue parses a date written to a date or timestamp field:
+// YYYY-MM-DD, MM-DD-YYYY, MM/DD/YYYY, an ISO timestamp or epoch milliseconds.
+func parseDateValue(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	for _, layout := range []string{"2006-01-02", "01-02-2006", "01/02/2006"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t.UTC(), true
+	}
+	if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.UnixMilli(ms).UTC(), true
+	}
+	return time.Time{}, false
+}
+
+// --- Value helpers ---
+
+func toStrings(v any) []string {
+	switch val := v.(type) {
+	case []string:
+		return val
+	case []any:
+		out := make([]string, 0, len(val))
+		for _, item := range val {
+			out = append(out, fieldvalue.Text(item))
+		}
+		return out
+	case nil:
+		return nil
+	}
+	return []string{fieldvalue.Text(v)}
+}
+
+// --- Sorting ---
+
+// sortKey is a single sort criterion.
+type sortKey struct {
+	fieldID int
+	desc    bool
+}
+
+// sortRecords sorts record IDs by the given keys, using record ID as the final tiebreaker.
+func sortRecords(t *table, ids []int, keys []sortKey) {
+	sort.SliceStable(ids, func(i, j int) bool {
+		a, b := t.records[ids[i]], t.records[ids[j]]
+		for _, k := range keys {
+			f := t.fields[k.fieldID]
+			if f == nil {
+				continue
+			}
+			c := compareValues(f.typ, a[k.fieldID], b[k.fieldID])
+			if c == 0 {
+				continue
+			}
+			if k.desc {
+				return c > 0
+			}
+			return c < 0
+		}
+		return ids[i] < ids[j]
+	})
+}
+
+// compareValues orders two values of the given field type. Blank values sort first.
+func compareValues(fieldType string, a, b any) int {
+	aBlank, bBlank := isBlank(a), isBlank(b)
+	switch {
+	case aBlank && bBlank:
+		return 0
+	case aBlank:
+		return -1
+	case bBlank:
+		return 1
+	}
+
+	switch typeCategory(fieldType) {
+	case categoryNumeric:
+		fa, _ := fieldvalue.Float(a)
+		fb, _ := fieldvalue.Float(b)
+		return cmp.Compare(fa, fb)
+	case categoryDate, categoryTimestamp:
+		ta, _ := storedTime(a)
+		tb, _ := storedTime(b)
+		return ta.Compare(tb)
+	case categoryCheckbox:
+		ba, _ := a.(bool)
+		bb, _ := b.(bool)
+		switch {
+		case ba == bb:
+			return 0
+		case !ba:
+			return -1
+		default:
+			return 1
+		}
+	case categoryList:
+		return cmp.Compare(strings.ToLower(strings.Join(toStrings(a), ";")), strings.ToLower(strings.Join(toStrings(b), ";")))
+	case categoryUser:
+		return cmp.Compare(strings.ToLower(fieldvalue.Text(a)), strings.ToLower(fieldvalue.Text(b)))
+	}
+	return cmp.Compare(strings.ToLower(fieldvalue.Text(a)), strings.ToLower(fieldvalue.Text(b)))
+}
@@ -0,0 +1,560 @@
+package qbtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/core"
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/internal/fieldvalue"
+)
+
+// --- RunQuery ---
+
+type runQueryRequest struct {
+	From    string          `json:"from"`
+	Select  []int           `json:"select"`
+	Where   string          `json:"where"`
+	SortBy  json.RawMessage `json:"sortBy"`
+	GroupBy []struct {
+		FieldID  int    `json:"fieldId"`
+		Grouping string `json:"grouping"`
+	} `json:"groupBy"`
+	Options struct {
+		Skip *int `json:"skip"`
+		Top  *int `json:"top"`
+	} `json:"options"`
+}
+
+func (s *Server) handleRunQuery(w http.ResponseWriter, r *http.Request) {
+	var body runQueryRequest
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.lookupTable(w, body.From)
+	if !ok {
+		return
+	}
+
+	selected := body.Select
+	if len(selected) == 0 {
+		selected = t.fieldOrder
+	}
+	for _, fid := range selected {
+		if t.fields[fid] == nil {
+			writeError(w, http.StatusBadRequest, "Bad Request", fmt.Sprintf("Field %d does not exist in table %s", fid, t.id))
+			return
+		}
+	}
+
+	keys, err := parseSortBy(body.SortBy)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Bad Request", err.Error())
+		return
+	}
+	// Grouping sorts by the group fields first, ascending.
+	groupKeys := make([]sortKey, 0, len(body.GroupBy))
+	for _, g := range body.GroupBy {
+		groupKeys = append(groupKeys, sortKey{fieldID: g.FieldID})
+	}
+	keys = append(groupKeys, keys...)
+	for _, k := range keys {
+		if t.fields[k.fieldID] == nil {
+			writeError(w, http.StatusBadRequest, "Bad Request", fmt.Sprintf("Sort field %d does not exist in table %s", k.fieldID, t.id))
+			return
+		}
+	}
+
+	ids, ok := s.matchRecords(w, t, body.Where)
+	if !ok {
+		return
+	}
+	sortRecords(t, ids, keys)
+
+	skip := 0
+	if body.Options.Skip != nil && *body.Options.Skip > 0 {
+		skip = *body.Options.Skip
+	}
+	top := s.pageSize
+	if body.Options.Top != nil && *body.Options.Top > 0 && *body.Options.Top < top {
+		top = *body.Options.Top
+	}
+	total := len(ids)
+	page := ids[min(skip, total):min(skip+top, total)]
+
+	data := make([]map[string]any, 0, len(page))
+	for _, id := range page {
+		data = append(data, t.recordJSON(t.records[id], selected))
+	}
+	fields := make([]map[string]any, 0, len(selected))
+	for _, fid := range selected {
+		f := t.fields[fid]
+		fields = append(fields, map[string]any{"id": f.id, "label": f.label, "type": f.typ})
+	}
+
+	metadata := map[string]any{
+		"totalRecords": total,
+		"numRecords":   len(data),
+		"numFields":    len(selected),
+		"skip":         skip,
+	}
+	if body.Options.Top != nil {
+		metadata["top"] = *body.Options.Top
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"data":     data,
+		"fields":   fields,
+		"metadata": metadata,
+	})
+}
+
+// parseSortBy accepts either false (no sorting) or a list of {fieldId, order}.
+func parseSortBy(raw json.RawMessage) ([]sortKey, error) {
+	if len(raw) == 0 || string(raw) == "null" || string(raw) == "false" {
+		return nil, nil
+	}
+	var items []struct {
+		FieldID int    `json:"fieldId"`
+		Order   string `json:"order"`
+	}
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, fmt.Errorf("invalid sortBy: %v", err)
+	}
+	keys := make([]sortKey, 0, len(items))
+	for _, item := range items {
+		keys = append(keys, sortKey{fieldID: item.FieldID, desc: strings.EqualFold(item.Order, "DESC")})
+	}
+	return keys, nil
+}
+
+// matchRecords returns the IDs of records matching a where clause, writing a
+// 400 response if the clause is invalid. Callers hold s.mu.
+func (s *Server) matchRecords(w http.ResponseWriter, t *table, where string) ([]int, bool) {
+	node, err := parseWhere(where, t)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Bad Request", err.Error())
+		return nil, false
+	}
+	opts := t.evalOptions(s.now())
+	var ids []int
+	for _, id := range t.recordIDs() {
+		if core.MatchWhere(node, evalRecord(t.records[id]), opts) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, true
+}
+
+// recordJSON renders selected fields in the {"fid": {"value": v}} wire format.
+func (t *table) recordJSON(rec record, fieldIDs []int) map[string]any {
+	out := make(map[string]any, len(fieldIDs))
+	for _, fid := range fieldIDs {
+		v, ok := rec[fid]
+		if !ok {
+			v = blankValue(t.fields[fid].typ)
+		}
+		out[strconv.Itoa(fid)] = map[string]any{"value": v}
+	}
+	return out
+}
+
+// blankValue returns the value QuickBase reports for an empty field.
+func blankValue(fieldType string) any {
+	switch typeCategory(fieldType) {
+	case categoryNumeric, categoryUser:
+		return nil
+	case categoryCheckbox:
+		return false
+	case categoryList:
+		return []string{}
+	}
+	return ""
+}
+
+// --- Upsert ---
+
+func (s *Server) handleUpsert(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		To   string `json:"to"`
+		Data []map[string]struct {
+			Value any `json:"value"`
+		} `json:"data"`
+		MergeFieldID   *int  `json:"mergeFieldId"`
+		FieldsToReturn []int `json:"fieldsToReturn"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.lookupTable(w, body.To)
+	if !ok {
+		return
+	}
+	mergeID := FieldRecordID
+	if body.MergeFieldID != nil {
+		mergeID = *body.MergeFieldID
+	}
+	if mf := t.fields[mergeID]; mf == nil || !mf.unique {
+		writeError(w, http.StatusBadRequest, "Bad Request", fmt.Sprintf("Merge field %d must be a unique field", mergeID))
+		return
+	}
+
+	now := s.now()
+	created, updated, unchanged := []int{}, []int{}, []int{}
+	lineErrors := map[string][]string{}
+	var returned []map[string]any
+
+	for i, raw := range body.Data {
+		line := strconv.Itoa(i + 1)
+
+		values, errs := t.coerceRecord(raw)
+		if len(errs) > 0 {
+			lineErrors[line] = errs
+			continue
+		}
+
+		existing := 0
+		if mv, ok := values[mergeID]; ok && !isBlank(mv) {
+			existing = t.findByValue(mergeID, mv)
+			if existing == 0 && mergeID == FieldRecordID {
+				lineErrors[line] = []string{fmt.Sprintf("No record found with Record ID# %s.", fieldvalue.Text(mv))}
+				continue
+			}
+		}
+
+		var id int
+		switch {
+		case existing != 0:
+			id = existing
+			if err := t.checkUnique(values, id); err != "" {
+				lineErrors[line] = []string{err}
+				continue
+			}
+			if t.update(id, values, now, s.user) {
+				updated = append(updated, id)
+			} else {
+				unchanged = append(unchanged, id)
+			}
+		default:
+			if err := t.checkRequired(values); err != "" {
+				lineErrors[line] = []string{err}
+				continue
+			}
+			if err := t.checkUnique(values, 0); err != "" {
+				lineErrors[line] = []string{err}
+				continue
+			}
+			delete(values, FieldRecordID)
+			id = t.insert(values, now, s.user)
+			created = append(created, id)
+		}
+
+		if len(body.FieldsToReturn) > 0 {
+			fids := []int{FieldRecordID}
+			for _, fid := range body.FieldsToReturn {
+				if !slices.Contains(fids, fid) && t.fields[fid] != nil {
+					fids = append(fids, fid)
+				}
+			}
+			returned = append(returned, t.recordJSON(t.records[id], fids))
+		}
+	}
+
+	metadata := map[string]any{
+		"createdRecordIds":              created,
+		"updatedRecordIds":              updated,
+		"unchangedRecordIds":            unchanged,
+		"totalNumberOfRecordsProcessed": len(body.Data),
+	}
+	if len(lineErrors) > 0 {
+		metadata["lineErrors"] = lineErrors
+	}
+	if returned == nil {
+		returned = []map[string]any{}
+	}
+	// Line errors are reported in metadata with a 200 status so the
+	// successfully processed records are still visible to the caller.
+	writeJSON(w, http.StatusOK, map[string]any{
+		"data":     returned,
+		"metadata": metadata,
+	})
+}
+
+// coerceRecord converts wire values to stored values, returning line errors
+// for unknown fields, read-only fields and incompatible values.
+func (t *table) coerceRecord(raw map[string]struct {
+	Value any `json:"value"`
+}) (record, []string) {
+	values := make(record, len(raw))
+	var errs []string
+	for key, fv := range raw {
+		fid, err := strconv.Atoi(key)
+		f := t.fields[fid]
+		if err != nil || f == nil {
+			errs = append(errs, fmt.Sprintf("Field %q does not exist.", key))
+			continue
+		}
+		if f.builtin && fid != FieldRecordID {
+			errs = append(errs, fmt.Sprintf("Field %d (%s) is read only.", fid, f.label))
+			continue
+		}
+		v, ok := coerceValue(f.typ, fv.Value)
+		if !ok {
+			errs = append(errs, fmt.Sprintf("Incompatible value for field with ID %q.", key))
+			continue
+		}
+		values[fid] = v
+	}
+	slices.Sort(errs)
+	return values, errs
+}
+
+// coerceValue converts a wire value to the stored representation for a field type.
+func coerceValue(fieldType string, v any) (any, bool) {
+	if v == nil {
+		return nil, true
+	}
+	switch typeCategory(fieldType) {
+	case categoryNumeric:
+		switch n := v.(type) {
+		case float64:
+			return n, true
+		case string:
+			if strings.TrimSpace(n) == "" {
+				return nil, true
+			}
+			f, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+			return f, err == nil
+		}
+	case categoryCheckbox:
+		switch b := v.(type) {
+		case bool:
+			return b, true
+		case string:
+			return fieldvalue.ParseBool(b)
+		case float64:
+			return b != 0, true
+		}
+	case categoryDate:
+		switch d := v.(type) {
+		case string:
+			if d == "" {
+				return "", true
+			}
+			t, ok := parseDateValue(d)
+			if !ok {
+				return nil, false
+			}
+			return t.Format("2006-01-02"), true
+		case float64:
+			return time.UnixMilli(int64(d)).UTC().Format("2006-01-02"), true
+		}
+	case categoryTimestamp:
+		switch d := v.(type) {
+		case string:
+			if d == "" {
+				return "", true
+			}
+			t, ok := parseDateValue(d)
+			if !ok {
+				return nil, false
+			}
+			return t.UTC().Format(timestampFormat), true
+		case float64:
+			return time.UnixMilli(int64(d)).UTC().Format(timestampFormat), true
+		}
+	case categoryList:
+		switch l := v.(type) {
+		case []any:
+			out := make([]string, 0, len(l))
+			for _, item := range l {
+				s, ok := item.(string)
+				if !ok {
+					return nil, false
+				}
+				out = append(out, s)
+			}
+			return out, true
+		case string:
+			if l == "" {
+				return []string{}, true
+			}
+			return strings.Split(l, ";"), true
+		}
+	case categoryUser:
+		switch u := v.(type) {
+		case string:
+			return map[string]any{"id": u, "email": u, "name": u}, true
+		case map[string]any:
+			id, _ := u["id"].(string)
+			email, _ := u["email"].(string)
+			name, _ := u["name"].(string)
+			if id == "" {
+				id = email
+			}
+			if email == "" {
+				email = id
+			}
+			if name == "" {
+				name = email
+			}
+			return map[string]any{"id": id, "email": email, "name": name}, true
+		}
+	default:
+		switch s := v.(type) {
+		case string:
+			return s, true
+		case float64, bool:
+			return fieldvalue.Text(s), true
+		}
+	}
+	return nil, false
+}
+
+// findByValue returns the ID of the first record whose field equals v, or 0.
+func (t *table) findByValue(fieldID int, v any) int {
+	if fieldID == FieldRecordID {
+		if f, ok := fieldvalue.Float(v); ok {
+			if _, exists := t.records[int(f)]; exists {
+				return int(f)
+			}
+		}
+		return 0
+	}
+	for _, id := range t.recordIDs() {
+		if compareValues(t.fields[fieldID].typ, t.records[id][fieldID], v) == 0 {
+			return id
+		}
+	}
+	return 0
+}
+
+// checkRequired reports the first required field missing from a new record.
+func (t *table) checkRequired(values record) string {
+	for _, fid := range t.fieldOrder {
+		f := t.fields[fid]
+		if f.required && isBlank(values[fid]) {
+			return fmt.Sprintf("Missing value for required field with ID %q.", strconv.Itoa(fid))
+		}
+	}
+	return ""
+}
+
+// checkUnique reports the first unique field whose value is already used by
+// another record. self is the record being updated, or 0 for a new record.
+func (t *table) checkUnique(values record, self int) string {
+	for fid, v := range values {
+		f := t.fields[fid]
+		if !f.unique || f.builtin || isBlank(v) {
+			continue
+		}
+		if other := t.findByValue(fid, v); other != 0 && other != self {
+			return fmt.Sprintf("Value for unique field with ID %q is already in use.", strconv.Itoa(fid))
+		}
+	}
+	return ""
+}
+
+// --- DeleteRecords ---
+
+func (s *Server) handleDeleteRecords(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		From  string `json:"from"`
+		Where string `json:"where"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.lookupTable(w, body.From)
+	if !ok {
+		return
+	}
+	if strings.TrimSpace(body.Where) == "" {
+		writeError(w, http.StatusBadRequest, "Bad Request", "A where clause is required to delete records")
+		return
+	}
+	ids, ok := s.matchRecords(w, t, body.Where)
+	if !ok {
+		return
+	}
+	now := s.now()
+	for _, id := range ids {
+		t.remove(id, now)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"numberDeleted": len(ids)})
+}
+
+// --- RecordsModifiedSince ---
+
+func (s *Server) handleRecordsModifiedSince(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		From           string    `json:"from"`
+		After          time.Time `json:"after"`
+		IncludeDetails bool      `json:"includeDetails"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.lookupTable(w, body.From)
+	if !ok {
+		return
+	}
+
+	// Report the latest change per record, ordered by time.
+	latest := make(map[int]change)
+	var order []int
+	for _, c := range t.changes {
+		if !c.timestamp.After(body.After) {
+			continue
+		}
+		prev, seen := latest[c.recordID]
+		if !seen {
+			order = append(order, c.recordID)
+		}
+		// A record created and then modified within the window is still a CREATE.
+		if seen && prev.changeType == "CREATE" && c.changeType == "MODIFY" {
+			c.changeType = "CREATE"
+		}
+		latest[c.recordID] = c
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return latest[a].timestamp.Compare(latest[b].timestamp)
+	})
+
+	result := map[string]any{
+		"count":            len(order),
+		"deletesTruncated": false,
+	}
+	if body.IncludeDetails {
+		changes := make([]map[string]any, 0, len(order))
+		for _, id := range order {
+			c := latest[id]
+			changes = append(changes, map[string]any{
+				"recordId":   id,
+				"changeType": c.changeType,
+				"timestamp":  c.timestamp.UTC().Format(timestampFormat),
+			})
+		}
+		result["changes"] = changes
+	}
+	writeJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,370 @@
+package qbtest
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// --- Apps ---
+
+func (s *Server) handleCreateApp(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.Name == "" {
+		writeError(w, http.StatusBadRequest, "Bad Request", "App name is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, appJSON(s.createApp(body.Name, body.Description)))
+}
+
+func (s *Server) handleGetApp(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.lookupApp(w, r.PathValue("appId"))
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, appJSON(a))
+}
+
+func (s *Server) handleUpdateApp(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Name        *string `json:"name"`
+		Description *string `json:"description"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.lookupApp(w, r.PathValue("appId"))
+	if !ok {
+		return
+	}
+	if body.Name != nil {
+		a.name = *body.Name
+	}
+	if body.Description != nil {
+		a.description = *body.Description
+	}
+	a.updated = s.now()
+	writeJSON(w, http.StatusOK, appJSON(a))
+}
+
+func (s *Server) handleDeleteApp(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Name string `json:"name"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.lookupApp(w, r.PathValue("appId"))
+	if !ok {
+		return
+	}
+	if body.Name != a.name {
+		writeError(w, http.StatusBadRequest, "Bad Request", "App name does not match")
+		return
+	}
+	for _, tid := range append([]string(nil), a.tables...) {
+		s.deleteTable(s.tables[tid])
+	}
+	delete(s.apps, a.id)
+	for i, id := range s.appOrder {
+		if id == a.id {
+			s.appOrder = append(s.appOrder[:i], s.appOrder[i+1:]...)
+			break
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"deletedAppId": a.id})
+}
+
+func appJSON(a *app) map[string]any {
+	return map[string]any{
+		"id":          a.id,
+		"name":        a.name,
+		"description": a.description,
+		"created":     a.created.UTC().Format(timestampFormat),
+		"updated":     a.updated.UTC().Format(timestampFormat),
+		"dateFormat":  "MM-DD-YYYY",
+		"timeZone":    "(UTC) Coordinated Universal Time",
+	}
+}
+
+// lookupApp finds an app or writes an error response. Callers hold s.mu.
+func (s *Server) lookupApp(w http.ResponseWriter, appID string) (*app, bool) {
+	if !validID(appID) {
+		writeError(w, http.StatusBadRequest, "Bad Request", "Invalid app ID "+appID)
+		return nil, false
+	}
+	a, ok := s.apps[appID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "No such application", "Application "+appID+" does not exist")
+		return nil, false
+	}
+	return a, true
+}
+
+// --- Tables ---
+
+func (s *Server) handleCreateTable(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Name             string  `json:"name"`
+		Description      string  `json:"description"`
+		SingleRecordName *string `json:"singleRecordName"`
+		PluralRecordName *string `json:"pluralRecordName"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.Name == "" {
+		writeError(w, http.StatusBadRequest, "Bad Request", "Table name is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.lookupApp(w, r.URL.Query().Get("appId"))
+	if !ok {
+		return
+	}
+	t := s.createTable(a, body.Name, body.Description)
+	if body.SingleRecordName != nil {
+		t.singleRecordName = *body.SingleRecordName
+	}
+	if body.PluralRecordName != nil {
+		t.pluralRecordName = *body.PluralRecordName
+	}
+	writeJSON(w, http.StatusOK, tableJSON(t))
+}
+
+func (s *Server) handleGetAppTables(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.lookupApp(w, r.URL.Query().Get("appId"))
+	if !ok {
+		return
+	}
+	result := make([]map[string]any, 0, len(a.tables))
+	for _, tid := range a.tables {
+		result = append(result, tableJSON(s.tables[tid]))
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) handleGetTable(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.lookupTable(w, r.PathValue("tableId"))
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, tableJSON(t))
+}
+
+func (s *Server) handleDeleteTable(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.lookupTable(w, r.PathValue("tableId"))
+	if !ok {
+		return
+	}
+	s.deleteTable(t)
+	writeJSON(w, http.StatusOK, map[string]any{"deletedTableId": t.id})
+}
+
+func tableJSON(t *table) map[string]any {
+	single, plural := t.singleRecordName, t.pluralRecordName
+	if single == "" {
+		single = "Record"
+	}
+	if plural == "" {
+		plural = "Records"
+	}
+	return map[string]any{
+		"id":                 t.id,
+		"name":               t.name,
+		"alias":              "_DBID_" + t.id,
+		"description":        t.description,
+		"created":            t.created.UTC().Format(timestampFormat),
+		"updated":            t.updated.UTC().Format(timestampFormat),
+		"nextRecordId":       t.nextRecordID,
+		"nextFieldId":        t.nextFieldID,
+		"defaultSortFieldId": FieldRecordID,
+		"defaultSortOrder":   "ASC",
+		"keyFieldId":         FieldRecordID,
+		"singleRecordName":   single,
+		"pluralRecordName":   plural,
+	}
+}
+
+// lookupTable finds a table or writes an error response. Callers hold s.mu.
+func (s *Server) lookupTable(w http.ResponseWriter, tableID string) (*table, bool) {
+	if !validID(tableID) {
+		writeError(w, http.StatusBadRequest, "Bad Request", "Invalid table ID "+tableID)
+		return nil, false
+	}
+	t, ok := s.tables[tableID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "No such table", "Table "+tableID+" does not exist")
+		return nil, false
+	}
+	return t, true
+}
+
+// validID reports whether s looks like a QuickBase dbid.
+func validID(s string) bool {
+	if len(s) < 2 || s[0] != 'b' {
+		return false
+	}
+	for _, c := range s {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// --- Fields ---
+
+func (s *Server) handleGetFields(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.lookupTable(w, r.URL.Query().Get("tableId"))
+	if !ok {
+		return
+	}
+	result := make([]map[string]any, 0, len(t.fieldOrder))
+	for _, fid := range t.fieldOrder {
+		result = append(result, fieldJSON(t.fields[fid]))
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) handleGetField(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.lookupTable(w, r.URL.Query().Get("tableId"))
+	if !ok {
+		return
+	}
+	fid, err := strconv.Atoi(r.PathValue("fieldId"))
+	if err != nil || t.fields[fid] == nil {
+		writeError(w, http.StatusNotFound, "No such field", "Field "+r.PathValue("fieldId")+" does not exist")
+		return
+	}
+	writeJSON(w, http.StatusOK, fieldJSON(t.fields[fid]))
+}
+
+func (s *Server) handleCreateField(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Label      string `json:"label"`
+		FieldType  string `json:"fieldType"`
+		Required   bool   `json:"required"`
+		Unique     bool   `json:"unique"`
+		Properties struct {
+			Choices []string `json:"choices"`
+		} `json:"properties"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.Label == "" || body.FieldType == "" {
+		writeError(w, http.StatusBadRequest, "Bad Request", "Field label and fieldType are required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.lookupTable(w, r.URL.Query().Get("tableId"))
+	if !ok {
+		return
+	}
+	if t.fieldByLabel(body.Label) != nil {
+		writeError(w, http.StatusBadRequest, "Bad Request", "A field with label '"+body.Label+"' already exists")
+		return
+	}
+	f := t.addField(Field{
+		Label:    body.Label,
+		Type:     body.FieldType,
+		Choices:  body.Properties.Choices,
+		Required: body.Required,
+		Unique:   body.Unique,
+	})
+	t.updated = s.now()
+	writeJSON(w, http.StatusOK, fieldJSON(f))
+}
+
+func (s *Server) handleDeleteFields(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		FieldIds []int `json:"fieldIds"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.lookupTable(w, r.URL.Query().Get("tableId"))
+	if !ok {
+		return
+	}
+	deleted := []int{}
+	errs := []string{}
+	for _, fid := range body.FieldIds {
+		f, exists := t.fields[fid]
+		switch {
+		case !exists:
+			errs = append(errs, "Field: "+strconv.Itoa(fid)+" does not exist.")
+		case f.builtin:
+			errs = append(errs, "Field: "+strconv.Itoa(fid)+" is a built in field and cannot be deleted.")
+		default:
+			t.deleteField(fid)
+			deleted = append(deleted, fid)
+		}
+	}
+	t.updated = s.now()
+	writeJSON(w, http.StatusOK, map[string]any{"deletedFieldIds": deleted, "errors": errs})
+}
+
+func fieldJSON(f *field) map[string]any {
+	props := map[string]any{}
+	if len(f.choices) > 0 {
+		props["choices"] = f.choices
+	}
+	return map[string]any{
+		"id":               f.id,
+		"label":            f.label,
+		"fieldType":        f.typ,
+		"mode":             "",
+		"required":         f.required,
+		"unique":           f.unique,
+		"appearsByDefault": true,
+		"findEnabled":      true,
+		"doesDataCopy":     false,
+		"properties":       props,
+	}
+}
@@ -0,0 +1,569 @@
+// Package qbtest provides an in-process fake QuickBase server for offline testing.
+//
+// The server emulates the subset of the JSON API that most applications rely on:
+// apps, tables and fields, RunQuery (including where-clause evaluation, sortBy,
+// groupBy, skip/top and paging metadata), Upsert with mergeFieldId,
+// DeleteRecords, RecordsModifiedSince and GetFields. State lives in memory and
+// is discarded when the server is closed.
+//
+// Point a client at the server with WithBaseURL:
+//
+//	srv := qbtest.NewServer()
+//	defer srv.Close()
+//
+//	appID := srv.CreateApp("Projects App")
+//	tableID := srv.CreateTable(appID, "Projects",
+//	    qbtest.Field{Label: "Name", Type: "text"},
+//	    qbtest.Field{Label: "Budget", Type: "numeric"},
+//	)
+//
+//	client, _ := quickbase.New("myrealm",
+//	    quickbase.WithUserToken("test-token"),
+//	    quickbase.WithBaseURL(srv.BaseURL()),
+//	)
+//
+//	records, _ := client.Query(tableID).Where("{6.EX.'Apollo'}").Run(ctx)
+//
+// Every table is created with the QuickBase built-in fields: 1 (Date Created),
+// 2 (Date Modified), 3 (Record ID#), 4 (Record Owner) and 5 (Last Modified By).
+// User-defined fields are numbered from 6 unless an explicit ID is given.
+//
+// To test failure handling, install a [FaultInjector] on the client with
+// quickbase.WithTransport.
+//
+// The server is not a full QuickBase implementation. Formulas, lookups,
+// relationships, permissions and reports are not emulated, and responses only
+// carry the properties that the SDK reads.
+package qbtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Built-in field IDs present on every QuickBase table.
+const (
+	FieldDateCreated    = 1
+	FieldDateModified   = 2
+	FieldRecordID       = 3
+	FieldRecordOwner    = 4
+	FieldLastModifiedBy = 5
+)
+
+// DefaultPageSize is the maximum number of records returned by a single
+// RunQuery call unless overridden with WithPageSize.
+const DefaultPageSize = 1000
+
+// timestampFormat is the ISO 8601 format QuickBase uses for timestamps.
+const timestampFormat = "2006-01-02T15:04:05.000Z"
+
+// Field describes a field to create with [Server.CreateTable] or [Server.CreateField].
+type Field struct {
+	// ID is the field ID. When zero, the next free ID (starting at 6) is used.
+	ID int
+
+	// Label is the field label (name).
+	Label string
+
+	// Type is the QuickBase field type, such as "text", "numeric", "date",
+	// "timestamp", "checkbox", "multitext" or "user". Defaults to "text".
+	Type string
+
+	// Choices lists the allowed values for multiple-choice fields.
+	Choices []string
+
+	// Required marks the field as required.
+	Required bool
+
+	// Unique marks the field as unique. Upserts may merge on unique fields.
+	Unique bool
+}
+
+// User is the identity the server reports in user fields (Record Owner,
+// Last Modified By) for records it creates or modifies.
+type User struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+	Name  string `json:"name"`
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithPageSize sets the maximum number of records returned per RunQuery page
+// (default [DefaultPageSize]). Use a small value to exercise pagination.
+func WithPageSize(n int) Option {
+	return func(s *Server) {
+		s.pageSize = n
+	}
+}
+
+// WithClock sets the time source used for Date Created, Date Modified and
+// RecordsModifiedSince. Defaults to time.Now.
+func WithClock(now func() time.Time) Option {
+	return func(s *Server) {
+		s.now = now
+	}
+}
+
+// WithUserToken requires every request to carry the given user token.
+// Requests with a missing or different token receive 401 Unauthorized.
+// By default any Authorization header is accepted.
+func WithUserToken(token string) Option {
+	return func(s *Server) {
+		s.userToken = token
+	}
+}
+
+// WithUser sets the identity written to Record Owner and Last Modified By.
+func WithUser(u User) Option {
+	return func(s *Server) {
+		s.user = u
+	}
+}
+
+// Server is an in-process fake of the QuickBase JSON API.
+// It embeds the underlying *httptest.Server, so URL and Close are available directly.
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	pageSize  int
+	now       func() time.Time
+	userToken string
+	user      User
+
+	apps       map[string]*app
+	appOrder   []string
+	tables     map[string]*table
+	nextAppID  int
+	nextTableN int
+}
+
+type app struct {
+	id          string
+	name        string
+	description string
+	created     time.Time
+	updated     time.Time
+	tables      []string
+}
+
+type table struct {
+	id               string
+	appID            string
+	name             string
+	description      string
+	singleRecordName string
+	pluralRecordName string
+	created          time.Time
+	updated          time.Time
+
+	fields       map[int]*field
+	fieldOrder   []int
+	nextFieldID  int
+	records      map[int]record
+	nextRecordID int
+	changes      []change
+}
+
+type field struct {
+	id       int
+	label    string
+	typ      string
+	choices  []string
+	required bool
+	unique   bool
+	builtin  bool
+}
+
+// record maps field IDs to stored values.
+type record map[int]any
+
+// change is an entry in a table's change log, used by RecordsModifiedSince.
+type change struct {
+	recordID   int
+	changeType string // CREATE, MODIFY or DELETE
+	timestamp  time.Time
+}
+
+// NewServer starts a fake QuickBase server. Call Close when finished.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		pageSize: DefaultPageSize,
+		now:      time.Now,
+		user: User{
+			ID:    "1.qbtest",
+			Email: "qbtest@example.com",
+			Name:  "QB Test",
+		},
+		apps:   make(map[string]*app),
+		tables: make(map[string]*table),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.Server = httptest.NewServer(s.routes())
+	return s
+}
+
+// BaseURL returns the JSON API base URL to pass to WithBaseURL.
+func (s *Server) BaseURL() string {
+	return s.URL + "/v1"
+}
+
+// routes registers the emulated endpoints.
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	// Apps
+	mux.HandleFunc("POST /v1/apps", s.handleCreateApp)
+	mux.HandleFunc("GET /v1/apps/{appId}", s.handleGetApp)
+	mux.HandleFunc("POST /v1/apps/{appId}", s.handleUpdateApp)
+	mux.HandleFunc("DELETE /v1/apps/{appId}", s.handleDeleteApp)
+
+	// Tables
+	mux.HandleFunc("POST /v1/tables", s.handleCreateTable)
+	mux.HandleFunc("GET /v1/tables", s.handleGetAppTables)
+	mux.HandleFunc("GET /v1/tables/{tableId}", s.handleGetTable)
+	mux.HandleFunc("DELETE /v1/tables/{tableId}", s.handleDeleteTable)
+
+	// Fields
+	mux.HandleFunc("GET /v1/fields", s.handleGetFields)
+	mux.HandleFunc("POST /v1/fields", s.handleCreateField)
+	mux.HandleFunc("DELETE /v1/fields", s.handleDeleteFields)
+	mux.HandleFunc("GET /v1/fields/{fieldId}", s.handleGetField)
+
+	// Records
+	mux.HandleFunc("POST /v1/records/query", s.handleRunQuery)
+	mux.HandleFunc("POST /v1/records", s.handleUpsert)
+	mux.HandleFunc("DELETE /v1/records", s.handleDeleteRecords)
+	mux.HandleFunc("POST /v1/records/modifiedSince", s.handleRecordsModifiedSince)
+
+	return s.authenticate(mux)
+}
+
+// authenticate rejects requests without the configured user token.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.userToken != "" && r.Header.Get("Authorization") != "QB-USER-TOKEN "+s.userToken {
+			writeError(w, http.StatusUnauthorized, "Access denied", "User token is invalid")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// --- Seeding and inspection ---
+
+// CreateApp creates an app and returns its ID.
+func (s *Server) CreateApp(name string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.createApp(name, "").id
+}
+
+// CreateTable creates a table in the given app and returns its ID.
+// The built-in fields 1-5 are always created; fields are added after them.
+// It panics if the app does not exist.
+func (s *Server) CreateTable(appID, name string, fields ...Field) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.apps[appID]
+	if !ok {
+		panic(fmt.Sprintf("qbtest: unknown app %q", appID))
+	}
+	t := s.createTable(a, name, "")
+	for _, f := range fields {
+		t.addField(f)
+	}
+	return t.id
+}
+
+// CreateField adds a field to a table and returns its ID.
+// It panics if the table does not exist.
+func (s *Server) CreateField(tableID string, f Field) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mustTable(tableID).addField(f).id
+}
+
+// FieldID returns the ID of the field with the given label, or 0 if not found.
+// It panics if the table does not exist.
+func (s *Server) FieldID(tableID, label string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if f := s.mustTable(tableID).fieldByLabel(label); f != nil {
+		return f.id
+	}
+	return 0
+}
+
+// AddRecords inserts records directly, bypassing the API, and returns the new
+// record IDs. Values are keyed by field ID and use the JSON API representation
+// (strings for dates, []string for multi-select). It panics if the table does
+// not exist.
+func (s *Server) AddRecords(tableID string, records ...map[int]any) []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.mustTable(tableID)
+	now := s.now()
+	ids := make([]int, 0, len(records))
+	for _, values := range records {
+		rec := make(record, len(values))
+		for fid, v := range values {
+			rec[fid] = v
+		}
+		ids = append(ids, t.insert(rec, now, s.user))
+	}
+	return ids
+}
+
+// Records returns a copy of all records in a table ordered by record ID.
+// It panics if the table does not exist.
+func (s *Server) Records(tableID string) []map[int]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.mustTable(tableID)
+	ids := t.recordIDs()
+	result := make([]map[int]any, len(ids))
+	for i, id := range ids {
+		result[i] = t.records[id].clone()
+	}
+	return result
+}
+
+// Record returns a copy of a single record, or nil if it does not exist.
+// It panics if the table does not exist.
+func (s *Server) Record(tableID string, recordID int) map[int]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if rec, ok := s.mustTable(tableID).records[recordID]; ok {
+		return rec.clone()
+	}
+	return nil
+}
+
+// --- Internal state management (callers hold s.mu) ---
+
+func (s *Server) createApp(name, description string) *app {
+	s.nextAppID++
+	now := s.now()
+	a := &app{
+		id:          fmt.Sprintf("bqapp%04d", s.nextAppID),
+		name:        name,
+		description: description,
+		created:     now,
+		updated:     now,
+	}
+	s.apps[a.id] = a
+	s.appOrder = append(s.appOrder, a.id)
+	return a
+}
+
+func (s *Server) createTable(a *app, name, description string) *table {
+	s.nextTableN++
+	now := s.now()
+	t := &table{
+		id:           fmt.Sprintf("bqtbl%04d", s.nextTableN),
+		appID:        a.id,
+		name:         name,
+		description:  description,
+		created:      now,
+		updated:      now,
+		fields:       make(map[int]*field),
+		nextFieldID:  6,
+		records:      make(map[int]record),
+		nextRecordID: 1,
+	}
+	for _, f := range []Field{
+		{ID: FieldDateCreated, Label: "Date Created", Type: "timestamp"},
+		{ID: FieldDateModified, Label: "Date Modified", Type: "timestamp"},
+		{ID: FieldRecordID, Label: "Record ID#", Type: "recordid", Unique: true},
+		{ID: FieldRecordOwner, Label: "Record Owner", Type: "user"},
+		{ID: FieldLastModifiedBy, Label: "Last Modified By", Type: "user"},
+	} {
+		t.addField(f).builtin = true
+	}
+	s.tables[t.id] = t
+	a.tables = append(a.tables, t.id)
+	return t
+}
+
+func (s *Server) deleteTable(t *table) {
+	delete(s.tables, t.id)
+	if a, ok := s.apps[t.appID]; ok {
+		for i, id := range a.tables {
+			if id == t.id {
+				a.tables = append(a.tables[:i], a.tables[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+func (s *Server) mustTable(tableID string) *table {
+	t, ok := s.tables[tableID]
+	if !ok {
+		panic(fmt.Sprintf("qbtest: unknown table %q", tableID))
+	}
+	return t
+}
+
+func (t *table) addField(f Field) *field {
+	id := f.ID
+	if id == 0 {
+		id = t.nextFieldID
+	}
+	if id >= t.nextFieldID {
+		t.nextFieldID = id + 1
+	}
+	typ := f.Type
+	if typ == "" {
+		typ = "text"
+	}
+	fd := &field{
+		id:       id,
+		label:    f.Label,
+		typ:      typ,
+		choices:  f.Choices,
+		required: f.Required,
+		unique:   f.Unique,
+	}
+	if _, exists := t.fields[id]; !exists {
+		t.fieldOrder = append(t.fieldOrder, id)
+	}
+	t.fields[id] = fd
+	return fd
+}
+
+func (t *table) deleteField(id int) {
+	delete(t.fields, id)
+	for i, fid := range t.fieldOrder {
+		if fid == id {
+			t.fieldOrder = append(t.fieldOrder[:i], t.fieldOrder[i+1:]...)
+			break
+		}
+	}
+	for _, rec := range t.records {
+		delete(rec, id)
+	}
+}
+
+func (t *table) fieldByLabel(label string) *field {
+	for _, id := range t.fieldOrder {
+		if strings.EqualFold(t.fields[id].label, label) {
+			return t.fields[id]
+		}
+	}
+	return nil
+}
+
+// insert stores a new record, stamping the built-in fields, and returns its ID.
+func (t *table) insert(rec record, now time.Time, user User) int {
+	id := t.nextRecordID
+	t.nextRecordID++
+
+	stamp := now.UTC().Format(timestampFormat)
+	rec[FieldRecordID] = float64(id)
+	rec[FieldDateCreated] = stamp
+	rec[FieldDateModified] = stamp
+	rec[FieldRecordOwner] = userValue(user)
+	rec[FieldLastModifiedBy] = userValue(user)
+
+	t.records[id] = rec
+	t.changes = append(t.changes, change{recordID: id, changeType: "CREATE", timestamp: now})
+	return id
+}
+
+// update merges values into an existing record. It returns false if no value changed.
+func (t *table) update(id int, values record, now time.Time, user User) bool {
+	rec := t.records[id]
+	changed := false
+	for fid, v := range values {
+		if fid == FieldRecordID {
+			continue
+		}
+		if !valuesEqual(rec[fid], v) {
+			rec[fid] = v
+			changed = true
+		}
+	}
+	if !changed {
+		return false
+	}
+	rec[FieldDateModified] = now.UTC().Format(timestampFormat)
+	rec[FieldLastModifiedBy] = userValue(user)
+	t.changes = append(t.changes, change{recordID: id, changeType: "MODIFY", timestamp: now})
+	return true
+}
+
+// remove deletes a record and logs the deletion.
+func (t *table) remove(id int, now time.Time) {
+	delete(t.records, id)
+	t.changes = append(t.changes, change{recordID: id, changeType: "DELETE", timestamp: now})
+}
+
+// recordIDs returns all record IDs in ascending order.
+func (t *table) recordIDs() []int {
+	ids := make([]int, 0, len(t.records))
+	for id := range t.records {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+func (r record) clone() map[int]any {
+	out := make(map[int]any, len(r))
+	for k, v := range r {
+		out[k] = v
+	}
+	return out
+}
+
+func userValue(u User) map[string]any {
+	return map[string]any{"id": u.ID, "email": u.Email, "name": u.Name}
+}
+
+// valuesEqual compares two stored values via their JSON encoding.
+func valuesEqual(a, b any) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(ja) == string(jb)
+}
+
+// --- HTTP helpers ---
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error body in the format QuickBase uses.
+func writeError(w http.ResponseWriter, status int, message, description string) {
+	writeJSON(w, status, map[string]any{
+		"message":     message,
+		"description": description,
+	})
+}
+
+// decodeBody decodes a JSON request body into v, writing a 400 on failure.
+func decodeBody(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "Bad Request", "Invalid JSON body: "+err.Error())
+		return false
+	}
+	return true
+}
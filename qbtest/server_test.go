@@ -0,0 +1,436 @@
+package qbtest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DrewBradfordXYZ/quickbase-go/v2"
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/generated"
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/qbtest"
+)
+
+const testToken = "test-token"
+
+// newTestClient starts a server with a Projects table and returns a client pointed at it.
+func newTestClient(t *testing.T, opts ...qbtest.Option) (*qbtest.Server, *quickbase.Client, string) {
+	t.Helper()
+
+	srv := qbtest.NewServer(append([]qbtest.Option{qbtest.WithUserToken(testToken)}, opts...)...)
+	t.Cleanup(srv.Close)
+
+	appID := srv.CreateApp("Test App")
+	tableID := srv.CreateTable(appID, "Projects",
+		qbtest.Field{Label: "Name", Type: "text", Unique: true},     // 6
+		qbtest.Field{Label: "Budget", Type: "numeric"},              // 7
+		qbtest.Field{Label: "Due", Type: "date"},                    // 8
+		qbtest.Field{Label: "Active", Type: "checkbox"},             // 9
+		qbtest.Field{Label: "Tags", Type: "multitext"},              // 10
+		qbtest.Field{Label: "Status", Type: "text-multiple-choice"}, // 11
+	)
+
+	client, err := quickbase.New("testrealm",
+		quickbase.WithUserToken(testToken),
+		quickbase.WithBaseURL(srv.BaseURL()),
+		quickbase.WithMaxRetries(1),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return srv, client, tableID
+}
+
+func seedProjects(srv *qbtest.Server, tableID string) {
+	srv.AddRecords(tableID,
+		map[int]any{6: "Apollo", 7: 5000.0, 8: "2024-01-15", 9: true, 10: []string{"space", "nasa"}, 11: "Open"},
+		map[int]any{6: "Gemini", 7: 1500.0, 8: "2024-06-15", 9: false, 10: []string{"space"}, 11: "Closed"},
+		map[int]any{6: "Mercury", 7: 800.0, 8: "2024-12-15", 9: true, 11: "Open"},
+		map[int]any{6: "O'Brien", 7: 42.5, 11: "Pending"},
+	)
+}
+
+func names(records []map[string]any) []string {
+	out := make([]string, len(records))
+	for i, r := range records {
+		out[i], _ = r["6"].(string)
+	}
+	return out
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestRunQueryWhere(t *testing.T) {
+	srv, client, tableID := newTestClient(t)
+	seedProjects(srv, tableID)
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		where string
+		want  []string
+	}{
+		{"all records", "", []string{"Apollo", "Gemini", "Mercury", "O'Brien"}},
+		{"text equals is case-insensitive", "{6.EX.'apollo'}", []string{"Apollo"}},
+		{"not equals", "{11.XEX.'Open'}", []string{"Gemini", "O'Brien"}},
+		{"contains", "{6.CT.'er'}", []string{"Mercury"}},
+		{"starts with", "{6.SW.'Ge'}", []string{"Gemini"}},
+		{"escaped quote", `{6.EX.'O\'Brien'}`, []string{"O'Brien"}},
+		{"numeric greater than", "{7.GT.1000}", []string{"Apollo", "Gemini"}},
+		{"numeric less or equal", "{7.LTE.800}", []string{"Mercury", "O'Brien"}},
+		{"date after", "{8.AF.2024-06-01}", []string{"Gemini", "Mercury"}},
+		{"date on or before", "{8.OBF.'06-15-2024'}", []string{"Apollo", "Gemini"}},
+		{"checkbox", "{9.EX.true}", []string{"Apollo", "Mercury"}},
+		{"multi-select has", "{10.HAS.'nasa'}", []string{"Apollo"}},
+		{"blank value", "{8.EX.''}", []string{"O'Brien"}},
+		{"and binds tighter than or", "{6.EX.'Apollo'} OR {9.EX.true} AND {7.LT.1000}", []string{"Apollo", "Mercury"}},
+		{"parentheses", "({6.EX.'Apollo'} OR {9.EX.true}) AND {7.LT.1000}", []string{"Mercury"}},
+		{"quoted field reference", "{'11'.EX.'Pending'}", []string{"O'Brien"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := client.Query(tableID).Select(6)
+			if tt.where != "" {
+				q = q.Where(tt.where)
+			}
+			records, err := q.Run(ctx)
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if got := names(records); !equalStrings(got, tt.want) {
+				t.Errorf("names = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunQueryInvalidWhere(t *testing.T) {
+	_, client, tableID := newTestClient(t)
+
+	for _, where := range []string{
+		"this is not valid query syntax",
+		"{99.EX.'x'}",
+		"{6.NOPE.'x'}",
+		"({6.EX.'x'}",
+	} {
+		_, err := client.Query(tableID).Where(where).Run(context.Background())
+		if err == nil {
+			t.Errorf("where %q: expected error", where)
+		}
+	}
+}
+
+func TestRunQueryCondition(t *testing.T) {
+	srv, client, tableID := newTestClient(t)
+	seedProjects(srv, tableID)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		cond quickbase.Condition
+		want []string
+	}{
+		{"quoted value", quickbase.F(6).EX("O'Brien"), []string{"O'Brien"}},
+		{"date", quickbase.F(8).OBF(time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)), []string{"Apollo", "Gemini"}},
+		{
+			"combined",
+			quickbase.F(11).EX("Open").And(quickbase.F(7).GT(1000).Or(quickbase.F(9).EX(false))),
+			[]string{"Apollo"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := client.Query(tableID).Where(tt.cond).SortBy(quickbase.Asc(6)).Run(ctx)
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if got := names(records); !equalStrings(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunQuerySortAndPaging(t *testing.T) {
+	srv, client, tableID := newTestClient(t, qbtest.WithPageSize(2))
+	seedProjects(srv, tableID)
+	ctx := context.Background()
+
+	t.Run("sort descending by number", func(t *testing.T) {
+		records, err := client.Query(tableID).Select(6).SortBy(quickbase.Desc(7)).Run(ctx)
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		want := []string{"Apollo", "Gemini", "Mercury", "O'Brien"}
+		if got := names(records); !equalStrings(got, want) {
+			t.Errorf("names = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("group by sorts groups first", func(t *testing.T) {
+		records, err := client.Query(tableID).Select(6).GroupBy(11).SortBy(quickbase.Desc(6)).Run(ctx)
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		want := []string{"Gemini", "Mercury", "Apollo", "O'Brien"}
+		if got := names(records); !equalStrings(got, want) {
+			t.Errorf("names = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("single page metadata", func(t *testing.T) {
+		resp, err := client.API().RunQueryWithResponse(ctx, generated.RunQueryJSONRequestBody{
+			From:    tableID,
+			Select:  quickbase.Ints(6),
+			Options: quickbase.Options(0, 1),
+		})
+		if err != nil {
+			t.Fatalf("RunQuery() error = %v", err)
+		}
+		m := resp.JSON200.Metadata
+		if m.TotalRecords != 4 || m.NumRecords != 2 || m.NumFields != 1 || *m.Skip != 1 {
+			t.Errorf("metadata = %+v, want total 4, num 2, fields 1, skip 1", m)
+		}
+		if got := names(quickbase.UnwrapRecords(*resp.JSON200.Data)); !equalStrings(got, []string{"Gemini", "Mercury"}) {
+			t.Errorf("names = %v", got)
+		}
+	})
+
+	t.Run("top limits results", func(t *testing.T) {
+		records, err := client.Query(tableID).Select(6).RunN(ctx, 3)
+		if err != nil {
+			t.Fatalf("RunN() error = %v", err)
+		}
+		if len(records) != 3 {
+			t.Errorf("len = %d, want 3", len(records))
+		}
+	})
+}
+
+func TestUpsert(t *testing.T) {
+	srv, client, tableID := newTestClient(t)
+	ctx := context.Background()
+
+	result, err := client.Upsert(tableID).
+		Data(
+			quickbase.Row(6, "Apollo", 7, 100, 10, []string{"a", "b"}),
+			quickbase.Row(6, "Gemini", 8, "2024-03-01"),
+		).
+		FieldsToReturn(6, 7).
+		Run(ctx)
+	if err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+	if got := result.Metadata().CreatedRecordIds(); len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("created = %v, want [1 2]", got)
+	}
+	if got := result.Records(); len(got) != 2 || got[0]["7"] != float64(100) {
+		t.Errorf("records = %v", got)
+	}
+
+	t.Run("merge on unique field updates", func(t *testing.T) {
+		result, err := client.Upsert(tableID).
+			Data(
+				quickbase.Row(6, "Apollo", 7, 250),
+				quickbase.Row(6, "Gemini", 8, "2024-03-01"),
+				quickbase.Row(6, "Mercury"),
+			).
+			MergeFieldId(6).
+			Run(ctx)
+		if err != nil {
+			t.Fatalf("Upsert() error = %v", err)
+		}
+		m := result.Metadata()
+		if got := m.UpdatedRecordIds(); len(got) != 1 || got[0] != 1 {
+			t.Errorf("updated = %v, want [1]", got)
+		}
+		if got := m.UnchangedRecordIds(); len(got) != 1 || got[0] != 2 {
+			t.Errorf("unchanged = %v, want [2]", got)
+		}
+		if got := m.CreatedRecordIds(); len(got) != 1 || got[0] != 3 {
+			t.Errorf("created = %v, want [3]", got)
+		}
+		if got := srv.Record(tableID, 1)[7]; got != float64(250) {
+			t.Errorf("budget = %v, want 250", got)
+		}
+	})
+
+	t.Run("line errors", func(t *testing.T) {
+		result, err := client.Upsert(tableID).
+			Data(
+				quickbase.Row(6, "Vostok", 7, "not a number"),
+				quickbase.Row(6, "Apollo"),
+				quickbase.Row(3, 999, 6, "Ghost"),
+			).
+			Run(ctx)
+		if err != nil {
+			t.Fatalf("Upsert() error = %v", err)
+		}
+		lineErrors := *result.Metadata().LineErrors
+		if len(lineErrors) != 3 {
+			t.Fatalf("lineErrors = %v, want 3 lines", lineErrors)
+		}
+		for _, line := range []string{"1", "2", "3"} {
+			if len(lineErrors[line]) == 0 {
+				t.Errorf("missing line error for line %s", line)
+			}
+		}
+	})
+
+	t.Run("merge field must be unique", func(t *testing.T) {
+		_, err := client.Upsert(tableID).Data(quickbase.Row(7, 1)).MergeFieldId(7).Run(ctx)
+		if err == nil {
+			t.Error("expected error for non-unique merge field")
+		}
+	})
+}
+
+func TestStructMapping(t *testing.T) {
+	_, client, tableID := newTestClient(t)
+	ctx := context.Background()
+
+	type project struct {
+		ID     int       `qb:"3,omitempty"`
+		Name   string    `qb:"6"`
+		Budget *float64  `qb:"7,omitempty"`
+		Due    time.Time `qb:"8,date"`
+		Tags   []string  `qb:"10"`
+	}
+
+	budget := 5000.0
+	_, err := quickbase.UpsertFrom(client.Upsert(tableID),
+		project{Name: "Apollo", Budget: &budget, Due: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), Tags: []string{"space"}},
+		project{Name: "Gemini"},
+	).Run(ctx)
+	if err != nil {
+		t.Fatalf("UpsertFrom() error = %v", err)
+	}
+
+	projects, err := quickbase.QueryInto[project](ctx, client.Query(tableID).SortBy(quickbase.Asc(6)))
+	if err != nil {
+		t.Fatalf("QueryInto() error = %v", err)
+	}
+	if len(projects) != 2 {
+		t.Fatalf("got %d projects, want 2", len(projects))
+	}
+	apollo := projects[0]
+	if apollo.ID != 1 || apollo.Name != "Apollo" || apollo.Budget == nil || *apollo.Budget != 5000 {
+		t.Errorf("apollo = %+v", apollo)
+	}
+	if apollo.Due.Format("2006-01-02") != "2024-01-15" || len(apollo.Tags) != 1 {
+		t.Errorf("apollo = %+v", apollo)
+	}
+	if projects[1].Budget != nil || !projects[1].Due.IsZero() {
+		t.Errorf("gemini = %+v, want empty budget and due", projects[1])
+	}
+}
+
+func TestDeleteRecords(t *testing.T) {
+	srv, client, tableID := newTestClient(t)
+	seedProjects(srv, tableID)
+	ctx := context.Background()
+
+	result, err := client.DeleteRecords(tableID).Where("{9.EX.true}").Run(ctx)
+	if err != nil {
+		t.Fatalf("DeleteRecords() error = %v", err)
+	}
+	if result.NumberDeleted() != 2 {
+		t.Errorf("NumberDeleted = %d, want 2", result.NumberDeleted())
+	}
+	if got := len(srv.Records(tableID)); got != 2 {
+		t.Errorf("remaining = %d, want 2", got)
+	}
+}
+
+func TestRecordsModifiedSince(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	srv, client, tableID := newTestClient(t, qbtest.WithClock(clock))
+	ctx := context.Background()
+
+	ids := srv.AddRecords(tableID, map[int]any{6: "Old"}, map[int]any{6: "Stale"})
+	checkpoint := now
+	now = now.Add(time.Minute)
+
+	if _, err := client.Upsert(tableID).Data(quickbase.Row(3, ids[0], 6, "Renamed"), quickbase.Row(6, "New")).Run(ctx); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+	if _, err := client.DeleteRecords(tableID).Where("{3.EX.2}").Run(ctx); err != nil {
+		t.Fatalf("DeleteRecords() error = %v", err)
+	}
+
+	result, err := client.RecordsModifiedSince().
+		From(tableID).
+		After(checkpoint.Format(time.RFC3339)).
+		IncludeDetails(true).
+		Run(ctx)
+	if err != nil {
+		t.Fatalf("RecordsModifiedSince() error = %v", err)
+	}
+	if result.Count() != 3 {
+		t.Fatalf("Count = %d, want 3", result.Count())
+	}
+
+	got := map[int]string{}
+	for _, c := range *result.Raw().JSON200.Changes {
+		got[*c.RecordId] = string(*c.ChangeType)
+	}
+	want := map[int]string{1: "MODIFY", 2: "DELETE", 3: "CREATE"}
+	for id, typ := range want {
+		if got[id] != typ {
+			t.Errorf("record %d change = %q, want %q", id, got[id], typ)
+		}
+	}
+}
+
+func TestSchemaEndpoints(t *testing.T) {
+	_, client, tableID := newTestClient(t)
+	ctx := context.Background()
+
+	fields, err := client.GetFields(tableID).Run(ctx)
+	if err != nil {
+		t.Fatalf("GetFields() error = %v", err)
+	}
+	if got := len(fields); got != 11 {
+		t.Errorf("field count = %d, want 11", got)
+	}
+
+	created, err := client.API().CreateFieldWithResponse(ctx, &generated.CreateFieldParams{TableId: tableID}, generated.CreateFieldJSONRequestBody{
+		Label:     "Notes",
+		FieldType: "text-multi-line",
+	})
+	if err != nil || created.JSON200 == nil {
+		t.Fatalf("CreateField() error = %v, status %d", err, created.StatusCode())
+	}
+	if created.JSON200.Id != 12 {
+		t.Errorf("new field ID = %d, want 12", created.JSON200.Id)
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	srv, _, tableID := newTestClient(t)
+
+	client, err := quickbase.New("testrealm",
+		quickbase.WithUserToken("wrong-token"),
+		quickbase.WithBaseURL(srv.BaseURL()),
+		quickbase.WithMaxRetries(1),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	_, err = client.Query(tableID).Run(context.Background())
+	if err == nil {
+		t.Fatal("expected authentication error")
+	}
+}
@@ -72,7 +72,12 @@
 package quickbase
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"iter"
+	"log/slog"
+	"net/http"
 	"strconv"
 	"time"
 
@@ -101,8 +106,12 @@ type (
 	ServerError         = core.ServerError
 	MissingTokenError   = core.MissingTokenError
 	ReadOnlyError       = core.ReadOnlyError
+	MappingError        = core.MappingError
 	RateLimitInfo       = core.RateLimitInfo
 
+	// Logging types
+	LogLevels = core.LogLevels
+
 	// Schema types
 	Schema         = core.Schema
 	TableSchema    = core.TableSchema
@@ -115,6 +124,19 @@ type (
 	SlidingWindowThrottle = client.SlidingWindowThrottle
 	NoOpThrottle          = client.NoOpThrottle
 	Throttle              = client.Throttle
+	ThrottleFeedback      = client.ThrottleFeedback
+	AIMDThrottle          = client.AIMDThrottle
+	AIMDThrottleOptions   = client.AIMDThrottleOptions
+	ThrottleRegistry      = client.ThrottleRegistry
+	FileThrottle          = client.FileThrottle
+	Priority              = client.Priority
+
+	// Retry policy types
+	RetryPolicy        = client.RetryPolicy
+	RetryPolicyFunc    = client.RetryPolicyFunc
+	RetryAttempt       = client.RetryAttempt
+	ExponentialBackoff = client.ExponentialBackoff
+	DecorrelatedJitter = client.DecorrelatedJitter
 
 	// Pagination types
 	PaginationMetadata = client.PaginationMetadata
@@ -125,6 +147,55 @@ type (
 	RequestInfo = client.RequestInfo
 	RetryInfo   = client.RetryInfo
 
+	// Middleware types
+	Doer       = client.Doer
+	DoerFunc   = client.DoerFunc
+	Middleware = client.Middleware
+
+	// Metrics types
+	Metrics         = client.Metrics
+	MetricsOptions  = client.MetricsOptions
+	Observation     = client.Observation
+	EndpointMetrics = client.EndpointMetrics
+	Histogram       = client.Histogram
+
+	// Cassette types
+	Cassette         = client.Cassette
+	CassetteMode     = client.CassetteMode
+	Interaction      = client.Interaction
+	CassetteRequest  = client.CassetteRequest
+	CassetteResponse = client.CassetteResponse
+
+	// Bulk types
+	BulkUpsertOptions = client.BulkUpsertOptions
+	BulkUpsertResult  = client.BulkUpsertResult
+	BulkLineError     = client.BulkLineError
+	BulkBatchError    = client.BulkBatchError
+	BulkDeleteOptions = client.BulkDeleteOptions
+	BulkDeleteResult  = client.BulkDeleteResult
+
+	// Change watching types
+	ChangeType            = client.ChangeType
+	ChangeEvent           = client.ChangeEvent
+	WatchOptions          = client.WatchOptions
+	CheckpointStore       = client.CheckpointStore
+	MemoryCheckpointStore = client.MemoryCheckpointStore
+	FileCheckpointStore   = client.FileCheckpointStore
+
+	// Export types
+	ExportFormat  = client.ExportFormat
+	HeaderStyle   = client.HeaderStyle
+	ExportOptions = client.ExportOptions
+
+	// CSV import types
+	ImportOptions   = client.ImportOptions
+	ImportError     = client.ImportError
+	ImportCellError = client.ImportCellError
+
+	// Number decoding types
+	NumberMode = client.NumberMode
+	Decimal    = core.Decimal
+
 )
 
 // Pagination type constants
@@ -134,6 +205,49 @@ const (
 	PaginationTypeNone  = client.PaginationTypeNone
 )
 
+// ErrResultSetChanged is returned by RunQueryAllParallel when totalRecords
+// changes between pages.
+var ErrResultSetChanged = client.ErrResultSetChanged
+
+// ErrTooManyRecords is returned by BulkDelete when more records match than
+// its MaxRecords option allows.
+var ErrTooManyRecords = client.ErrTooManyRecords
+
+// ErrDeletesTruncated is yielded by Watch when QuickBase omits the details of
+// some deleted records.
+var ErrDeletesTruncated = client.ErrDeletesTruncated
+
+// Change types for ChangeEvent
+const (
+	ChangeCreate = client.ChangeCreate
+	ChangeModify = client.ChangeModify
+	ChangeDelete = client.ChangeDelete
+)
+
+// Export formats and header styles for ExportOptions
+const (
+	ExportCSV   = client.ExportCSV
+	ExportJSONL = client.ExportJSONL
+
+	HeaderLabels  = client.HeaderLabels
+	HeaderAliases = client.HeaderAliases
+	HeaderIDs     = client.HeaderIDs
+)
+
+// Number modes for WithNumberMode
+const (
+	NumberFloat64 = client.NumberFloat64
+	NumberJSON    = client.NumberJSON
+	NumberDecimal = client.NumberDecimal
+)
+
+// Request priorities
+const (
+	PriorityLow    = client.PriorityLow
+	PriorityNormal = client.PriorityNormal
+	PriorityHigh   = client.PriorityHigh
+)
+
 // Option configures a Client.
 type Option func(*clientConfig)
 
@@ -290,7 +404,7 @@ func WithTicket(ticket string) Option {
 	}
 }
 
-// WithMaxRetries sets the maximum number of retry attempts.
+// WithMaxRetries sets the maximum number of attempts, including the first.
 func WithMaxRetries(n int) Option {
 	return func(c *clientConfig) {
 		c.clientOpts = append(c.clientOpts, client.WithMaxRetries(n))
@@ -318,6 +432,19 @@ func WithBackoffMultiplier(m float64) Option {
 	}
 }
 
+// WithRetryPolicy sets the policy that decides which failed requests are
+// retried and how long to wait (default ExponentialBackoff). It replaces
+// WithMaxRetries, WithRetryDelay, WithMaxRetryDelay and WithBackoffMultiplier.
+//
+// Example:
+//
+//	quickbase.WithRetryPolicy(quickbase.NeverRetryWrites(quickbase.DecorrelatedJitter{MaxAttempts: 8}))
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *clientConfig) {
+		c.clientOpts = append(c.clientOpts, client.WithRetryPolicy(policy))
+	}
+}
+
 // WithTimeout sets the request timeout.
 func WithTimeout(d time.Duration) Option {
 	return func(c *clientConfig) {
@@ -350,14 +477,34 @@ func WithIdleConnTimeout(d time.Duration) Option {
 	}
 }
 
+// WithTransport replaces the client's HTTP transport. Connection pool options
+// are ignored. Use it for proxies or test transports such as
+// qbtest.FaultInjector.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *clientConfig) {
+		c.clientOpts = append(c.clientOpts, client.WithTransport(rt))
+	}
+}
+
 // WithProactiveThrottle enables sliding window throttling.
-// QuickBase's limit is 100 requests per 10 seconds per user token.
+// QuickBase's limit is 100 requests per 10 seconds per user token, so clients
+// with the same realm and credentials share one window (see WithThrottleRegistry).
 func WithProactiveThrottle(requestsPer10Seconds int) Option {
 	return func(c *clientConfig) {
 		c.clientOpts = append(c.clientOpts, client.WithProactiveThrottle(requestsPer10Seconds))
 	}
 }
 
+// WithThrottleRegistry sets the registry WithProactiveThrottle shares its
+// throttle through (default DefaultThrottleRegistry). Use
+// NewFileThrottleRegistry to share across processes on one host, or pass nil
+// to give the client a throttle of its own.
+func WithThrottleRegistry(r *ThrottleRegistry) Option {
+	return func(c *clientConfig) {
+		c.clientOpts = append(c.clientOpts, client.WithThrottleRegistry(r))
+	}
+}
+
 // WithThrottle sets a custom throttle implementation.
 func WithThrottle(t client.Throttle) Option {
 	return func(c *clientConfig) {
@@ -365,13 +512,57 @@ func WithThrottle(t client.Throttle) Option {
 	}
 }
 
-// WithDebug enables debug logging.
+// WithMaxConcurrency limits the number of requests in flight at once.
+// Waiting requests are served by priority (see ContextWithPriority).
+// The default, 0, is unlimited.
+func WithMaxConcurrency(n int) Option {
+	return func(c *clientConfig) {
+		c.clientOpts = append(c.clientOpts, client.WithMaxConcurrency(n))
+	}
+}
+
+// WithDebug enables debug logging through the standard log package.
+// Use WithLogger for structured logging.
 func WithDebug(enabled bool) Option {
 	return func(c *clientConfig) {
 		c.clientOpts = append(c.clientOpts, client.WithDebug(enabled))
 	}
 }
 
+// WithLogger sends logs to a *slog.Logger as structured records with method,
+// path, dbid, attempt, status, ray ID and duration attributes. It takes
+// precedence over WithDebug.
+//
+// Example:
+//
+//	logger := slog.New(slog.NewJSONHandler(os.Stderr, nil))
+//	client, _ := quickbase.New(realm, quickbase.WithUserToken(token), quickbase.WithLogger(logger))
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *clientConfig) {
+		c.clientOpts = append(c.clientOpts, client.WithLogger(logger))
+	}
+}
+
+// WithSlogHandler sends logs to a slog.Handler as structured records.
+func WithSlogHandler(handler slog.Handler) Option {
+	return func(c *clientConfig) {
+		c.clientOpts = append(c.clientOpts, client.WithSlogHandler(handler))
+	}
+}
+
+// WithLogLevels sets the level of each kind of structured record
+// (default DefaultLogLevels()).
+func WithLogLevels(levels LogLevels) Option {
+	return func(c *clientConfig) {
+		c.clientOpts = append(c.clientOpts, client.WithLogLevels(levels))
+	}
+}
+
+// DefaultLogLevels returns the default level of each kind of structured record:
+// requests and XML calls at Debug, retries and token refreshes at Info, and
+// rate limits at Warn.
+var DefaultLogLevels = core.DefaultLogLevels
+
 // WithConvertDates enables/disables automatic ISO date string conversion.
 func WithConvertDates(enabled bool) Option {
 	return func(c *clientConfig) {
@@ -379,6 +570,21 @@ func WithConvertDates(enabled bool) Option {
 	}
 }
 
+// WithNumberMode sets how numbers in unwrapped records are decoded: float64
+// (the default), json.Number or Decimal.
+//
+// Example:
+//
+//	client, _ := quickbase.New("myrealm",
+//	    quickbase.WithUserToken("token"),
+//	    quickbase.WithNumberMode(quickbase.NumberJSON),
+//	)
+func WithNumberMode(mode NumberMode) Option {
+	return func(c *clientConfig) {
+		c.clientOpts = append(c.clientOpts, client.WithNumberMode(mode))
+	}
+}
+
 // WithOnRateLimit sets a callback for rate limit events.
 func WithOnRateLimit(callback func(RateLimitInfo)) Option {
 	return func(c *clientConfig) {
@@ -414,6 +620,87 @@ func WithOnRetry(callback func(RetryInfo)) Option {
 	}
 }
 
+// WithMiddleware wraps every JSON and XML API call with middleware that can
+// modify requests, short-circuit with a response, or inspect responses.
+// Middleware runs once per call, outside retries and authentication; the first
+// given is the outermost.
+//
+// Example:
+//
+//	quickbase.WithMiddleware(func(next quickbase.Doer) quickbase.Doer {
+//	    return quickbase.DoerFunc(func(req *http.Request) (*http.Response, error) {
+//	        req.Header.Set("X-Request-Source", "nightly-sync")
+//	        return next.Do(req)
+//	    })
+//	})
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(c *clientConfig) {
+		c.clientOpts = append(c.clientOpts, client.WithMiddleware(middleware...))
+	}
+}
+
+// RequestDBID returns the app or table ID a request targets. Use it in
+// middleware to attribute requests to apps or tables.
+var RequestDBID = client.RequestDBID
+
+// WithMetrics records request counts, error counts by class, retries, throttle
+// wait time and latency histograms in m, keyed by operation and table.
+//
+// Example:
+//
+//	metrics := quickbase.NewMetrics()
+//	qb, _ := quickbase.New(realm, quickbase.WithUserToken(token), quickbase.WithMetrics(metrics))
+//	expvar.Publish("quickbase", metrics)
+func WithMetrics(m *Metrics) Option {
+	return func(c *clientConfig) {
+		c.clientOpts = append(c.clientOpts, client.WithMetrics(m))
+	}
+}
+
+// NewMetrics creates a metrics collector with the default latency buckets.
+func NewMetrics() *Metrics {
+	return client.NewMetrics()
+}
+
+// NewMetricsWithOptions creates a metrics collector with custom latency
+// buckets or an OnObserve hook.
+func NewMetricsWithOptions(opts MetricsOptions) *Metrics {
+	return client.NewMetricsWithOptions(opts)
+}
+
+// DefaultLatencyBuckets are the default latency histogram upper bounds.
+var DefaultLatencyBuckets = client.DefaultLatencyBuckets
+
+// Cassette modes
+const (
+	CassetteRecord = client.CassetteRecord
+	CassetteReplay = client.CassetteReplay
+)
+
+// ErrCassetteMiss is returned in replay mode when no recorded interaction
+// matches a request.
+var ErrCassetteMiss = client.ErrCassetteMiss
+
+// NewCassette creates a cassette backed by the file at path. In replay mode
+// the file must exist; in record mode it is written by Save.
+//
+// Example:
+//
+//	cassette, _ := quickbase.NewCassette("testdata/app.cassette.json", quickbase.CassetteRecord)
+//	defer cassette.Save()
+//	qb, _ := quickbase.New(realm, quickbase.WithUserToken(token), quickbase.WithCassette(cassette))
+func NewCassette(path string, mode CassetteMode) (*Cassette, error) {
+	return client.NewCassette(path, mode)
+}
+
+// WithCassette records or replays every JSON and XML API exchange with the
+// given cassette. Credentials are scrubbed before recording.
+func WithCassette(cassette *Cassette) Option {
+	return func(c *clientConfig) {
+		c.clientOpts = append(c.clientOpts, client.WithCassette(cassette))
+	}
+}
+
 // WithBaseURL sets a custom base URL.
 func WithBaseURL(url string) Option {
 	return func(c *clientConfig) {
@@ -606,6 +893,13 @@ var (
 
 	// TransformDates recursively transforms ISO date strings to time.Time in a map.
 	TransformDates = core.TransformDates
+
+	// ParseDecimal parses a decimal number such as "-1234.50".
+	ParseDecimal = core.ParseDecimal
+
+	// NewDecimal creates a Decimal from a big.Rat, rounded to a number of
+	// fractional digits.
+	NewDecimal = core.NewDecimal
 )
 
 // NewSlidingWindowThrottle creates a new sliding window throttle.
@@ -618,6 +912,91 @@ func NewNoOpThrottle() *NoOpThrottle {
 	return client.NewNoOpThrottle()
 }
 
+// DefaultThrottleRegistry is the process-wide registry that shares proactive
+// throttles between clients with the same credentials.
+var DefaultThrottleRegistry = client.DefaultThrottleRegistry
+
+// NewThrottleRegistry creates a registry of in-process sliding window throttles.
+func NewThrottleRegistry() *ThrottleRegistry {
+	return client.NewThrottleRegistry()
+}
+
+// NewThrottleRegistryWithFactory creates a registry that builds throttles with
+// newThrottle, for custom shared throttles.
+func NewThrottleRegistryWithFactory(newThrottle func(key string, requestsPer10Seconds int) Throttle) *ThrottleRegistry {
+	return client.NewThrottleRegistryWithFactory(newThrottle)
+}
+
+// NewFileThrottleRegistry creates a registry of file-backed throttles stored in
+// dir, shared by processes on one host (Unix only).
+func NewFileThrottleRegistry(dir string) *ThrottleRegistry {
+	return client.NewFileThrottleRegistry(dir)
+}
+
+// NewFileThrottle creates a sliding window throttle stored in a locked file.
+func NewFileThrottle(path string, requestsPer10Seconds int) *FileThrottle {
+	return client.NewFileThrottle(path, requestsPer10Seconds)
+}
+
+// NewMemoryCheckpointStore creates an in-memory checkpoint store for Watch.
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return client.NewMemoryCheckpointStore()
+}
+
+// NewTypedValue wraps a field value for typed access. fieldType is the
+// QuickBase field type, or "" if unknown.
+func NewTypedValue(v any, fieldType string) TypedValue {
+	return client.NewTypedValue(v, fieldType)
+}
+
+// NewTypedRecord wraps a record for typed access, with field types by field
+// ID (may be nil).
+func NewTypedRecord(record Record, fieldTypes map[int]string) TypedRecord {
+	return client.NewTypedRecord(record, fieldTypes)
+}
+
+// NewFileCheckpointStore creates a checkpoint store for Watch backed by a JSON
+// file, so a restarted process resumes where it left off.
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return client.NewFileCheckpointStore(path)
+}
+
+// NewAIMDThrottle creates a throttle that adapts its rate to 429 feedback.
+func NewAIMDThrottle(opts AIMDThrottleOptions) *AIMDThrottle {
+	return client.NewAIMDThrottle(opts)
+}
+
+// NeverRetryWrites wraps a retry policy so that requests which modify data are
+// not retried after a network error or 5xx response. 429s are still retried.
+func NeverRetryWrites(policy RetryPolicy) RetryPolicy {
+	return client.NeverRetryWrites(policy)
+}
+
+// NeverRetry is a retry policy that returns the first failure.
+var NeverRetry = client.NeverRetry
+
+// ContextWithRetryPolicy returns a context that overrides the client's retry
+// policy for requests made with it.
+//
+// Example:
+//
+//	ctx := quickbase.ContextWithRetryPolicy(r.Context(), quickbase.NeverRetry)
+//	app, err := qb.GetApp(appID).Run(ctx)
+func ContextWithRetryPolicy(ctx context.Context, policy RetryPolicy) context.Context {
+	return client.ContextWithRetryPolicy(ctx, policy)
+}
+
+// ContextWithPriority returns a context whose requests wait for the throttle
+// and concurrency slots with the given priority. Higher priorities go first.
+//
+// Example:
+//
+//	ctx := quickbase.ContextWithPriority(r.Context(), quickbase.PriorityHigh)
+//	app, err := qb.GetApp(appID).Run(ctx)
+func ContextWithPriority(ctx context.Context, p Priority) context.Context {
+	return client.ContextWithPriority(ctx, p)
+}
+
 // NewSchema creates a new SchemaBuilder for fluent schema definition.
 //
 // Example:
@@ -965,6 +1344,48 @@ type (
 
 	// SortSpec specifies a sort field and order for RunQuery.
 	SortSpec = client.SortSpec
+
+	// QueryBuilder provides a fluent API for building RunQuery requests.
+	QueryBuilder = client.QueryBuilder
+
+	// UpsertBuilder provides a fluent API for upserting records.
+	UpsertBuilder = client.UpsertBuilder
+
+	// User is a user field value for struct tag mapping.
+	User = client.User
+
+	// TypedValue reads a field value as the Go type of its field type.
+	TypedValue = client.TypedValue
+
+	// TypedRecord is a record whose fields are read as TypedValues.
+	TypedRecord = client.TypedRecord
+
+	// FileAttachment is a file attachment field value.
+	FileAttachment = client.FileAttachment
+
+	// FileVersion is one uploaded version of a file attachment.
+	FileVersion = client.FileVersion
+
+	// Address is an address field value.
+	Address = client.Address
+
+	// Condition is a type-safe where clause built with F, And and Or.
+	Condition = core.Condition
+
+	// FieldRef is the field side of a where condition. Create one with F.
+	FieldRef = core.FieldRef
+
+	// WhereNode is a node in a parsed where clause (*WhereClause or *WhereGroup).
+	WhereNode = core.WhereNode
+
+	// WhereClause is a parsed {field.OP.value} comparison.
+	WhereClause = core.WhereClause
+
+	// WhereGroup is parsed clauses joined by AND or OR.
+	WhereGroup = core.WhereGroup
+
+	// EvaluateOptions configures EvaluateWhereWithOptions.
+	EvaluateOptions = core.EvaluateOptions
 )
 
 // FieldType is the type of a field for CreateField
@@ -1028,6 +1449,69 @@ var UnwrapRecords = client.UnwrapRecords
 // UnwrapRecord converts a single QuickbaseRecord to map[string]any.
 var UnwrapRecord = client.UnwrapRecord
 
+// UnwrapRecordsAs is UnwrapRecords with numbers decoded as json.Number or
+// Decimal instead of float64.
+var UnwrapRecordsAs = client.UnwrapRecordsAs
+
+// --- Struct Tag Mapping ---
+
+// QueryInto executes a query and decodes all records into structs using `qb`
+// struct tags. Tags name fields by ID or alias: `qb:"6"` or `qb:"name"`.
+// If the query has no Select, the struct's tagged fields are selected.
+//
+// Example:
+//
+//	type Project struct {
+//	    ID     int        `qb:"3"`
+//	    Name   string     `qb:"name"`
+//	    Budget *float64   `qb:"budget,omitempty"`
+//	    Due    time.Time  `qb:"dueDate,date"`
+//	}
+//
+//	projects, err := quickbase.QueryInto[Project](ctx, qb.Query("projects").
+//	    Where("{status.EX.'Active'}"))
+func QueryInto[T any](ctx context.Context, b *QueryBuilder) ([]T, error) {
+	return client.QueryInto[T](ctx, b)
+}
+
+// IterInto executes a query and returns an iterator that decodes each record
+// into a struct using `qb` struct tags. Pages are fetched lazily, so large
+// tables can be streamed without holding every record in memory.
+//
+// Example:
+//
+//	for project, err := range quickbase.IterInto[Project](ctx, qb.Query("projects")) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    export(project)
+//	}
+func IterInto[T any](ctx context.Context, b *QueryBuilder) iter.Seq2[T, error] {
+	return client.IterInto[T](ctx, b)
+}
+
+// UpsertFrom sets upsert data from structs using `qb` struct tags.
+// Fields tagged omitempty are left out when empty; nil pointers otherwise clear the field.
+//
+// Example:
+//
+//	result, err := quickbase.UpsertFrom(qb.Upsert("projects"), projects...).
+//	    MergeFieldId(6).
+//	    Run(ctx)
+func UpsertFrom[T any](b *UpsertBuilder, items ...T) *UpsertBuilder {
+	return client.UpsertFrom(b, items...)
+}
+
+// DecodeRecords converts raw records into structs using `qb` struct tags.
+func DecodeRecords[T any](c *Client, table string, records []Record) ([]T, error) {
+	return client.DecodeRecords[T](c, table, records)
+}
+
+// EncodeRecords converts structs into raw records using `qb` struct tags.
+func EncodeRecords[T any](c *Client, table string, items []T) ([]Record, error) {
+	return client.EncodeRecords(c, table, items)
+}
+
 // Deref returns the value of a pointer, or zero value if nil.
 // Use this to safely access optional (pointer) fields in generated types.
 //
@@ -1094,16 +1578,14 @@ func Value(v any) FieldValue {
 	switch val := v.(type) {
 	case string:
 		fv.FromFieldValueValue0(val)
-	case int:
-		fv.FromFieldValueValue1(float32(val))
-	case int32:
-		fv.FromFieldValueValue1(float32(val))
-	case int64:
-		fv.FromFieldValueValue1(float32(val))
+	case int, int32, int64, float64, json.Number, Decimal:
+		// Numbers are written with every digit; the union's float32 would
+		// round anything past about 7 significant digits
+		if data, err := json.Marshal(val); err == nil {
+			fv.UnmarshalJSON(data)
+		}
 	case float32:
 		fv.FromFieldValueValue1(val)
-	case float64:
-		fv.FromFieldValueValue1(float32(val))
 	case bool:
 		fv.FromFieldValueValue2(val)
 	case []string:
@@ -1237,8 +1719,17 @@ type WhereUnion = generated.RunQueryJSONBody_Where
 //	    From:   tableId,
 //	    Where:  quickbase.Where("{6.EX.'Active'}"),
 //	})
-func Where(query string) *WhereUnion {
-	where, err := client.StringToWhereUnion(query)
+//
+// Where also accepts a [Condition] built with [F]. Aliases in conditions are
+// written as quoted field references and resolved by RunQuery when a schema is configured:
+//
+//	Where: quickbase.Where(quickbase.F("status").EX("Active"))
+func Where(query any) *WhereUnion {
+	s, ok := whereQueryString(query)
+	if !ok {
+		return nil
+	}
+	where, err := client.StringToWhereUnion(s)
 	if err != nil {
 		return nil
 	}
@@ -1254,14 +1745,101 @@ type DeleteWhereUnion = generated.DeleteRecordsJSONBody_Where
 // Example:
 //
 //	result, _ := client.DeleteRecords(ctx, tableId, quickbase.DeleteWhere("{6.EX.'Deleted'}"))
-func DeleteWhere(query string) DeleteWhereUnion {
-	where, err := client.StringToDeleteWhereUnion(query)
+//
+// DeleteWhere also accepts a [Condition]. The raw DeleteRecords request does not
+// resolve aliases, so use field IDs here, or pass the condition to
+// client.DeleteRecords(table).Where(cond), which resolves them through the schema.
+func DeleteWhere(query any) DeleteWhereUnion {
+	s, ok := whereQueryString(query)
+	if !ok {
+		return DeleteWhereUnion{}
+	}
+	where, err := client.StringToDeleteWhereUnion(s)
 	if err != nil {
 		return DeleteWhereUnion{}
 	}
 	return where
 }
 
+// whereQueryString converts a where string or Condition to a query string.
+func whereQueryString(query any) (string, bool) {
+	switch q := query.(type) {
+	case string:
+		return q, true
+	case Condition:
+		return q.String(), q.Err() == nil
+	case *Condition:
+		if q == nil {
+			return "", false
+		}
+		return q.String(), q.Err() == nil
+	}
+	return "", false
+}
+
+// --- Where Clause Builder ---
+
+// F starts a type-safe where condition on a field ID (int) or alias (string).
+// Values are escaped and dates formatted for QuickBase; combine conditions with
+// [Condition.And], [Condition.Or], [And] and [Or].
+//
+// Example:
+//
+//	records, err := client.Query("projects").
+//	    Where(quickbase.F("status").EX("Open").And(quickbase.F("dueDate").OBF(time.Now()))).
+//	    Run(ctx)
+func F(field any) FieldRef {
+	return core.F(field)
+}
+
+// And combines conditions so that all must match.
+func And(conds ...Condition) Condition {
+	return core.And(conds...)
+}
+
+// Or combines conditions so that any may match.
+func Or(conds ...Condition) Condition {
+	return core.Or(conds...)
+}
+
+// --- Where Clause Parsing ---
+
+// ParseWhere parses a where clause into an AST. Syntax errors and unknown
+// operators are returned as a *SchemaError with the offending position.
+//
+// Example:
+//
+//	node, err := quickbase.ParseWhere("{'status'.EX.'Open'} AND {7.GT.100}")
+//	fmt.Println(quickbase.PrettyWhere(node))
+var ParseWhere = core.ParseWhere
+
+// ValidateWhere checks a where clause and rewrites field aliases to IDs using
+// the schema (which may be nil if the query only uses field IDs).
+// RunQuery and DeleteRecords apply this automatically before sending a request.
+var ValidateWhere = core.TransformWhere
+
+// FormatWhere renders a parsed where clause in canonical compact form.
+var FormatWhere = core.FormatWhere
+
+// PrettyWhere renders a parsed where clause with one clause per line.
+var PrettyWhere = core.PrettyWhere
+
+// EvaluateWhere reports whether an unwrapped record matches a where clause,
+// using QuickBase comparison semantics. Use it to filter cached results or
+// check webhook payloads without a round trip.
+//
+// Example:
+//
+//	ok, err := quickbase.EvaluateWhere(record, "{6.CT.'apo'} AND {8.IR.'last 7 days'}")
+var EvaluateWhere = core.EvaluateWhere
+
+// EvaluateWhereWithOptions is EvaluateWhere with field types, a reference time
+// and a schema for alias-keyed records.
+var EvaluateWhereWithOptions = core.EvaluateWhereWithOptions
+
+// MatchWhere evaluates a clause parsed with ParseWhere against a record.
+var MatchWhere = core.MatchWhere
+
 // --- Query Options Helper ---
 
 // Options creates a QueryOptions with top (limit) and skip (offset).
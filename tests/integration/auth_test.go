@@ -15,7 +15,7 @@ func TestUserTokenAuth(t *testing.T) {
 	testCtx := getTestContext(t)
 
 	t.Run("works with valid user token", func(t *testing.T) {
-		client, err := quickbase.New(qbRealm, quickbase.WithUserToken(qbUserToken))
+		client, err := newClient(quickbase.WithUserToken(qbUserToken))
 		if err != nil {
 			t.Fatalf("Failed to create client: %v", err)
 		}
@@ -33,7 +33,7 @@ func TestUserTokenAuth(t *testing.T) {
 	})
 
 	t.Run("fails with invalid user token", func(t *testing.T) {
-		client, err := quickbase.New(qbRealm, quickbase.WithUserToken("invalid_token_12345"))
+		client, err := newClient(quickbase.WithUserToken("invalid_token_12345"))
 		if err != nil {
 			t.Fatalf("Failed to create client: %v", err)
 		}
@@ -53,7 +53,7 @@ func TestClientOptions(t *testing.T) {
 	testCtx := getTestContext(t)
 
 	t.Run("works with debug enabled", func(t *testing.T) {
-		client, err := quickbase.New(qbRealm,
+		client, err := newClient(
 			quickbase.WithUserToken(qbUserToken),
 			quickbase.WithDebug(true),
 		)
@@ -71,7 +71,7 @@ func TestClientOptions(t *testing.T) {
 	})
 
 	t.Run("works with proactive throttle", func(t *testing.T) {
-		client, err := quickbase.New(qbRealm,
+		client, err := newClient(
 			quickbase.WithUserToken(qbUserToken),
 			quickbase.WithProactiveThrottle(100),
 		)
@@ -92,7 +92,7 @@ func TestClientOptions(t *testing.T) {
 	})
 
 	t.Run("works with custom retry settings", func(t *testing.T) {
-		client, err := quickbase.New(qbRealm,
+		client, err := newClient(
 			quickbase.WithUserToken(qbUserToken),
 			quickbase.WithMaxRetries(5),
 		)
@@ -114,6 +114,7 @@ func TestClientOptions(t *testing.T) {
 // Requires QB_USERNAME and QB_PASSWORD environment variables.
 func TestTicketAuth(t *testing.T) {
 	skipIfNoCredentials(t)
+	skipIfOffline(t)
 
 	username := os.Getenv("QB_USERNAME")
 	password := os.Getenv("QB_PASSWORD")
@@ -10,6 +10,7 @@ import (
 // TestRelationships tests all relationship CRUD operations
 func TestRelationships(t *testing.T) {
 	skipIfNoCredentials(t)
+	skipIfOffline(t)
 	ctx := context.Background()
 	client := getTestClient(t)
 	testCtx := getTestContext(t)
@@ -155,6 +156,7 @@ func TestRelationships(t *testing.T) {
 // TestGetRelationships_NoRelationships verifies behavior on table with no relationships
 func TestGetRelationships_NoRelationships(t *testing.T) {
 	skipIfNoCredentials(t)
+	skipIfOffline(t)
 	ctx := context.Background()
 	client := getTestClient(t)
 	testCtx := getTestContext(t)
@@ -3,6 +3,15 @@
 // These tests run against a real QuickBase instance and require credentials.
 // Set QB_REALM and QB_USER_TOKEN environment variables to run these tests.
 //
+// Set QB_OFFLINE=1 to run the scenarios against the in-process fake server
+// from the qbtest package instead. No credentials are needed; tests that rely
+// on endpoints the fake does not emulate (XML API, relationships, ticket auth)
+// are skipped.
+//
+// Set QB_CASSETTE=record (with credentials) to record every request to
+// testdata/integration.cassette.json, and QB_CASSETTE=replay to run the
+// recorded scenarios offline without credentials.
+//
 // The tests create an ephemeral app for each test run and clean it up afterward.
 //
 // Run with: go test -v ./tests/integration/...
@@ -20,6 +29,7 @@ import (
 
 	"github.com/DrewBradfordXYZ/quickbase-go/v2"
 	"github.com/DrewBradfordXYZ/quickbase-go/v2/generated"
+	"github.com/DrewBradfordXYZ/quickbase-go/v2/qbtest"
 	"github.com/joho/godotenv"
 )
 
@@ -27,8 +37,13 @@ import (
 const (
 	envRealm     = "QB_REALM"
 	envUserToken = "QB_USER_TOKEN"
+	envOffline   = "QB_OFFLINE"
+	envCassette  = "QB_CASSETTE"
 )
 
+// Path to the cassette used by QB_CASSETTE
+const cassettePath = "testdata/integration.cassette.json"
+
 // TestContext holds shared test context
 type TestContext struct {
 	AppID           string `json:"appId"`
@@ -50,6 +65,12 @@ var (
 	qbUserToken string
 	testCtx     *TestContext
 	testClient  *quickbase.Client
+
+	// offlineServer is set when running against the qbtest fake server
+	offlineServer *qbtest.Server
+
+	// cassette is set when recording or replaying with QB_CASSETTE
+	cassette *quickbase.Cassette
 )
 
 // hasCredentials returns true if credentials are available
@@ -64,6 +85,30 @@ func skipIfNoCredentials(t *testing.T) {
 	}
 }
 
+// skipIfOffline skips the test when running against the fake server
+func skipIfOffline(t *testing.T) {
+	if offlineServer != nil {
+		t.Skip("Skipping integration test: not supported by the offline fake server")
+	}
+}
+
+// replaying returns true when serving responses from the cassette
+func replaying() bool {
+	return cassette != nil && cassette.Mode() == quickbase.CassetteReplay
+}
+
+// newClient creates a client for the test realm, pointed at the fake server when
+// offline and recording or replaying when a cassette is set
+func newClient(opts ...quickbase.Option) (*quickbase.Client, error) {
+	if offlineServer != nil {
+		opts = append(opts, quickbase.WithBaseURL(offlineServer.BaseURL()))
+	}
+	if cassette != nil {
+		opts = append(opts, quickbase.WithCassette(cassette))
+	}
+	return quickbase.New(qbRealm, opts...)
+}
+
 // TestMain handles setup and teardown for all integration tests
 func TestMain(m *testing.M) {
 	// Load .env file from project root (try multiple locations)
@@ -82,6 +127,36 @@ func TestMain(m *testing.M) {
 	qbRealm = os.Getenv(envRealm)
 	qbUserToken = os.Getenv(envUserToken)
 
+	// Offline mode: run against the in-process fake server
+	if os.Getenv(envOffline) != "" {
+		qbRealm = "offline"
+		qbUserToken = "offline-token"
+		offlineServer = qbtest.NewServer(qbtest.WithUserToken(qbUserToken))
+		fmt.Printf("🔌 Offline mode: using fake server at %s\n", offlineServer.BaseURL())
+	}
+
+	// Cassette mode: record against the realm, or replay without credentials
+	switch mode := os.Getenv(envCassette); mode {
+	case "":
+	case "record", "replay":
+		cassetteMode := quickbase.CassetteRecord
+		if mode == "replay" {
+			cassetteMode = quickbase.CassetteReplay
+			qbRealm = "replay"
+			qbUserToken = "replay-token"
+		}
+		var err error
+		cassette, err = quickbase.NewCassette(cassettePath, cassetteMode)
+		if err != nil {
+			fmt.Printf("Failed to open cassette: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("📼 Cassette mode: %s %s\n", mode, cassettePath)
+	default:
+		fmt.Printf("Invalid %s=%q (want record or replay)\n", envCassette, mode)
+		os.Exit(1)
+	}
+
 	if !hasCredentials() {
 		fmt.Println("⚠️  No credentials - skipping integration test setup")
 		fmt.Println("   Set QB_REALM and QB_USER_TOKEN to run integration tests")
@@ -90,7 +165,7 @@ func TestMain(m *testing.M) {
 
 	// Create test client
 	var err error
-	testClient, err = quickbase.New(qbRealm, quickbase.WithUserToken(qbUserToken))
+	testClient, err = newClient(quickbase.WithUserToken(qbUserToken))
 	if err != nil {
 		fmt.Printf("Failed to create client: %v\n", err)
 		os.Exit(1)
@@ -107,6 +182,15 @@ func TestMain(m *testing.M) {
 
 	// Teardown: leave app for inspection, will be cleaned up next run
 	teardown()
+	if offlineServer != nil {
+		offlineServer.Close()
+	}
+	if cassette != nil {
+		if err := cassette.Save(); err != nil {
+			fmt.Printf("Failed to save cassette: %v\n", err)
+			code = 1
+		}
+	}
 
 	os.Exit(code)
 }
@@ -115,11 +199,17 @@ func setup() error {
 	ctx := context.Background()
 
 	// Auto-cleanup: Delete orphaned app from previous failed run
-	fmt.Println("🧹 Checking for orphaned test apps...")
-	cleanupOrphanedApp(ctx)
+	if offlineServer == nil && !replaying() {
+		fmt.Println("🧹 Checking for orphaned test apps...")
+		cleanupOrphanedApp(ctx)
+	}
 
 	// Create fresh test app
+	// (with a fixed name when recording, so replayed requests match)
 	appName := fmt.Sprintf("%s%d", testAppPrefix, time.Now().UnixMilli())
+	if cassette != nil {
+		appName = testAppPrefix + "cassette"
+	}
 	fmt.Printf("📱 Creating test app: %s\n", appName)
 
 	assignToken := true
@@ -193,8 +283,10 @@ func setup() error {
 	}
 
 	// Write context to file for inspection/debugging
-	if err := writeTestContext(testCtx); err != nil {
-		fmt.Printf("   Warning: could not write test context: %v\n", err)
+	if offlineServer == nil && !replaying() {
+		if err := writeTestContext(testCtx); err != nil {
+			fmt.Printf("   Warning: could not write test context: %v\n", err)
+		}
 	}
 
 	fmt.Println("✅ Test environment ready")
@@ -202,7 +294,7 @@ func setup() error {
 }
 
 func teardown() {
-	if testCtx == nil {
+	if testCtx == nil || offlineServer != nil || replaying() {
 		return
 	}
 	fmt.Printf("\n📌 Test app preserved for inspection: %s\n", testCtx.AppID)
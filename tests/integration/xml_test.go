@@ -20,6 +20,7 @@ func getXMLClient(t *testing.T) *xml.Client {
 // TestXMLGrantedDBs tests the GrantedDBs API
 func TestXMLGrantedDBs(t *testing.T) {
 	skipIfNoCredentials(t)
+	skipIfOffline(t)
 	ctx := context.Background()
 	xmlClient := getXMLClient(t)
 
@@ -73,6 +74,7 @@ func TestXMLGrantedDBs(t *testing.T) {
 // TestXMLGetDBInfo tests the GetDBInfo API
 func TestXMLGetDBInfo(t *testing.T) {
 	skipIfNoCredentials(t)
+	skipIfOffline(t)
 	ctx := context.Background()
 	xmlClient := getXMLClient(t)
 	testCtx := getTestContext(t)
@@ -108,6 +110,7 @@ func TestXMLGetDBInfo(t *testing.T) {
 // TestXMLGetNumRecords tests the GetNumRecords API
 func TestXMLGetNumRecords(t *testing.T) {
 	skipIfNoCredentials(t)
+	skipIfOffline(t)
 	ctx := context.Background()
 	xmlClient := getXMLClient(t)
 	testCtx := getTestContext(t)
@@ -131,6 +134,7 @@ func TestXMLGetNumRecords(t *testing.T) {
 // TestXMLDoQueryCount tests the DoQueryCount API
 func TestXMLDoQueryCount(t *testing.T) {
 	skipIfNoCredentials(t)
+	skipIfOffline(t)
 	ctx := context.Background()
 	xmlClient := getXMLClient(t)
 	testCtx := getTestContext(t)
@@ -167,6 +171,7 @@ func TestXMLDoQueryCount(t *testing.T) {
 // TestXMLGetRoleInfo tests the GetRoleInfo API
 func TestXMLGetRoleInfo(t *testing.T) {
 	skipIfNoCredentials(t)
+	skipIfOffline(t)
 	ctx := context.Background()
 	xmlClient := getXMLClient(t)
 	testCtx := getTestContext(t)
@@ -191,6 +196,7 @@ func TestXMLGetRoleInfo(t *testing.T) {
 // TestXMLUserRoles tests the UserRoles API
 func TestXMLUserRoles(t *testing.T) {
 	skipIfNoCredentials(t)
+	skipIfOffline(t)
 	ctx := context.Background()
 	xmlClient := getXMLClient(t)
 	testCtx := getTestContext(t)
@@ -219,6 +225,7 @@ func TestXMLUserRoles(t *testing.T) {
 // TestXMLGetSchema tests the GetSchema API
 func TestXMLGetSchema(t *testing.T) {
 	skipIfNoCredentials(t)
+	skipIfOffline(t)
 	ctx := context.Background()
 	xmlClient := getXMLClient(t)
 	testCtx := getTestContext(t)
@@ -257,6 +264,7 @@ func TestXMLGetSchema(t *testing.T) {
 // TestXMLGetRecordInfo tests the GetRecordInfo API
 func TestXMLGetRecordInfo(t *testing.T) {
 	skipIfNoCredentials(t)
+	skipIfOffline(t)
 	ctx := context.Background()
 	xmlClient := getXMLClient(t)
 	testCtx := getTestContext(t)
@@ -288,6 +296,7 @@ func TestXMLGetRecordInfo(t *testing.T) {
 // TestXMLDBVars tests the GetDBVar and SetDBVar APIs
 func TestXMLDBVars(t *testing.T) {
 	skipIfNoCredentials(t)
+	skipIfOffline(t)
 	ctx := context.Background()
 	xmlClient := getXMLClient(t)
 	testCtx := getTestContext(t)
@@ -318,6 +327,7 @@ func TestXMLDBVars(t *testing.T) {
 // NOTE: This API cannot be called with user tokens, requires ticket auth.
 func TestXMLGetAppDTMInfo(t *testing.T) {
 	skipIfNoCredentials(t)
+	skipIfOffline(t)
 
 	username := os.Getenv("QB_USERNAME")
 	password := os.Getenv("QB_PASSWORD")
@@ -358,6 +368,7 @@ func TestXMLGetAppDTMInfo(t *testing.T) {
 // NOTE: This API cannot be called with user tokens, requires ticket auth.
 func TestXMLFindDBByName(t *testing.T) {
 	skipIfNoCredentials(t)
+	skipIfOffline(t)
 
 	username := os.Getenv("QB_USERNAME")
 	password := os.Getenv("QB_PASSWORD")
@@ -398,6 +409,7 @@ func TestXMLFindDBByName(t *testing.T) {
 // TestXMLGenResultsTable tests the GenResultsTable API
 func TestXMLGenResultsTable(t *testing.T) {
 	skipIfNoCredentials(t)
+	skipIfOffline(t)
 	ctx := context.Background()
 	xmlClient := getXMLClient(t)
 	testCtx := getTestContext(t)
@@ -452,6 +464,7 @@ func TestXMLGenResultsTable(t *testing.T) {
 // TestXMLGetRecordAsHTML tests the GetRecordAsHTML API
 func TestXMLGetRecordAsHTML(t *testing.T) {
 	skipIfNoCredentials(t)
+	skipIfOffline(t)
 	ctx := context.Background()
 	xmlClient := getXMLClient(t)
 	testCtx := getTestContext(t)
@@ -365,6 +365,9 @@ type importFromCSVResponse struct {
 // For adds, leave the record ID column empty. For updates, include the
 // key field (usually field 3, Record ID#) in the clist and CSV data.
 //
+// The JSON API importer, Client.ImportCSV in the client package, matches
+// columns by label and converts values to each field's type before sending.
+//
 // Example - Add new records:
 //
 //	result, err := xmlClient.ImportFromCSV(ctx, tableId, xml.ImportFromCSVOptions{